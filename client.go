@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/lifedraft/targetprocess-cli/internal/api"
 )
@@ -19,8 +20,9 @@ type Client struct {
 type Option func(*clientConfig)
 
 type clientConfig struct {
-	httpClient *http.Client
-	debug      bool
+	httpClient   *http.Client
+	debug        bool
+	maxRetryWait time.Duration
 }
 
 // WithHTTPClient sets a custom HTTP client for the API client.
@@ -37,6 +39,14 @@ func WithDebug(debug bool) Option {
 	}
 }
 
+// WithMaxRetryWait caps how long a rate-limited request waits when the server
+// sends a Retry-After header. The default is 60 seconds.
+func WithMaxRetryWait(d time.Duration) Option {
+	return func(cfg *clientConfig) {
+		cfg.maxRetryWait = d
+	}
+}
+
 // NewClient creates a new Targetprocess API client.
 // The domain should be your Targetprocess subdomain (e.g., "yourcompany.tpondemand.com").
 func NewClient(domain, token string, opts ...Option) (*Client, error) {
@@ -46,6 +56,9 @@ func NewClient(domain, token string, opts ...Option) (*Client, error) {
 	}
 
 	ic := api.NewClient(domain, token, cfg.debug)
+	if cfg.maxRetryWait > 0 {
+		ic.MaxRetryWait = cfg.maxRetryWait
+	}
 	if cfg.httpClient != nil {
 		ic.HTTPClient = cfg.httpClient
 	}
@@ -106,6 +119,19 @@ func (c *Client) Query(ctx context.Context, entityType string, params QueryParam
 	})
 }
 
+// QueryAll executes a v2 API query and follows "next" links until every
+// page has been fetched, returning the combined items. Take sets the page
+// size.
+func (c *Client) QueryAll(ctx context.Context, entityType string, params QueryParams) ([]Entity, error) {
+	return c.internal.QueryV2All(ctx, entityType, api.V2Params{
+		Where:   params.Where,
+		Select:  params.Select,
+		OrderBy: params.OrderBy,
+		Take:    params.Take,
+		Skip:    params.Skip,
+	})
+}
+
 // QueryEntity queries a single entity by ID via the v2 API.
 func (c *Client) QueryEntity(ctx context.Context, entityType string, id int, selectExpr string) (json.RawMessage, error) {
 	return c.internal.QueryV2Entity(ctx, entityType, id, selectExpr)
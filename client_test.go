@@ -175,6 +175,28 @@ func TestClientQuery(t *testing.T) {
 	}
 }
 
+func TestClientQueryAll(t *testing.T) {
+	c, srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("skip") == "1" {
+			writeJSON(w, map[string]any{"items": []map[string]any{{"id": 2}}})
+			return
+		}
+		writeJSON(w, map[string]any{
+			"items": []map[string]any{{"id": 1}},
+			"next":  "/api/v2/UserStory?take=1&skip=1",
+		})
+	})
+	defer srv.Close()
+
+	items, err := c.QueryAll(context.Background(), "UserStory", QueryParams{Select: "id", Take: 1})
+	if err != nil {
+		t.Fatalf("QueryAll() error = %v", err)
+	}
+	if len(items) != 2 || items[1]["id"] != float64(2) {
+		t.Errorf("QueryAll() = %v, want items 1 and 2", items)
+	}
+}
+
 func TestClientAPIError(t *testing.T) {
 	c, srv := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
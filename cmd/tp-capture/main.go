@@ -6,6 +6,10 @@
 //
 // It will make a set of representative API calls, record the responses,
 // redact all sensitive data, and write simulation files to testdata/simulations/.
+//
+// The comment and entity scenarios write to the instance: they create
+// throwaway comments and a user story named "tp-capture throwaway" and
+// delete them again once each scenario has been recorded.
 package main
 
 import (
@@ -51,7 +55,7 @@ func run() error {
 
 	scenarios := []struct {
 		name    string
-		capture func(ctx context.Context, client *api.Client, rt *testutil.RecordingTransport) error
+		capture func(ctx context.Context, client *api.Client, s *scratch) error
 	}{
 		{"query_collection", captureQueryCollection},
 		{"query_single", captureQuerySingle},
@@ -59,6 +63,19 @@ func run() error {
 		{"entity_search", captureEntitySearch},
 		{"inspect_types", captureInspectTypes},
 		{"inspect_properties", captureInspectProperties},
+		{"comment_list", captureCommentList},
+		{"comment_add", captureCommentAdd},
+		{"comment_delete", captureCommentDelete},
+		{"entity_create", captureEntityCreate},
+		{"entity_update", captureEntityUpdate},
+	}
+
+	// Setup and cleanup go through an unrecorded client so fixtures hold
+	// only the calls under test.
+	setup := &api.Client{
+		BaseURL:    "https://" + cfg.Domain,
+		Token:      cfg.Token,
+		HTTPClient: &http.Client{},
 	}
 
 	for _, sc := range scenarios {
@@ -73,7 +90,10 @@ func run() error {
 			HTTPClient: &http.Client{Transport: rt},
 		}
 
-		if err := sc.capture(ctx, client, rt); err != nil {
+		s := &scratch{client: setup}
+		err := sc.capture(ctx, client, s)
+		s.cleanup()
+		if err != nil {
 			fmt.Printf("FAILED: %v\n", err)
 			continue
 		}
@@ -101,7 +121,7 @@ func run() error {
 	return nil
 }
 
-func captureQueryCollection(ctx context.Context, client *api.Client, _ *testutil.RecordingTransport) error {
+func captureQueryCollection(ctx context.Context, client *api.Client, _ *scratch) error {
 	_, err := client.QueryV2(ctx, "UserStory", api.V2Params{
 		Select: "id,name,entityState.name as state",
 		Where:  "entityState.isFinal!=true",
@@ -110,7 +130,7 @@ func captureQueryCollection(ctx context.Context, client *api.Client, _ *testutil
 	return err
 }
 
-func captureQuerySingle(ctx context.Context, client *api.Client, _ *testutil.RecordingTransport) error {
+func captureQuerySingle(ctx context.Context, client *api.Client, _ *scratch) error {
 	id, err := findFirstUserStoryID(ctx, client)
 	if err != nil {
 		return err
@@ -119,7 +139,7 @@ func captureQuerySingle(ctx context.Context, client *api.Client, _ *testutil.Rec
 	return err
 }
 
-func captureEntityGet(ctx context.Context, client *api.Client, _ *testutil.RecordingTransport) error {
+func captureEntityGet(ctx context.Context, client *api.Client, _ *scratch) error {
 	id, err := findFirstUserStoryID(ctx, client)
 	if err != nil {
 		return err
@@ -128,7 +148,7 @@ func captureEntityGet(ctx context.Context, client *api.Client, _ *testutil.Recor
 	return err
 }
 
-func captureEntitySearch(ctx context.Context, client *api.Client, _ *testutil.RecordingTransport) error {
+func captureEntitySearch(ctx context.Context, client *api.Client, _ *scratch) error {
 	_, err := client.QueryV2(ctx, "UserStory", api.V2Params{
 		Select: "id,name,entityState.name as state",
 		Where:  "entityState.isFinal!=true",
@@ -137,16 +157,173 @@ func captureEntitySearch(ctx context.Context, client *api.Client, _ *testutil.Re
 	return err
 }
 
-func captureInspectTypes(ctx context.Context, client *api.Client, _ *testutil.RecordingTransport) error {
+func captureInspectTypes(ctx context.Context, client *api.Client, _ *scratch) error {
 	_, err := client.GetMetaIndex(ctx)
 	return err
 }
 
-func captureInspectProperties(ctx context.Context, client *api.Client, _ *testutil.RecordingTransport) error {
+func captureInspectProperties(ctx context.Context, client *api.Client, _ *scratch) error {
 	_, err := client.GetTypeMeta(ctx, "UserStory")
 	return err
 }
 
+func captureCommentList(ctx context.Context, client *api.Client, s *scratch) error {
+	storyID, err := findFirstUserStoryID(ctx, s.client)
+	if err != nil {
+		return err
+	}
+	if _, err := s.create(ctx, "Comment", commentFields(storyID)); err != nil {
+		return err
+	}
+	where := fmt.Sprintf("General.Id eq %d", storyID)
+	_, err = client.SearchEntities(ctx, "Comment", where, []string{"Description", "CreateDate", "Owner", "ParentId"}, 0, nil)
+	return err
+}
+
+func captureCommentAdd(ctx context.Context, client *api.Client, s *scratch) error {
+	storyID, err := findFirstUserStoryID(ctx, s.client)
+	if err != nil {
+		return err
+	}
+	entity, err := client.CreateEntity(ctx, "Comment", commentFields(storyID))
+	if err != nil {
+		return err
+	}
+	s.track("Comment", entityID(entity))
+	return nil
+}
+
+func captureCommentDelete(ctx context.Context, client *api.Client, s *scratch) error {
+	storyID, err := findFirstUserStoryID(ctx, s.client)
+	if err != nil {
+		return err
+	}
+	id, err := s.create(ctx, "Comment", commentFields(storyID))
+	if err != nil {
+		return err
+	}
+	if _, err := client.DeleteEntity(ctx, "Comment", id); err != nil {
+		return err
+	}
+	s.forget("Comment", id)
+	return nil
+}
+
+func captureEntityCreate(ctx context.Context, client *api.Client, s *scratch) error {
+	projectID, err := findFirstProjectID(ctx, s.client)
+	if err != nil {
+		return err
+	}
+	entity, err := client.CreateEntity(ctx, "UserStory", storyFields(projectID))
+	if err != nil {
+		return err
+	}
+	s.track("UserStory", entityID(entity))
+	return nil
+}
+
+func captureEntityUpdate(ctx context.Context, client *api.Client, s *scratch) error {
+	projectID, err := findFirstProjectID(ctx, s.client)
+	if err != nil {
+		return err
+	}
+	id, err := s.create(ctx, "UserStory", storyFields(projectID))
+	if err != nil {
+		return err
+	}
+	_, err = client.UpdateEntity(ctx, "UserStory", id, map[string]any{"Name": throwawayName + " (updated)"})
+	return err
+}
+
+// throwawayName marks entities tp-capture creates, so any left behind by an
+// interrupted run are easy to find and remove by hand.
+const throwawayName = "tp-capture throwaway"
+
+func commentFields(storyID int) map[string]any {
+	return map[string]any{
+		"Description": "<!--markdown-->" + throwawayName + " comment",
+		"General":     map[string]any{"Id": storyID},
+	}
+}
+
+func storyFields(projectID int) map[string]any {
+	return map[string]any{
+		"Name":    throwawayName,
+		"Project": map[string]any{"Id": projectID},
+	}
+}
+
+// scratch tracks throwaway entities a scenario creates so they can be
+// deleted once it has been captured, whether or not it succeeded.
+type scratch struct {
+	// client is unrecorded; use it for setup that should not appear in
+	// the fixture.
+	client  *api.Client
+	created []scratchRef
+}
+
+type scratchRef struct {
+	entityType string
+	id         int
+}
+
+// create makes an entity through the unrecorded client and tracks it.
+func (s *scratch) create(ctx context.Context, entityType string, fields map[string]any) (int, error) {
+	entity, err := s.client.CreateEntity(ctx, entityType, fields)
+	if err != nil {
+		return 0, fmt.Errorf("creating throwaway %s: %w", entityType, err)
+	}
+	id := entityID(entity)
+	s.track(entityType, id)
+	return id, nil
+}
+
+func (s *scratch) track(entityType string, id int) {
+	if id > 0 {
+		s.created = append(s.created, scratchRef{entityType, id})
+	}
+}
+
+// forget stops tracking an entity the scenario already deleted.
+func (s *scratch) forget(entityType string, id int) {
+	for i, ref := range s.created {
+		if ref.entityType == entityType && ref.id == id {
+			s.created = append(s.created[:i], s.created[i+1:]...)
+			return
+		}
+	}
+}
+
+// cleanup deletes tracked entities newest first. It uses its own timeout so
+// entities are still removed after the capture context has expired.
+func (s *scratch) cleanup() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	for i := len(s.created) - 1; i >= 0; i-- {
+		ref := s.created[i]
+		if _, err := s.client.DeleteEntity(ctx, ref.entityType, ref.id); err != nil {
+			fmt.Fprintf(os.Stderr, "\nWarning: could not delete throwaway %s %d: %v\n", ref.entityType, ref.id, err)
+		}
+	}
+	s.created = nil
+}
+
+func entityID(entity api.Entity) int {
+	id, _ := entity["Id"].(float64)
+	return int(id)
+}
+
+func findFirstProjectID(ctx context.Context, client *api.Client) (int, error) {
+	projects, err := client.ListProjects(ctx, true)
+	if err != nil {
+		return 0, err
+	}
+	if len(projects) == 0 {
+		return 0, errors.New("no active projects found")
+	}
+	return projects[0].ID, nil
+}
+
 func findFirstUserStoryID(ctx context.Context, client *api.Client) (int, error) {
 	data, err := client.QueryV2(ctx, "UserStory", api.V2Params{
 		Select: "id",
@@ -7,21 +7,34 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"time"
 
 	"github.com/urfave/cli/v3"
 
 	apicmd "github.com/lifedraft/targetprocess-cli/internal/cmd/api"
 	"github.com/lifedraft/targetprocess-cli/internal/cmd/bugreport"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/bulkcomment"
 	cheatsht "github.com/lifedraft/targetprocess-cli/internal/cmd/cheatsheet"
 	"github.com/lifedraft/targetprocess-cli/internal/cmd/commentcmd"
 	configcmd "github.com/lifedraft/targetprocess-cli/internal/cmd/config"
 	createcmd "github.com/lifedraft/targetprocess-cli/internal/cmd/create"
 	"github.com/lifedraft/targetprocess-cli/internal/cmd/inspect"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/login"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/opencmd"
 	"github.com/lifedraft/targetprocess-cli/internal/cmd/presets"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/projects"
 	querycmd "github.com/lifedraft/targetprocess-cli/internal/cmd/query"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/relations"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/report"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/rollup"
 	searchcmd "github.com/lifedraft/targetprocess-cli/internal/cmd/search"
 	showcmd "github.com/lifedraft/targetprocess-cli/internal/cmd/show"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/states"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/timecmd"
 	updatecmd "github.com/lifedraft/targetprocess-cli/internal/cmd/update"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/versioncmd"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/watch"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/whoami"
 	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
 )
 
@@ -33,6 +46,8 @@ func main() {
 
 func run() (exitCode int) {
 	f := &cmdutil.Factory{}
+	cancelTimeout := context.CancelFunc(func() {})
+	defer func() { cancelTimeout() }()
 
 	defer func() {
 		if r := recover(); r != nil {
@@ -60,10 +75,70 @@ func run() (exitCode int) {
 				Name:  "debug",
 				Usage: "Enable debug output to stderr",
 			},
+			&cli.StringFlag{
+				Name:  "log-file",
+				Usage: "Append a JSON line per HTTP request (method, redacted URL, status, bytes, duration) to this file",
+			},
+			&cli.StringFlag{
+				Name:  "har",
+				Usage: "Write every HTTP request and response, credentials redacted, to this file in HAR format",
+			},
+			&cli.BoolFlag{
+				Name:  "cache",
+				Usage: "Serve repeated GET requests from an on-disk cache (see --cache-ttl)",
+			},
+			&cli.DurationFlag{
+				Name:  "cache-ttl",
+				Value: 5 * time.Minute,
+				Usage: "How long --cache keeps responses",
+			},
+			&cli.IntFlag{
+				Name:  "width",
+				Usage: "Column budget for width-dependent table output (overrides output_width; default: detect the terminal)",
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "Limit each HTTP request and the whole command to this long, 0 for no limit (overrides timeout; default: 60s per request)",
+			},
+			&cli.BoolFlag{
+				Name:    "quiet",
+				Aliases: []string{"q"},
+				Usage:   "Suppress warnings and hints on stderr; errors still print (config: quiet, env: TP_QUIET)",
+			},
+			&cli.BoolFlag{
+				Name:  "verbose",
+				Usage: "Log each HTTP request (method, URL, status, size, time), retries, and detected entity types to stderr, and show hints even off a terminal; less than --debug",
+			},
+			&cli.BoolFlag{
+				Name:  "version-check",
+				Usage: "Warn if the Targetprocess version is outside the range this CLI was tested against (cached for a day)",
+			},
 		},
 		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
 			f.ConfigPath = cmd.String("config")
 			f.Debug = cmd.Bool("debug")
+			f.VersionCheck = cmd.Bool("version-check")
+			f.Quiet = cmd.Bool("quiet")
+			f.Verbose = cmd.Bool("verbose")
+			if f.Quiet && f.Verbose {
+				return ctx, errors.New("--quiet and --verbose cannot be combined")
+			}
+			f.LogFile = cmd.String("log-file")
+			f.HARFile = cmd.String("har")
+			f.Width = cmd.Int("width")
+			if cmd.Bool("cache") {
+				f.CacheTTL = cmd.Duration("cache-ttl")
+			}
+			if cmd.IsSet("timeout") {
+				timeout := cmd.Duration("timeout")
+				f.Timeout = &timeout
+			}
+			// Only an explicit timeout bounds the whole command: the default
+			// is per request, so long --all runs and watch-changes keep going.
+			// Config errors surface later, from the command that needs it.
+			if timeout, ok, err := f.RequestTimeout(); err == nil && ok && timeout > 0 {
+				ctx, cancelTimeout = context.WithTimeout(ctx, timeout)
+			}
 			return ctx, nil
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
@@ -75,7 +150,7 @@ func run() (exitCode int) {
 			// If the first arg is a positive integer, delegate to "show"
 			id, err := strconv.Atoi(args[0])
 			if err == nil && id > 0 {
-				return showcmd.RunShow(ctx, f, id, "", "", false)
+				return showcmd.RunShow(ctx, f, id, "", "", "text")
 			}
 
 			return cli.ShowAppHelp(cmd)
@@ -86,23 +161,37 @@ func run() (exitCode int) {
 			createCmd,
 			updateCmd,
 			commentCmd,
-			presets.NewCmd(),
+			timecmd.NewCmd(f),
+			relations.NewRelateCmd(f),
+			relations.NewListCmd(f),
+			bulkcomment.NewCmd(f),
+			opencmd.NewCmd(f),
+			projects.NewCmd(f),
+			states.NewCmd(f),
+			presets.NewCmd(f),
 			querycmd.NewCmd(f),
+			querycmd.NewExportCmd(f),
+			report.NewCmd(f),
+			rollup.NewCmd(f),
 			inspect.NewCmd(f),
 			apicmd.NewCmd(f),
+			watch.NewCmd(f),
 			configcmd.NewCmd(f),
+			login.NewCmd(f),
+			whoami.NewCmd(f),
 			cheatsht.NewCmd(f),
 			bugreport.NewCmd(f, version),
+			versioncmd.NewCmd(f, version),
 
 			// Hidden aliases
-			hiddenAlias("get", "show", showCmd),
-			hiddenAlias("view", "show", showCmd),
-			hiddenAlias("find", "search", searchCmd),
-			hiddenAlias("list", "search", searchCmd),
-			hiddenAlias("edit", "update", updateCmd),
-			hiddenAlias("new", "create", createCmd),
-			hiddenAlias("add", "create", createCmd),
-			hiddenAlias("comments", "comment", commentCmd),
+			hiddenAlias(f, "get", "show", showCmd),
+			hiddenAlias(f, "view", "show", showCmd),
+			hiddenAlias(f, "find", "search", searchCmd),
+			hiddenAlias(f, "list", "search", searchCmd),
+			hiddenAlias(f, "edit", "update", updateCmd),
+			hiddenAlias(f, "new", "create", createCmd),
+			hiddenAlias(f, "add", "create", createCmd),
+			hiddenAlias(f, "comments", "comment", commentCmd),
 		},
 	}
 
@@ -114,6 +203,10 @@ func run() (exitCode int) {
 		if errors.Is(err, context.Canceled) {
 			return 130
 		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			fmt.Fprintf(os.Stderr, "Error: %v (raise or disable the limit with --timeout or the timeout config key)\n", err)
+			return 1
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return 1
 	}
@@ -121,7 +214,7 @@ func run() (exitCode int) {
 }
 
 // hiddenAlias creates a hidden command that delegates to the target command.
-func hiddenAlias(alias, target string, targetCmd *cli.Command) *cli.Command {
+func hiddenAlias(f *cmdutil.Factory, alias, target string, targetCmd *cli.Command) *cli.Command {
 	return &cli.Command{
 		Name:      alias,
 		Hidden:    true,
@@ -130,7 +223,7 @@ func hiddenAlias(alias, target string, targetCmd *cli.Command) *cli.Command {
 		Flags:     targetCmd.Flags,
 		Commands:  targetCmd.Commands,
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			fmt.Fprintf(os.Stderr, "Hint: %q is an alias for %q\n", alias, target)
+			fmt.Fprintf(f.Hints(), "Hint: %q is an alias for %q\n", alias, target)
 			return targetCmd.Action(ctx, cmd)
 		},
 	}
@@ -0,0 +1,115 @@
+package api //nolint:revive // package name "api" is intentional
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// regexBareComparison matches a comparison whose right-hand side is one
+	// or more bare words, e.g. name==login or entityState.name==In Progress.
+	regexBareComparison = regexp.MustCompile(`(==|!=|>=|<=|>|<)\s*([A-Za-z_]\w*(?:[ \t]+[A-Za-z_]\w*)*)`)
+	regexWord           = regexp.MustCompile(`[A-Za-z_]\w*`)
+)
+
+// whereKeywords are bare words v2 understands on the right of a comparison.
+var whereKeywords = map[string]bool{
+	"true": true, "false": true, "null": true,
+	"today": true, "now": true, "me": true,
+}
+
+// commonFields are top-level v2 fields often compared with each other, as in
+// effort>timeSpent; they are never taken for string literals.
+var commonFields = map[string]bool{
+	"id": true, "name": true, "description": true, "tags": true,
+	"effort": true, "effortCompleted": true, "effortToDo": true,
+	"timeSpent": true, "timeRemain": true, "initialEstimate": true,
+	"progress": true, "numericPriority": true, "units": true,
+	"createDate": true, "modifyDate": true, "startDate": true, "endDate": true,
+	"plannedStartDate": true, "plannedEndDate": true, "lastStateChangeDate": true,
+}
+
+// bareLiteral is a run of bare words compared against, at where[Start:End].
+type bareLiteral struct {
+	Value      string
+	Start, End int
+}
+
+// findBareLiterals returns comparisons against bare words that v2 would read
+// as field names: not keywords, not common fields, not dot-paths or method
+// calls. Multi-word values run up to the next and/or. Text inside string
+// literals is skipped.
+func findBareLiterals(where string) []bareLiteral {
+	if where == "" {
+		return nil
+	}
+	// Blank out string literals so their contents never match, keeping offsets.
+	masked := regexStringLiteral.ReplaceAllStringFunc(where, func(s string) string {
+		return strings.Repeat("#", len(s))
+	})
+
+	var found []bareLiteral
+	for _, m := range regexBareComparison.FindAllStringSubmatchIndex(masked, -1) {
+		start, end := m[4], m[5]
+		// Stop at the first and/or: "In Progress and effort" is "In Progress".
+		words := regexWord.FindAllStringIndex(masked[start:end], -1)
+		for i, w := range words {
+			if i > 0 && isConnective(masked[start+w[0]:start+w[1]]) {
+				end = start + words[i-1][1]
+				break
+			}
+		}
+		first := masked[start : start+words[0][1]]
+		if whereKeywords[strings.ToLower(first)] || commonFields[first] {
+			continue
+		}
+		if end < len(masked) && (masked[end] == '.' || masked[end] == '(') {
+			continue
+		}
+		found = append(found, bareLiteral{Value: where[start:end], Start: start, End: end})
+	}
+	return found
+}
+
+func isConnective(word string) bool {
+	w := strings.ToLower(word)
+	return w == "and" || w == "or"
+}
+
+// QuoteBareLiterals double-quotes comparisons against bare words, turning
+// name==login into name=="login". It returns the rewritten clause and the
+// values it quoted.
+func QuoteBareLiterals(where string) (string, []string) {
+	found := findBareLiterals(where)
+	if len(found) == 0 {
+		return where, nil
+	}
+	var sb strings.Builder
+	var quoted []string
+	last := 0
+	for _, bl := range found {
+		sb.WriteString(where[last:bl.Start])
+		sb.WriteString(`"` + bl.Value + `"`)
+		last = bl.End
+		quoted = append(quoted, bl.Value)
+	}
+	sb.WriteString(where[last:])
+	return sb.String(), quoted
+}
+
+// WarnWhereBareLiterals checks a where expression for comparisons against
+// bare words, which v2 reads as field names rather than strings.
+// Returns a warning message or empty string.
+func WarnWhereBareLiterals(where string) string {
+	found := findBareLiterals(where)
+	if len(found) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("Warning: The where clause compares to bare words, which v2 reads as field names, not strings:\n")
+	for _, bl := range found {
+		fmt.Fprintf(&sb, "  - %s  (use: \"%s\", or pass --auto-quote)\n", bl.Value, bl.Value)
+	}
+	return sb.String()
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestQuoteBareLiterals(t *testing.T) {
+	tests := []struct {
+		where  string
+		want   string
+		quoted []string
+	}{
+		{"name==login", `name=="login"`, []string{"login"}},
+		{"entityState.name==In Progress and effort>3", `entityState.name=="In Progress" and effort>3`, []string{"In Progress"}},
+		{"priority.name!=Low or name == bug", `priority.name!="Low" or name == "bug"`, []string{"Low", "bug"}},
+		// Left alone: keywords, numbers, quoted strings, dot-paths, fields, calls.
+		{"entityState.isFinal!=true and assignedUser==null", "entityState.isFinal!=true and assignedUser==null", nil},
+		{"effort>=5", "effort>=5", nil},
+		{`name=="a==b"`, `name=="a==b"`, nil},
+		{"owner.id==creator.id", "owner.id==creator.id", nil},
+		{"effort>timeSpent", "effort>timeSpent", nil},
+		{"createDate>Today.AddDays(-7)", "createDate>Today.AddDays(-7)", nil},
+		{"", "", nil},
+	}
+	for _, tt := range tests {
+		got, quoted := QuoteBareLiterals(tt.where)
+		if got != tt.want || !reflect.DeepEqual(quoted, tt.quoted) {
+			t.Errorf("QuoteBareLiterals(%q) = %q, %v; want %q, %v", tt.where, got, quoted, tt.want, tt.quoted)
+		}
+	}
+}
+
+func TestWarnWhereBareLiterals(t *testing.T) {
+	if warn := WarnWhereBareLiterals(`name=="login"`); warn != "" {
+		t.Errorf("expected no warning for a quoted string, got %q", warn)
+	}
+	warn := WarnWhereBareLiterals("name==login")
+	if !strings.Contains(warn, `login  (use: "login", or pass --auto-quote)`) {
+		t.Errorf("expected quoting suggestion, got %q", warn)
+	}
+}
@@ -0,0 +1,123 @@
+package api //nolint:revive // package name "api" is intentional
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// cacheEntry is one cached response as stored on disk.
+type cacheEntry struct {
+	URL      string      `json:"url"`
+	Status   int         `json:"status"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+	StoredAt time.Time   `json:"storedAt"`
+}
+
+// CachingTransport is an http.RoundTripper that serves repeated GET requests
+// from an on-disk cache in Dir for up to TTL. Entries are keyed by the
+// request URL with credentials redacted, plus the Accept header. Only 2xx
+// responses are stored; cache read or write failures fall back to the
+// network silently.
+type CachingTransport struct {
+	Base http.RoundTripper
+	Dir  string
+	TTL  time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *CachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.base().RoundTrip(req)
+	}
+
+	redacted := redactToken(req.URL.String())
+	path := filepath.Join(t.Dir, cacheKey(redacted, req.Header.Get("Accept"))+".json")
+	if entry, ok := t.load(path); ok {
+		return entry.response(req), nil
+	}
+
+	resp, err := t.base().RoundTrip(req)
+	if err != nil || resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if int64(len(body)) <= maxResponseSize {
+		t.store(path, cacheEntry{URL: redacted, Status: resp.StatusCode, Header: resp.Header, Body: body, StoredAt: time.Now()})
+	}
+	return resp, nil
+}
+
+func (t *CachingTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func cacheKey(url, accept string) string {
+	sum := sha256.Sum256([]byte(url + "\n" + accept))
+	return hex.EncodeToString(sum[:])
+}
+
+// load returns the entry at path if it exists and is younger than TTL.
+func (t *CachingTransport) load(path string) (cacheEntry, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return cacheEntry{}, false
+	}
+	if time.Since(entry.StoredAt) > t.TTL {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// store writes the entry best-effort; a failure only means a later miss.
+func (t *CachingTransport) store(path string, entry cacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(t.Dir, 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
+
+func (e cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// CacheTo serves GET responses from an on-disk cache in dir for up to ttl.
+// Call it after LogTo so logs show only requests that reach the network.
+func (c *Client) CacheTo(dir string, ttl time.Duration) {
+	c.rc.HTTPClient.Transport = &CachingTransport{Base: c.rc.HTTPClient.Transport, Dir: dir, TTL: ttl}
+}
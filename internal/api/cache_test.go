@@ -0,0 +1,90 @@
+package api //nolint:revive // package name "api" is intentional
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheTo(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := hits.Add(1)
+		if r.URL.Path == "/api/v1/Missing" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"n":%d}`, n)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	c := NewClient(srv.URL, "secret-token", false)
+	c.CacheTo(dir, time.Minute)
+	ctx := context.Background()
+
+	first, err := c.Raw(ctx, http.MethodGet, "/api/v1/Bugs/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := c.Raw(ctx, http.MethodGet, "/api/v1/Bugs/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(first) != string(second) || hits.Load() != 1 {
+		t.Errorf("second GET should be served from cache: %s vs %s, %d server hits", first, second, hits.Load())
+	}
+
+	if _, err := c.Raw(ctx, http.MethodPost, "/api/v1/Bugs/1", strings.NewReader("{}")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Raw(ctx, http.MethodPost, "/api/v1/Bugs/1", strings.NewReader("{}")); err != nil {
+		t.Fatal(err)
+	}
+	if hits.Load() != 3 {
+		t.Errorf("POSTs must not be cached, got %d server hits", hits.Load())
+	}
+
+	for range 2 {
+		if _, err := c.Raw(ctx, http.MethodGet, "/api/v1/Missing", nil); err == nil {
+			t.Fatal("expected 404 error")
+		}
+	}
+	if hits.Load() != 5 {
+		t.Errorf("non-2xx responses must not be cached, got %d server hits", hits.Load())
+	}
+
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		data, _ := os.ReadFile(dir + "/" + e.Name())
+		if strings.Contains(string(data), "secret-token") {
+			t.Errorf("cache entry %s contains the token", e.Name())
+		}
+	}
+}
+
+func TestCacheTo_Expires(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "tok", false)
+	c.CacheTo(t.TempDir(), -time.Second) // every entry is already stale
+	for range 2 {
+		if _, err := c.Raw(context.Background(), http.MethodGet, "/api/v1/Bugs/1", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if hits.Load() != 2 {
+		t.Errorf("stale entries must be refetched, got %d server hits", hits.Load())
+	}
+}
@@ -4,14 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -22,6 +25,12 @@ var validEntityType = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
 
 const maxResponseSize = 50 * 1024 * 1024 // 50 MB
 
+// DefaultMaxRetryWait caps how long a single Retry-After may make the client wait.
+const DefaultMaxRetryWait = 60 * time.Second
+
+// DefaultTimeout bounds each HTTP attempt unless SetTimeout changes it.
+const DefaultTimeout = 60 * time.Second
+
 // Entity represents a generic TP entity as a flexible map.
 type Entity = map[string]any
 
@@ -29,18 +38,91 @@ type Entity = map[string]any
 type APIError struct {
 	StatusCode int
 	Body       string
+
+	// RetryAfter is the server's requested wait from a Retry-After header,
+	// set when a rate-limited (429) request ran out of retries.
+	RetryAfter time.Duration
 }
 
 func (e *APIError) Error() string {
+	if e.StatusCode == http.StatusTooManyRequests {
+		wait := ""
+		if e.RetryAfter > 0 {
+			wait = fmt.Sprintf("; server asks to retry after %s", e.RetryAfter)
+		}
+		return fmt.Sprintf("API error (HTTP 429): rate limited, retries exhausted%s: %s", wait, e.Body)
+	}
 	return fmt.Sprintf("API error (HTTP %d): %s", e.StatusCode, e.Body)
 }
 
+// AuthMode selects how the client authenticates its requests.
+type AuthMode string
+
+const (
+	// AuthTokenQuery sends the token as the access_token query parameter (default).
+	AuthTokenQuery AuthMode = "token_query"
+	// AuthBasic sends Username and Password as HTTP Basic auth.
+	AuthBasic AuthMode = "basic"
+	// AuthBearer sends the token in an "Authorization: Bearer" header.
+	AuthBearer AuthMode = "bearer"
+)
+
 // Client is the Targetprocess API client.
 type Client struct {
 	BaseURL    string
 	Token      string
 	HTTPClient *http.Client
 	Debug      bool
+
+	// Verbose logs one line per request (method, redacted URL, status,
+	// size, and time), retries, and detected entity types: less than Debug,
+	// which adds headers.
+	Verbose bool
+
+	// AuthMode defaults to AuthTokenQuery when empty. Username and Password
+	// are only used with AuthBasic.
+	AuthMode AuthMode
+	Username string
+	Password string
+
+	// MaxRetryWait caps the wait requested by a Retry-After header on 429/503
+	// responses, so a pathological value can't hang the CLI.
+	MaxRetryWait time.Duration
+
+	rc *retryablehttp.Client
+
+	// debugOut receives --debug output; nil means stderr.
+	debugOut io.Writer
+
+	// typeMeta caches GetTypeMeta responses by entity type; metadata does
+	// not change within a session.
+	metaMu   sync.Mutex
+	typeMeta map[string][]byte
+
+	// entityTypes caches ResolveEntityType results by ID; an entity's type
+	// never changes.
+	entityTypesMu sync.Mutex
+	entityTypes   map[int]string
+}
+
+// debugf writes a debug line. Callers check c.Debug first.
+func (c *Client) debugf(format string, args ...any) {
+	w := c.debugOut
+	if w == nil {
+		w = os.Stderr
+	}
+	fmt.Fprintf(w, format, args...) //nolint:gosec // debug log to stderr, not web output
+}
+
+// tracef writes a line shown by both Debug (with a DEBUG: prefix) and
+// Verbose.
+func (c *Client) tracef(format string, args ...any) {
+	switch {
+	case c.Debug:
+		c.debugf("DEBUG: "+format, args...)
+	case c.Verbose:
+		c.debugf(format, args...)
+	}
 }
 
 // NewClient creates a new API client with retry support.
@@ -48,48 +130,118 @@ func NewClient(baseURL, token string, debug bool) *Client {
 	rc := retryablehttp.NewClient()
 	rc.RetryMax = 3
 	rc.Logger = nil
-	rc.HTTPClient.Timeout = 60 * time.Second
+	rc.HTTPClient.Timeout = DefaultTimeout
+	// Hand the last response back once retries run out, so a final 429 or
+	// 5xx surfaces as an APIError rather than an opaque "giving up" error.
+	rc.ErrorHandler = retryablehttp.PassthroughErrorHandler
 
 	if !strings.HasPrefix(baseURL, "http") {
 		baseURL = "https://" + baseURL
 	}
 	baseURL = strings.TrimRight(baseURL, "/")
 
-	return &Client{
-		BaseURL:    baseURL,
-		Token:      token,
-		HTTPClient: rc.StandardClient(),
-		Debug:      debug,
+	c := &Client{
+		BaseURL:      baseURL,
+		Token:        token,
+		Debug:        debug,
+		MaxRetryWait: DefaultMaxRetryWait,
+		rc:           rc,
 	}
+	rc.Backoff = c.backoff
+	c.HTTPClient = rc.StandardClient()
+	return c
+}
+
+// SetTimeout bounds each HTTP attempt to d. Zero means no timeout.
+func (c *Client) SetTimeout(d time.Duration) {
+	c.rc.HTTPClient.Timeout = d
+}
+
+// backoff waits as long as a 429/503 response's Retry-After header asks,
+// capped at MaxRetryWait, and otherwise backs off exponentially.
+func (c *Client) backoff(minWait, maxWait time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	wait, ok := time.Duration(0), false
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		wait, ok = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	}
+	if !ok {
+		wait = retryablehttp.DefaultBackoff(minWait, maxWait, attemptNum, nil)
+	}
+
+	capped := ""
+	if c.MaxRetryWait > 0 && wait > c.MaxRetryWait {
+		wait = c.MaxRetryWait
+		capped = " (Retry-After capped)"
+	}
+
+	if c.Debug || c.Verbose {
+		status := "request error"
+		if resp != nil {
+			status = fmt.Sprintf("HTTP %d", resp.StatusCode)
+		}
+		c.tracef("%s, retrying in %s%s\n", status, wait, capped)
+	}
+	return wait
+}
+
+// parseRetryAfter parses a Retry-After value given either in seconds or as
+// an HTTP date. A date in the past means no wait.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if wait := at.Sub(now); wait > 0 {
+		return wait, true
+	}
+	return 0, true
 }
 
 func (c *Client) buildURL(path string, params url.Values) string {
 	if params == nil {
 		params = url.Values{}
 	}
-	params.Set("access_token", c.Token)
+	c.setTokenParam(params)
 	params.Set("format", "json")
 	return fmt.Sprintf("%s%s?%s", c.BaseURL, path, params.Encode())
 }
 
 func (c *Client) request(ctx context.Context, method, fullURL string, body io.Reader) ([]byte, error) {
-	if c.Debug {
-		fmt.Fprintf(os.Stderr, "DEBUG: %s %s\n", method, redactToken(fullURL)) //nolint:gosec // debug log to stderr, not web output
-	}
+	return c.requestWithType(ctx, method, fullURL, "application/json", body)
+}
 
+// requestWithType is request with an explicit Content-Type for the body.
+func (c *Client) requestWithType(ctx context.Context, method, fullURL, contentType string, body io.Reader) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, method, fullURL, body)
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		return nil, fmt.Errorf("creating request: %w", redactURLError(err))
 	}
 	req.Header.Set("Accept", "application/json")
 	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Type", contentType)
 	}
-	req.Header.Set("User-Agent", "tp-cli/0.1.0")
+	req.Header.Set("User-Agent", "tp-cli/"+clientVersion)
+	c.authorize(req)
 
+	if c.Debug {
+		c.debugf("DEBUG: %s %s\n", method, redactToken(fullURL))
+		c.debugf("DEBUG: headers: %s\n", redactHeaders(req.Header))
+	}
+
+	start := time.Now()
 	resp, err := c.HTTPClient.Do(req) //nolint:gosec // URL is constructed from configured base URL + API path
 	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
+		return nil, fmt.Errorf("executing request: %w", redactURLError(err))
 	}
 	defer resp.Body.Close()
 
@@ -102,7 +254,9 @@ func (c *Client) request(ctx context.Context, method, fullURL string, body io.Re
 	}
 
 	if c.Debug {
-		fmt.Fprintf(os.Stderr, "DEBUG: HTTP %d, %d bytes\n", resp.StatusCode, len(data)) //nolint:gosec // debug log to stderr, not web output
+		c.debugf("DEBUG: HTTP %d, %d bytes\n", resp.StatusCode, len(data))
+	} else if c.Verbose {
+		c.debugf("%s %s: HTTP %d, %d bytes in %s\n", method, redactToken(fullURL), resp.StatusCode, len(data), time.Since(start).Round(time.Millisecond))
 	}
 
 	if resp.StatusCode >= 400 {
@@ -111,26 +265,107 @@ func (c *Client) request(ctx context.Context, method, fullURL string, body io.Re
 		if len(body) > maxErrorBody {
 			body = body[:maxErrorBody] + "... (truncated)"
 		}
-		return nil, &APIError{StatusCode: resp.StatusCode, Body: body}
+		apiErr := &APIError{StatusCode: resp.StatusCode, Body: body}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			apiErr.RetryAfter, _ = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		}
+		return nil, apiErr
 	}
 	return data, nil
 }
 
-// redactToken removes all access_token values from a URL for safe logging.
+// setTokenParam adds the access_token query parameter when the client
+// authenticates via the query string.
+func (c *Client) setTokenParam(q url.Values) {
+	if c.AuthMode == "" || c.AuthMode == AuthTokenQuery {
+		q.Set("access_token", c.Token)
+	}
+}
+
+// authorize sets the Authorization header for header-based auth modes.
+func (c *Client) authorize(req *http.Request) {
+	switch c.AuthMode {
+	case AuthBasic:
+		req.SetBasicAuth(c.Username, c.Password)
+	case AuthBearer:
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	case "", AuthTokenQuery:
+		// Token travels in the query string.
+	}
+}
+
+// clientVersion identifies the client in the User-Agent and HAR traces.
+const clientVersion = "0.1.0"
+
+// credentialParams are query parameters whose values are secrets.
+var credentialParams = []string{"access_token", "token", "password", "api_key", "apikey"}
+
+// credentialParamRe finds credential parameters in URLs that fail to parse.
+var credentialParamRe = regexp.MustCompile(`(?i)((?:access_token|token|password|api_key|apikey)=)[^&#\s]*`)
+
+// redactedValue replaces secrets in log output.
+const redactedValue = "[REDACTED]"
+
+// redactToken removes credential query parameter values (access_token and
+// similar) and any user:password userinfo from a URL for safe logging.
 func redactToken(rawURL string) string {
 	u, err := url.Parse(rawURL)
 	if err != nil {
-		return rawURL
+		return credentialParamRe.ReplaceAllString(rawURL, "${1}"+redactedValue)
+	}
+	if u.User != nil {
+		u.User = url.User(redactedValue)
 	}
 	q := u.Query()
-	if q.Has("access_token") {
-		q.Del("access_token")
-		q.Set("access_token", "[REDACTED]")
+	for _, key := range credentialParams {
+		if q.Has(key) {
+			q.Set(key, redactedValue)
+		}
 	}
 	u.RawQuery = q.Encode()
 	return u.String()
 }
 
+// redactURLError scrubs credentials from the URL a *url.Error embeds, so
+// network errors can be shown without leaking the token.
+func redactURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = redactToken(urlErr.URL)
+	}
+	return err
+}
+
+// sensitiveHeaders are request and response headers that carry credentials
+// or session state, redacted in debug output and HAR files.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"Www-Authenticate":    true,
+	"Proxy-Authenticate":  true,
+}
+
+// redactHeaders formats headers for debug output, sorted by name, with
+// credential-bearing values replaced.
+func redactHeaders(h http.Header) string {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		value := strings.Join(h[name], ", ")
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			value = redactedValue
+		}
+		parts = append(parts, name+": "+value)
+	}
+	return strings.Join(parts, "; ")
+}
+
 // ValidateEntityType checks that an entity type name is safe for use in URL paths.
 func ValidateEntityType(entityType string) error {
 	if !validEntityType.MatchString(entityType) {
@@ -201,8 +436,7 @@ func (c *Client) CreateEntity(ctx context.Context, entityType string, fields map
 		return nil, fmt.Errorf("encoding request body: %w", err)
 	}
 
-	path := fmt.Sprintf("/api/v1/%ss", entityType)
-	data, err := c.do(ctx, http.MethodPost, path, nil, bytes.NewReader(body))
+	data, err := c.do(ctx, http.MethodPost, EntityPath(entityType, 0), nil, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("creating %s: %w", entityType, err)
 	}
@@ -221,8 +455,7 @@ func (c *Client) UpdateEntity(ctx context.Context, entityType string, id int, fi
 		return nil, fmt.Errorf("encoding request body: %w", err)
 	}
 
-	path := fmt.Sprintf("/api/v1/%ss/%d", entityType, id)
-	data, err := c.do(ctx, http.MethodPost, path, nil, bytes.NewReader(body))
+	data, err := c.do(ctx, http.MethodPost, EntityPath(entityType, id), nil, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("updating %s/%d: %w", entityType, id, err)
 	}
@@ -239,59 +472,119 @@ func (c *Client) DeleteEntity(ctx context.Context, entityType string, id int) ([
 	if err := ValidateEntityType(entityType); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/api/v1/%ss/%d", entityType, id)
-	return c.do(ctx, http.MethodDelete, path, nil, nil)
+	return c.do(ctx, http.MethodDelete, EntityPath(entityType, id), nil, nil)
 }
 
-// ResolveEntityType resolves the entity type for a given ID via the General endpoint.
-func (c *Client) ResolveEntityType(ctx context.Context, id int) (string, error) {
-	data, err := c.QueryV2Entity(ctx, "General", id, "resourceType")
-	if err != nil {
-		return "", fmt.Errorf("resolving entity type for ID %d: %w", id, err)
+// EntityPath returns the v1 path that create (id 0), update, and delete
+// requests for an entity use.
+func EntityPath(entityType string, id int) string {
+	if id == 0 {
+		return fmt.Sprintf("/api/v1/%ss", entityType)
 	}
+	return fmt.Sprintf("/api/v1/%ss/%d", entityType, id)
+}
 
-	var resp struct {
-		Items []struct {
-			ResourceType string `json:"resourceType"`
-		} `json:"items"`
+// AppContext is the subset of /api/v1/Context the CLI uses.
+type AppContext struct {
+	Version    string `json:"Version"`
+	LoggedUser *User  `json:"LoggedUser"`
+}
+
+// User is the authenticated user as reported by /api/v1/Context.
+type User struct {
+	ID        int    `json:"Id"`
+	FirstName string `json:"FirstName"`
+	LastName  string `json:"LastName"`
+	Login     string `json:"Login"`
+	Email     string `json:"Email"`
+}
+
+// GetContext fetches the instance's /api/v1/Context: product version and
+// the user the credentials authenticate as.
+func (c *Client) GetContext(ctx context.Context) (AppContext, error) {
+	data, err := c.do(ctx, http.MethodGet, "/api/v1/Context", nil, nil)
+	if err != nil {
+		return AppContext{}, fmt.Errorf("fetching context: %w", err)
 	}
-	if err := json.Unmarshal(data, &resp); err != nil {
-		return "", fmt.Errorf("parsing entity type response for ID %d: %w", id, err)
+	var appCtx AppContext
+	if err := json.Unmarshal(data, &appCtx); err != nil {
+		return AppContext{}, fmt.Errorf("parsing context: %w", err)
 	}
-	if len(resp.Items) == 0 {
-		return "", fmt.Errorf("entity with ID %d not found", id)
+	return appCtx, nil
+}
+
+// ProductVersion returns the Targetprocess product version reported by the
+// instance's context, or "" if the response carries none.
+func (c *Client) ProductVersion(ctx context.Context) (string, error) {
+	appCtx, err := c.GetContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	return appCtx.Version, nil
+}
+
+// CurrentUser returns the user the client's credentials authenticate as. It
+// doubles as a credentials check: a bad domain or token fails here.
+func (c *Client) CurrentUser(ctx context.Context) (User, error) {
+	appCtx, err := c.GetContext(ctx)
+	if err != nil {
+		return User{}, err
 	}
-	rt := resp.Items[0].ResourceType
-	if rt == "" {
-		return "", fmt.Errorf("entity %d has no resourceType", id)
+	if appCtx.LoggedUser == nil || appCtx.LoggedUser.ID == 0 {
+		return User{}, errors.New("response did not identify a logged-in user")
 	}
-	return rt, nil
+	return *appCtx.LoggedUser, nil
 }
 
 // GetMetaIndex fetches the metadata index (list of all entity types) as XML.
 func (c *Client) GetMetaIndex(ctx context.Context) ([]byte, error) {
 	params := url.Values{}
-	params.Set("access_token", c.Token)
+	c.setTokenParam(params)
 	fullURL := fmt.Sprintf("%s/api/v1/Index/meta?%s", c.BaseURL, params.Encode())
 	return c.request(ctx, http.MethodGet, fullURL, nil)
 }
 
-// GetTypeMeta fetches metadata for a specific entity type as XML.
+// GetTypeMeta fetches metadata for a specific entity type as XML. Successful
+// responses are cached for the life of the client.
 func (c *Client) GetTypeMeta(ctx context.Context, entityType string) ([]byte, error) {
+	c.metaMu.Lock()
+	cached, ok := c.typeMeta[entityType]
+	c.metaMu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
 	params := url.Values{}
-	params.Set("access_token", c.Token)
+	c.setTokenParam(params)
 	fullURL := fmt.Sprintf("%s/api/v1/%ss/meta?%s", c.BaseURL, entityType, params.Encode())
-	return c.request(ctx, http.MethodGet, fullURL, nil)
+	data, err := c.request(ctx, http.MethodGet, fullURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.metaMu.Lock()
+	defer c.metaMu.Unlock()
+	if c.typeMeta == nil {
+		c.typeMeta = make(map[string][]byte)
+	}
+	c.typeMeta[entityType] = data
+	return data, nil
 }
 
-// Raw makes a raw API request. The path can include query parameters.
+// Raw makes a raw API request with a JSON body. The path can include query parameters.
 func (c *Client) Raw(ctx context.Context, method, path string, body io.Reader) ([]byte, error) {
+	return c.RawWithContentType(ctx, method, path, "application/json", body)
+}
+
+// RawWithContentType is Raw with an explicit body Content-Type, e.g.
+// application/x-www-form-urlencoded.
+func (c *Client) RawWithContentType(ctx context.Context, method, path, contentType string, body io.Reader) ([]byte, error) {
 	u, err := url.Parse(c.BaseURL + path)
 	if err != nil {
 		return nil, fmt.Errorf("parsing URL: %w", err)
 	}
 	q := u.Query()
-	q.Set("access_token", c.Token)
+	c.setTokenParam(q)
 	u.RawQuery = q.Encode()
-	return c.request(ctx, method, u.String(), body)
+	return c.requestWithType(ctx, method, u.String(), contentType, body)
 }
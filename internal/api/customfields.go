@@ -0,0 +1,62 @@
+package api //nolint:revive // package name "api" is intentional
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BuildCustomFields turns name → value pairs into the CustomFields array
+// Targetprocess expects, [{"Name": ..., "Value": ...}], sorted by name.
+// Values are typed from their text: true and false become booleans, numbers
+// become numbers, an empty value or null clears the field, and a value in
+// double quotes is sent as the string inside them, so "123" stays text.
+func BuildCustomFields(values map[string]string) []map[string]any {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]map[string]any, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, map[string]any{"Name": name, "Value": customFieldValue(values[name])})
+	}
+	return fields
+}
+
+// customFieldValue infers the JSON type of a custom field value.
+func customFieldValue(s string) any {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && strings.HasPrefix(s, `"`) && strings.HasSuffix(s, `"`) {
+		return s[1 : len(s)-1]
+	}
+	switch strings.ToLower(s) {
+	case "", "null":
+		return nil
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		return n
+	}
+	return s
+}
+
+// CustomFieldValue returns the value of the custom field named name on
+// entity, matching the name case-insensitively.
+func CustomFieldValue(entity Entity, name string) (any, bool) {
+	list, _ := entity["CustomFields"].([]any)
+	for _, item := range list {
+		cf, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		if n, _ := cf["Name"].(string); strings.EqualFold(n, name) {
+			return cf["Value"], true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,46 @@
+package api //nolint:revive // package name "api" is intentional
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildCustomFields(t *testing.T) {
+	got := BuildCustomFields(map[string]string{
+		"Story Points": "5",
+		"Risk":         "High",
+		"Approved":     "TRUE",
+		"Ticket":       `"00123"`,
+		"Notes":        "",
+		"Ratio":        "0.25",
+		"Owner Team":   "null",
+	})
+	want := []map[string]any{
+		{"Name": "Approved", "Value": true},
+		{"Name": "Notes", "Value": nil},
+		{"Name": "Owner Team", "Value": nil},
+		{"Name": "Ratio", "Value": 0.25},
+		{"Name": "Risk", "Value": "High"},
+		{"Name": "Story Points", "Value": float64(5)},
+		{"Name": "Ticket", "Value": "00123"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BuildCustomFields() = %v, want %v", got, want)
+	}
+}
+
+func TestCustomFieldValue(t *testing.T) {
+	entity := Entity{"CustomFields": []any{
+		map[string]any{"Name": "Risk", "Value": "High"},
+		map[string]any{"Name": "Notes", "Value": nil},
+	}}
+	if v, ok := CustomFieldValue(entity, "risk"); !ok || v != "High" {
+		t.Errorf("CustomFieldValue(risk) = %v, %v; want High, true", v, ok)
+	}
+	if v, ok := CustomFieldValue(entity, "Notes"); !ok || v != nil {
+		t.Errorf("CustomFieldValue(Notes) = %v, %v; want nil, true", v, ok)
+	}
+	if _, ok := CustomFieldValue(entity, "Missing"); ok {
+		t.Error("CustomFieldValue(Missing) found a field")
+	}
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// msDateRe matches the Microsoft JSON date format v1 responses use, e.g.
+// "/Date(1700000000000+0100)/". The offset is the server's zone and does
+// not change the instant.
+var msDateRe = regexp.MustCompile(`^/Date\((-?\d+)([+-]\d{4})?\)/$`)
+
+// ParseDate parses a date as Targetprocess returns it: the v1
+// "/Date(ms+zzzz)/" form or an RFC 3339 timestamp from v2. ok is false for
+// anything else, including nil.
+func ParseDate(v any) (t time.Time, ok bool) {
+	s, isString := v.(string)
+	if !isString {
+		return time.Time{}, false
+	}
+	if m := msDateRe.FindStringSubmatch(s); m != nil {
+		ms, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.UnixMilli(ms), true
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in   any
+		want time.Time
+		ok   bool
+	}{
+		{"/Date(1700000000000+0100)/", time.UnixMilli(1700000000000), true},
+		{"/Date(1700000000000)/", time.UnixMilli(1700000000000), true},
+		{"2024-01-02T03:04:05Z", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), true},
+		{"2024-01-02T03:04:05.5+02:00", time.Date(2024, 1, 2, 1, 4, 5, 5e8, time.UTC), true},
+		{"yesterday", time.Time{}, false},
+		{nil, time.Time{}, false},
+		{float64(3), time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseDate(tt.in)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("ParseDate(%v) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
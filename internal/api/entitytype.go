@@ -0,0 +1,102 @@
+package api //nolint:revive // package name "api" is intentional
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ProbeEntityTypes are probed, one request each, when the General lookup
+// cannot place an ID. Only types outside General are listed: an ID General
+// does not know cannot be a work item, project, or release. Their ID spaces
+// may overlap, so every type is probed.
+var ProbeEntityTypes = []string{"Team", "Comment", "GeneralUser", "Time"}
+
+// ResolveEntityType returns the entity type of id. It asks the v2 General
+// endpoint first, a single cheap request that covers every work item, and
+// if that does not find the ID, probes all ProbeEntityTypes; an ID more than
+// one of them knows is reported as ambiguous. Results are cached for the life
+// of the client; with Debug or Verbose set, the detected type and how it was
+// found are logged.
+func (c *Client) ResolveEntityType(ctx context.Context, id int) (string, error) {
+	c.entityTypesMu.Lock()
+	cached, ok := c.entityTypes[id]
+	c.entityTypesMu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	rt, err := c.resourceType(ctx, "General", id)
+	via := "General"
+	if err == nil && rt == "" {
+		rt, err = c.probeEntityType(ctx, id)
+		via = rt + " probe"
+	}
+	if err != nil {
+		return "", fmt.Errorf("resolving entity type for ID %d: %w", id, err)
+	}
+	if rt == "" {
+		return "", fmt.Errorf("entity with ID %d not found", id)
+	}
+	c.tracef("entity %d is a %s (via %s)\n", id, rt, via)
+
+	c.entityTypesMu.Lock()
+	defer c.entityTypesMu.Unlock()
+	if c.entityTypes == nil {
+		c.entityTypes = make(map[int]string)
+	}
+	c.entityTypes[id] = rt
+	return rt, nil
+}
+
+// probeEntityType asks every ProbeEntityTypes endpoint for id and returns
+// the one type that has it, "" if none does, or an error if several do.
+func (c *Client) probeEntityType(ctx context.Context, id int) (string, error) {
+	var found []string
+	for _, t := range ProbeEntityTypes {
+		rt, err := c.resourceType(ctx, t, id)
+		if err != nil {
+			return "", err
+		}
+		if rt != "" {
+			found = append(found, rt)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return "", nil
+	case 1:
+		return found[0], nil
+	}
+	return "", fmt.Errorf("the ID is ambiguous: it matches a %s; pass --type to choose", strings.Join(found, " and a "))
+}
+
+// resourceType asks the v2 endpoint of entityType for id's resourceType. It
+// returns "" with no error when that endpoint has no such entity, which v2
+// reports as an empty result or, for some types, a 400 or 404.
+func (c *Client) resourceType(ctx context.Context, entityType string, id int) (string, error) {
+	data, err := c.QueryV2Entity(ctx, entityType, id, "resourceType")
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusBadRequest) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	var resp struct {
+		Items []struct {
+			ResourceType string `json:"resourceType"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return "", fmt.Errorf("parsing %s response: %w", entityType, err)
+	}
+	if len(resp.Items) == 0 {
+		return "", nil
+	}
+	return resp.Items[0].ResourceType, nil
+}
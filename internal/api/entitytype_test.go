@@ -0,0 +1,86 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/testutil"
+)
+
+func TestResolveEntityType_GeneralThenProbe(t *testing.T) {
+	resourceType := func(path, body string) testutil.Pair {
+		return testutil.Pair{
+			Request:  testutil.Request{Method: "GET", Path: path, Query: map[string]string{"select": "{resourceType}"}},
+			Response: testutil.Response{Status: 200, Body: json.RawMessage(body)},
+		}
+	}
+	ss := testutil.NewSimulationServer(&testutil.Simulation{Pairs: []testutil.Pair{
+		// The feature is a General; the comment is only found by probing.
+		resourceType("/api/v2/General/100", `{"items":[{"resourceType":"Feature"}]}`),
+		resourceType("/api/v2/General/200", `{"items":[]}`),
+		resourceType("/api/v2/Team/200", `{"items":[]}`),
+		resourceType("/api/v2/Comment/200", `{"items":[{"resourceType":"Comment"}]}`),
+		// GeneralUser and Time have no pair: the server's 404 counts as not found.
+	}})
+	defer ss.Close()
+
+	var debug bytes.Buffer
+	c := NewClient(ss.URL(), "tok", true)
+	c.debugOut = &debug
+
+	tests := []struct {
+		id   int
+		want string
+		via  string
+	}{
+		{100, "Feature", "via General"},
+		{200, "Comment", "via Comment probe"},
+	}
+	for _, tt := range tests {
+		got, err := c.ResolveEntityType(context.Background(), tt.id)
+		if err != nil || got != tt.want {
+			t.Errorf("ResolveEntityType(%d) = %q, %v; want %q", tt.id, got, err, tt.want)
+		}
+		if !strings.Contains(debug.String(), tt.via) {
+			t.Errorf("debug output does not mention %q:\n%s", tt.via, debug.String())
+		}
+	}
+
+	for _, r := range ss.Requests() {
+		if strings.HasPrefix(r.Path, "/api/v2/UserStory/") || strings.HasPrefix(r.Path, "/api/v2/Bug/") {
+			t.Errorf("probed the General type %s after General had no match", r.Path)
+		}
+	}
+}
+
+func TestResolveEntityType_AmbiguousProbe(t *testing.T) {
+	found := func(path, rt string) testutil.Pair {
+		return testutil.Pair{
+			Request:  testutil.Request{Method: "GET", Path: path},
+			Response: testutil.Response{Status: 200, Body: json.RawMessage(`{"items":[{"resourceType":"` + rt + `"}]}`)},
+		}
+	}
+	ss := testutil.NewSimulationServer(&testutil.Simulation{Pairs: []testutil.Pair{
+		found("/api/v2/Team/300", "Team"),
+		found("/api/v2/Time/300", "Time"),
+	}})
+	defer ss.Close()
+
+	_, err := NewClient(ss.URL(), "tok", false).ResolveEntityType(context.Background(), 300)
+	if err == nil || !strings.Contains(err.Error(), "ambiguous") || !strings.Contains(err.Error(), "Team and a Time") || !strings.Contains(err.Error(), "--type") {
+		t.Errorf("ResolveEntityType() error = %v, want an ambiguity error naming both types", err)
+	}
+}
+
+func TestResolveEntityType_NotFound(t *testing.T) {
+	ss := testutil.NewSimulationServer(&testutil.Simulation{})
+	defer ss.Close()
+
+	_, err := NewClient(ss.URL(), "tok", false).ResolveEntityType(context.Background(), 999)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("ResolveEntityType() error = %v, want not found", err)
+	}
+}
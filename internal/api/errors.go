@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -12,11 +14,23 @@ import (
 var (
 	regexNow           = regexp.MustCompile(`\bNow\b`)
 	regexColonSubfield = regexp.MustCompile(`\{[a-zA-Z]+:\{`)
-	regexTokenPattern  = regexp.MustCompile(`\b([a-zA-Z_]\w*(?:\.[a-zA-Z_]\w*)+)\b`)
-	regexAsPattern     = regexp.MustCompile(`\b([a-zA-Z_]\w*(?:\.[a-zA-Z_]\w*)+)\s+as\b`)
-	regexParenPattern  = regexp.MustCompile(`\([^)]*\)`)
+	regexDotPath       = regexp.MustCompile(`^[a-zA-Z_]\w*(?:\.[a-zA-Z_]\w*)+$`)
+	regexTopLevelAs    = regexp.MustCompile(`\sas(?:\s+\w+)?$`)
+	regexStringLiteral = regexp.MustCompile(`'[^']*'|"[^"]*"`)
+	regexV1Operator    = regexp.MustCompile(`(?i)(?:^|\s)(eq|ne|gt|gte|lt|lte)(?:\s|$)`)
+	regexEmptyAlias    = regexp.MustCompile(`\bas\s*(?:[,})]|$)`)
 )
 
+// v1Operators maps v1 where operators to their v2 equivalents.
+var v1Operators = map[string]string{
+	"eq":  "==",
+	"ne":  "!=",
+	"gt":  ">",
+	"gte": ">=",
+	"lt":  "<",
+	"lte": "<=",
+}
+
 // errorPattern defines a known API error pattern and its suggested fix.
 type errorPattern struct {
 	// Name is a short identifier for the pattern (for debugging/logging).
@@ -30,8 +44,22 @@ type errorPattern struct {
 }
 
 // knownPatterns is the list of known API error patterns with fix suggestions.
-// Order matters: first match wins.
+// Order matters: first match wins in EnhanceError; MatchPatterns reports all.
 var knownPatterns = []errorPattern{
+	{
+		Name: "unauthorized",
+		Match: func(apiErr *APIError, path string, params map[string]string) bool {
+			return apiErr.StatusCode == http.StatusUnauthorized
+		},
+		Hint: "The access token was rejected: it is missing, mistyped, revoked, or expired. Set a new one with tp login or tp config set token <token> (TP_TOKEN overrides the config), and check the domain with tp config get domain.",
+	},
+	{
+		Name: "forbidden",
+		Match: func(apiErr *APIError, path string, params map[string]string) bool {
+			return apiErr.StatusCode == http.StatusForbidden
+		},
+		Hint: "The token is valid but its user lacks permission for this entity or operation. Check the user's role and project access in Targetprocess, or use a token for a user who has it.",
+	},
 	{
 		Name: "is-null",
 		Match: func(apiErr *APIError, path string, params map[string]string) bool {
@@ -69,6 +97,29 @@ var knownPatterns = []errorPattern{
 		},
 		Hint: "Use ==null instead of 'is null', or !=null instead of 'is not null'.",
 	},
+	{
+		Name: "v1-operator-in-v2",
+		Match: func(apiErr *APIError, path string, params map[string]string) bool {
+			return apiErr.StatusCode == http.StatusBadRequest &&
+				strings.Contains(path, "/api/v2/") &&
+				len(findV1Operators(params["where"])) > 0
+		},
+		Hint: "v2 where clauses don't use v1 word operators (eq, ne, gt, gte, lt, lte). Use ==, !=, >, >=, <, <= instead. Example: general.id==5 (not General.Id eq 5)",
+	},
+	{
+		Name: "single-equals",
+		Match: func(apiErr *APIError, path string, params map[string]string) bool {
+			return apiErr.StatusCode == http.StatusBadRequest && hasSingleEquals(params["where"])
+		},
+		Hint: "v2 compares with ==, not =. Example: name==\"Login\" (not name=\"Login\")",
+	},
+	{
+		Name: "capitalized-boolean",
+		Match: func(apiErr *APIError, path string, params map[string]string) bool {
+			return apiErr.StatusCode == http.StatusBadRequest && hasCapitalizedBoolean(params["where"])
+		},
+		Hint: "v2 booleans are lower-case. Example: entityState.isFinal==true (not True or TRUE)",
+	},
 	{
 		Name: "datetime-minus-int",
 		Match: func(apiErr *APIError, path string, params map[string]string) bool {
@@ -137,7 +188,7 @@ var knownPatterns = []errorPattern{
 			_, hasInclude := params["include"]
 			return hasInclude && strings.Contains(path, "/api/v2/")
 		},
-		Hint: "'include' is a v1 parameter. In v2, use 'select' instead: select={id,name,field}",
+		Hint: "'include' is a v1 parameter. In v2, use 'select' instead: select={id,name,field}, or pass tp query --include to add related names and counts.",
 	},
 	{
 		Name: "wrong-entity-plural-404",
@@ -149,6 +200,20 @@ var knownPatterns = []errorPattern{
 		},
 		Hint: "v2 uses singular entity names. Example: /api/v2/UserStory (not /api/v2/UserStorys or /api/v2/UserStories).",
 	},
+	{
+		Name: "bare-collection",
+		Match: func(apiErr *APIError, path string, params map[string]string) bool {
+			return apiErr.StatusCode >= 400 && bareCollection(params["where"], params["select"]) != ""
+		},
+		Hint: "A collection such as userStories can't be used as a value. Use userStories.count, userStories.select({id,name}) as stories, or userStories.where(condition).count.",
+	},
+	{
+		Name: "take-over-limit",
+		Match: func(apiErr *APIError, path string, params map[string]string) bool {
+			return apiErr.StatusCode >= 400 && takeOverLimit(path, params["take"])
+		},
+		Hint: "take is capped at 1000 per request. Page with take and skip, or use tp query --all to fetch every page.",
+	},
 	{
 		Name: "orderby-aggregate",
 		Match: func(apiErr *APIError, path string, params map[string]string) bool {
@@ -158,10 +223,7 @@ var knownPatterns = []errorPattern{
 			if !strings.Contains(apiErr.Body, "issues with generated report") {
 				return false
 			}
-			orderBy := params["orderBy"]
-			return strings.Contains(orderBy, "count") || strings.Contains(orderBy, "sum") ||
-				strings.Contains(orderBy, "avg") || strings.Contains(orderBy, "Count") ||
-				strings.Contains(orderBy, "Sum") || strings.Contains(orderBy, "Avg")
+			return hasAggregateOrder(params["orderBy"])
 		},
 		Hint: "Ordering by aggregate fields is not supported in v2. Sort results client-side.",
 	},
@@ -175,7 +237,7 @@ var knownPatterns = []errorPattern{
 			selectParam := params["select"]
 			return strings.Contains(selectParam, "count") || strings.Contains(selectParam, "Count")
 		},
-		Hint: "Top-level groupBy with count is not well supported. Query from the parent entity using a collection instead.",
+		Hint: "Top-level groupBy with count is not well supported. Query from the parent entity using a collection instead, or count client-side with tp query --group-by FIELD.",
 	},
 	{
 		Name: "colon-subfield-syntax",
@@ -190,6 +252,109 @@ var knownPatterns = []errorPattern{
 	},
 }
 
+// maxV2Take is the largest take the v2 API accepts.
+const maxV2Take = 1000
+
+// regexSingleEquals matches an = that is not part of ==, !=, >=, or <=.
+var regexSingleEquals = regexp.MustCompile(`(?:^|[^=!<>])=(?:[^=]|$)`)
+
+// regexBoolean matches true or false in any case.
+var regexBoolean = regexp.MustCompile(`(?i)\b(?:true|false)\b`)
+
+// collectionNames are v2 collections people compare or select bare.
+var collectionNames = map[string]bool{
+	"userStories": true, "tasks": true, "bugs": true, "features": true,
+	"epics": true, "comments": true, "assignments": true, "times": true,
+	"impediments": true, "attachments": true, "testCases": true,
+	"requests": true, "relations": true, "masterRelations": true,
+	"slaveRelations": true,
+}
+
+// hasSingleEquals reports whether a where expression compares with a single
+// =, ignoring string literals.
+func hasSingleEquals(where string) bool {
+	return regexSingleEquals.MatchString(regexStringLiteral.ReplaceAllString(where, "''"))
+}
+
+// hasCapitalizedBoolean reports whether a where expression spells true or
+// false with capitals, ignoring string literals.
+func hasCapitalizedBoolean(where string) bool {
+	for _, b := range regexBoolean.FindAllString(regexStringLiteral.ReplaceAllString(where, "''"), -1) {
+		if b != strings.ToLower(b) {
+			return true
+		}
+	}
+	return false
+}
+
+// bareCollection returns the first collection used as a plain value in where
+// or as a top-level select item, without .count, .select, or .where, or "".
+func bareCollection(where, selectExpr string) string {
+	for _, item := range topLevelSelectItems(selectExpr) {
+		name := strings.TrimSpace(flattenNested(item))
+		if i := strings.Index(name, " "); i >= 0 {
+			name = name[:i]
+		}
+		if collectionNames[name] {
+			return name
+		}
+	}
+	stripped := regexStringLiteral.ReplaceAllString(where, "''")
+	for _, loc := range regexWord.FindAllStringIndex(stripped, -1) {
+		name := stripped[loc[0]:loc[1]]
+		if !collectionNames[name] {
+			continue
+		}
+		if loc[0] > 0 && stripped[loc[0]-1] == '.' {
+			continue // a field of something else, e.g. feature.userStories
+		}
+		if loc[1] < len(stripped) && (stripped[loc[1]] == '.' || stripped[loc[1]] == '(') {
+			continue
+		}
+		return name
+	}
+	return ""
+}
+
+// takeOverLimit reports whether the take parameter, or a take= in the path's
+// query string, exceeds what v2 accepts.
+func takeOverLimit(path, take string) bool {
+	if take == "" {
+		if i := strings.Index(path, "?"); i >= 0 {
+			if q, err := url.ParseQuery(path[i+1:]); err == nil {
+				take = q.Get("take")
+			}
+		}
+	}
+	n, err := strconv.Atoi(take)
+	return err == nil && n > maxV2Take
+}
+
+// HintError is an API error that matched a known error pattern. It keeps the
+// pattern name and hint as separate fields so callers can surface them in
+// structured output; Error() renders the same text as before.
+type HintError struct {
+	// Pattern is the name of the matched known pattern (e.g. "is-null").
+	Pattern string
+
+	// Hint is the suggested fix.
+	Hint string
+
+	// APIErr is the underlying API error response.
+	APIErr *APIError
+
+	// Err is the original error, which wraps APIErr.
+	Err error
+}
+
+func (e *HintError) Error() string {
+	return fmt.Sprintf("%v\n\nHint: %s", e.Err, e.Hint)
+}
+
+func (e *HintError) Unwrap() error {
+	return e.Err
+}
+
 // endsWithPlural checks if the path's last segment looks like a naive plural (ends with 's').
 func endsWithPlural(path string) bool {
 	// Strip query string if present.
@@ -218,7 +383,7 @@ func endsWithPlural(path string) bool {
 }
 
 // EnhanceError checks if an API error matches known patterns and returns
-// an enhanced error message with fix suggestions. If no pattern matches,
+// a *HintError carrying the fix suggestion. If no pattern matches,
 // returns the original error unchanged.
 func EnhanceError(err error, path string, params map[string]string) error {
 	if err == nil {
@@ -236,65 +401,262 @@ func EnhanceError(err error, path string, params map[string]string) error {
 
 	for _, p := range knownPatterns {
 		if p.Match(apiErr, path, params) {
-			return fmt.Errorf("%w\n\nHint: %s", err, p.Hint)
+			return &HintError{Pattern: p.Name, Hint: p.Hint, APIErr: apiErr, Err: err}
 		}
 	}
 
 	return err
 }
 
+// PatternMatch is a known error pattern that matched an API error.
+type PatternMatch struct {
+	Pattern string
+	Hint    string
+}
+
+// MatchPatterns returns every known pattern that matches an API error, in
+// the order they are checked. Unlike EnhanceError it does not stop at the
+// first match, so overlapping problems are all reported.
+func MatchPatterns(err error, path string, params map[string]string) []PatternMatch {
+	var apiErr *APIError
+	if err == nil || !errors.As(err, &apiErr) {
+		return nil
+	}
+
+	if params == nil {
+		params = map[string]string{}
+	}
+
+	var matches []PatternMatch
+	for _, p := range knownPatterns {
+		if p.Match(apiErr, path, params) {
+			matches = append(matches, PatternMatch{Pattern: p.Name, Hint: p.Hint})
+		}
+	}
+	return matches
+}
+
+// FormatExplain renders pattern matches as a delimited section for --explain-errors.
+func FormatExplain(matches []PatternMatch) string {
+	var sb strings.Builder
+	sb.WriteString("--- explain: matching error patterns ---\n")
+	if len(matches) == 0 {
+		sb.WriteString("(no known patterns matched)\n")
+	}
+	for i, m := range matches {
+		fmt.Fprintf(&sb, "%d. %s\n   %s\n", i+1, m.Pattern, m.Hint)
+	}
+	sb.WriteString("--- end explain ---")
+	return sb.String()
+}
+
 // WarnSelectDotPaths checks for dot-path fields in a select expression
 // that are missing 'as' aliases. These fields are silently dropped by the API.
+// Only top-level items count: anything inside the parentheses or braces of
+// select({...}), where(...), or a method call is left alone, at any depth.
 // Returns a warning message or empty string.
 func WarnSelectDotPaths(selectExpr string) string {
-	if selectExpr == "" {
-		return ""
+	var missing []string
+	seen := make(map[string]bool)
+	for _, item := range topLevelSelectItems(selectExpr) {
+		flat := strings.TrimSpace(flattenNested(item))
+		if regexTopLevelAs.MatchString(flat) {
+			continue
+		}
+		if regexDotPath.MatchString(flat) && !seen[flat] {
+			missing = append(missing, flat)
+			seen[flat] = true
+		}
 	}
 
-	allDotPaths := regexTokenPattern.FindAllString(selectExpr, -1)
-	if len(allDotPaths) == 0 {
+	if len(missing) == 0 {
 		return ""
 	}
 
-	aliased := make(map[string]bool)
-	for _, match := range regexAsPattern.FindAllStringSubmatch(selectExpr, -1) {
-		aliased[match[1]] = true
+	var sb strings.Builder
+	sb.WriteString("Warning: These dot-path fields in select are missing 'as' aliases and will be silently dropped by the API:\n")
+	for _, m := range missing {
+		fmt.Fprintf(&sb, "  - %s  (add: %s as %s)\n", m, m, suggestAlias(m))
+	}
+	return sb.String()
+}
+
+// topLevelSelectItems splits a select expression at the commas that are not
+// inside parentheses, braces, or string literals. An expression wrapped in
+// one pair of braces, as in {id,name}, is unwrapped first.
+func topLevelSelectItems(expr string) []string {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "{") && strings.HasSuffix(expr, "}") && closingBrace(expr) == len(expr)-1 {
+		expr = expr[1 : len(expr)-1]
+	}
+	if expr == "" {
+		return nil
 	}
 
-	// Find dot-paths that appear inside parentheses (e.g., .where(entityState.isFinal==true))
-	// These are collection sub-expressions and should not trigger warnings.
-	insideParens := make(map[string]bool)
-	for _, match := range regexParenPattern.FindAllString(selectExpr, -1) {
-		for _, dp := range regexTokenPattern.FindAllString(match, -1) {
-			insideParens[dp] = true
+	var items []string
+	depth, start := 0, 0
+	var quote rune
+	for i, r := range expr {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '(' || r == '{':
+			depth++
+		case r == ')' || r == '}':
+			depth--
+		case r == ',' && depth == 0:
+			items = append(items, expr[start:i])
+			start = i + 1
 		}
 	}
+	return append(items, expr[start:])
+}
 
-	var missing []string
-	seen := make(map[string]bool)
-	for _, dp := range allDotPaths {
-		// Skip things that look like method calls (e.g., Today.AddDays).
-		if strings.Contains(selectExpr, dp+"(") {
-			continue
+// closingBrace returns the index of the brace closing the one at s[0], or -1.
+func closingBrace(s string) int {
+	depth := 0
+	for i, r := range s {
+		switch r {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
 		}
-		// Skip dot-paths inside parentheses (collection sub-expressions).
-		if insideParens[dp] {
+	}
+	return -1
+}
+
+// flattenNested drops string literals and everything inside parentheses or
+// braces, keeping the brackets: tasks.select({id,name}) as t becomes
+// tasks.select() as t.
+func flattenNested(item string) string {
+	var sb strings.Builder
+	depth := 0
+	var quote rune
+	for _, r := range item {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+			continue
+		case r == '\'' || r == '"':
+			quote = r
+			continue
+		case r == '(' || r == '{':
+			if depth == 0 {
+				sb.WriteRune(r)
+			}
+			depth++
+			continue
+		case r == ')' || r == '}':
+			depth--
+			if depth == 0 {
+				sb.WriteRune(r)
+			}
 			continue
 		}
-		if !aliased[dp] && !seen[dp] {
-			missing = append(missing, dp)
-			seen[dp] = true
+		if depth == 0 {
+			sb.WriteRune(r)
 		}
 	}
+	return sb.String()
+}
 
-	if len(missing) == 0 {
+// ValidateSelect checks a select expression for mistakes the API would reject
+// or silently mishandle: the {field:{subfield}} colon syntax, unbalanced
+// braces or parentheses, and an 'as' with no alias after it.
+// Returns a warning message or empty string.
+func ValidateSelect(selectExpr string) string {
+	if selectExpr == "" {
+		return ""
+	}
+	stripped := regexStringLiteral.ReplaceAllString(selectExpr, "''")
+
+	var problems []string
+	if regexColonSubfield.MatchString(stripped) {
+		problems = append(problems, "{field:{subfield}} syntax can return incorrect data (use: field.subfield as alias)")
+	}
+	if msg := checkBalanced(stripped); msg != "" {
+		problems = append(problems, msg)
+	}
+	if regexEmptyAlias.MatchString(stripped) {
+		problems = append(problems, "'as' without an alias name (use: field as alias)")
+	}
+
+	if len(problems) == 0 {
 		return ""
 	}
 
 	var sb strings.Builder
-	sb.WriteString("Warning: These dot-path fields in select are missing 'as' aliases and will be silently dropped by the API:\n")
-	for _, m := range missing {
-		fmt.Fprintf(&sb, "  - %s  (add: %s as %s)\n", m, m, suggestAlias(m))
+	sb.WriteString("Warning: The select expression looks malformed:\n")
+	for _, p := range problems {
+		fmt.Fprintf(&sb, "  - %s\n", p)
+	}
+	return sb.String()
+}
+
+// checkBalanced reports the first unmatched brace or parenthesis in s, or "".
+func checkBalanced(s string) string {
+	pairs := map[rune]rune{')': '(', '}': '{'}
+	var stack []rune
+	for _, r := range s {
+		switch r {
+		case '(', '{':
+			stack = append(stack, r)
+		case ')', '}':
+			if len(stack) == 0 || stack[len(stack)-1] != pairs[r] {
+				return fmt.Sprintf("unexpected '%c' (unbalanced braces/parentheses)", r)
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if len(stack) > 0 {
+		return fmt.Sprintf("unclosed '%c' (unbalanced braces/parentheses)", stack[len(stack)-1])
+	}
+	return ""
+}
+
+// findV1Operators returns the v1-style word operators (eq, ne, gt, ...) used in
+// a where expression, ignoring anything inside string literals.
+func findV1Operators(where string) []string {
+	if where == "" {
+		return nil
+	}
+	stripped := regexStringLiteral.ReplaceAllString(where, "''")
+
+	var found []string
+	seen := make(map[string]bool)
+	for _, m := range regexV1Operator.FindAllStringSubmatch(stripped, -1) {
+		op := strings.ToLower(m[1])
+		if !seen[op] {
+			seen[op] = true
+			found = append(found, op)
+		}
+	}
+	return found
+}
+
+// WarnWhereV1Operators checks a v2 where expression for v1-style word
+// operators, which the v2 API rejects with a cryptic parse error.
+// Returns a warning message or empty string.
+func WarnWhereV1Operators(where string) string {
+	ops := findV1Operators(where)
+	if len(ops) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Warning: The where clause uses v1 operators, which the v2 API does not understand:\n")
+	for _, op := range ops {
+		fmt.Fprintf(&sb, "  - %s  (use: %s)\n", op, v1Operators[op])
 	}
 	return sb.String()
 }
@@ -0,0 +1,258 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestEnhanceError_V1Operator(t *testing.T) {
+	err := &APIError{StatusCode: 400, Body: "mismatched input 'eq'"}
+	got := EnhanceError(err, "/api/v2/Bug", map[string]string{"where": "General.Id eq 5"})
+	if !strings.Contains(got.Error(), "v1 word operators") {
+		t.Errorf("expected v1 operator hint, got: %v", got)
+	}
+	if !errors.Is(got, err) {
+		t.Error("enhanced error should wrap the original")
+	}
+}
+
+func TestFindV1Operators(t *testing.T) {
+	tests := []struct {
+		where string
+		want  []string
+	}{
+		{"General.Id eq 5", []string{"eq"}},
+		{"effort GT 3 and effort lte 10", []string{"gt", "lte"}},
+		{"id==5", nil},
+		{"name=='eq ne gt'", nil},
+		{`name.contains("a gt b")`, nil},
+		{"request.id==1", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.where, func(t *testing.T) {
+			got := findV1Operators(tt.where)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("findV1Operators(%q) = %v, want %v", tt.where, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWarnSelectDotPaths(t *testing.T) {
+	tests := []struct {
+		name   string
+		expr   string
+		wantIn []string
+		noWarn bool
+	}{
+		{name: "empty", expr: "", noWarn: true},
+		{name: "plain fields", expr: "id,name", noWarn: true},
+		{name: "aliased dot-path", expr: "id,entityState.name as state", noWarn: true},
+		{name: "unaliased dot-path", expr: "id,entityState.name", wantIn: []string{"entityState.name  (add: entityState.name as name)"}},
+		{name: "aliased collection projection", expr: "id,tasks.select({id,name,entityState.name}) as t", noWarn: true},
+		{name: "nested where and select", expr: "features.where(userStories.where(entityState.isFinal==true).count>0).select({id,owner.login}) as f", noWarn: true},
+		{name: "count with where", expr: "userStories.where(entityState.isFinal==true).count as done", noWarn: true},
+		{name: "dot-path inside a string literal", expr: `id,name.contains("a.b") as hit`, noWarn: true},
+		{name: "braced select", expr: "{id,owner.login}", wantIn: []string{"owner.login  (add: owner.login as login)"}},
+		{
+			name:   "mixed",
+			expr:   "id,tasks.select({id,name}) as t,project.name,entityState.name as state,owner.login",
+			wantIn: []string{"  - project.name ", "  - owner.login "},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warn := WarnSelectDotPaths(tt.expr)
+			if tt.noWarn {
+				if warn != "" {
+					t.Errorf("WarnSelectDotPaths(%q) = %q, want no warning", tt.expr, warn)
+				}
+				return
+			}
+			for _, want := range tt.wantIn {
+				if !strings.Contains(warn, want) {
+					t.Errorf("WarnSelectDotPaths(%q) = %q, want it to contain %q", tt.expr, warn, want)
+				}
+			}
+			if strings.Contains(warn, "state") || strings.Contains(warn, "tasks") {
+				t.Errorf("WarnSelectDotPaths(%q) = %q, flagged an aliased item", tt.expr, warn)
+			}
+		})
+	}
+}
+
+func TestWarnWhereV1Operators(t *testing.T) {
+	if warn := WarnWhereV1Operators("entityState.isFinal!=true"); warn != "" {
+		t.Errorf("expected no warning for v2 syntax, got %q", warn)
+	}
+	warn := WarnWhereV1Operators("General.Id eq 5")
+	if !strings.Contains(warn, "eq  (use: ==)") {
+		t.Errorf("expected eq suggestion, got %q", warn)
+	}
+}
+
+func TestEnhanceError_HintError(t *testing.T) {
+	apiErr := &APIError{StatusCode: 400, Body: "mismatched input 'is'"}
+	wrapped := fmt.Errorf("querying: %w", apiErr)
+	got := EnhanceError(wrapped, "/api/v2/Bug", map[string]string{"where": "description is null"})
+
+	var hintErr *HintError
+	if !errors.As(got, &hintErr) {
+		t.Fatalf("expected *HintError, got %T", got)
+	}
+	if hintErr.Pattern != "is-null" {
+		t.Errorf("Pattern = %q, want is-null", hintErr.Pattern)
+	}
+	if hintErr.APIErr != apiErr {
+		t.Error("APIErr should be the original API error")
+	}
+	if !strings.HasSuffix(got.Error(), "\n\nHint: "+hintErr.Hint) {
+		t.Errorf("Error() should end with the hint, got %q", got.Error())
+	}
+
+	var asAPI *APIError
+	if !errors.As(got, &asAPI) {
+		t.Error("errors.As should still find the *APIError")
+	}
+}
+
+func TestEnhanceError_NoMatch(t *testing.T) {
+	err := &APIError{StatusCode: 500, Body: "boom"}
+	if got := EnhanceError(err, "/api/v2/Bug", nil); got != error(err) {
+		t.Errorf("expected original error, got %v", got)
+	}
+}
+
+func TestMatchPatterns_ReportsAll(t *testing.T) {
+	err := &APIError{StatusCode: 400, Body: "mismatched input 'is'"}
+	got := MatchPatterns(err, "/api/v2/Bug", map[string]string{"where": "description is null and modifyDate > Now"})
+
+	var names []string
+	for _, m := range got {
+		names = append(names, m.Pattern)
+	}
+	want := "is-null,is-mismatched-generic,now-not-recognized"
+	if strings.Join(names, ",") != want {
+		t.Errorf("MatchPatterns = %v, want %s", names, want)
+	}
+
+	explain := FormatExplain(got)
+	if !strings.HasPrefix(explain, "--- explain") || !strings.HasSuffix(explain, "--- end explain ---") {
+		t.Errorf("explain section should be delimited, got %q", explain)
+	}
+}
+
+func TestMatchPatterns_NotAPIError(t *testing.T) {
+	if got := MatchPatterns(errors.New("boom"), "/api/v2/Bug", nil); got != nil {
+		t.Errorf("expected no matches, got %v", got)
+	}
+}
+
+func TestValidateSelect(t *testing.T) {
+	tests := []struct {
+		name   string
+		expr   string
+		expect string // substring of the warning; "" means no warning
+	}{
+		{"valid", "id,name,entityState.name as state,tasks.select({id,name}) as tasks", ""},
+		{"empty", "", ""},
+		{"colon subfield", "id,{project:{name}}", "{field:{subfield}}"},
+		{"unclosed paren", "id,tasks.where(effort>0.count", "unclosed '('"},
+		{"stray brace", "id,name}", "unexpected '}'"},
+		{"brace in string literal", `id,name.contains("}") as x`, ""},
+		{"empty alias at end", "id,entityState.name as", "without an alias"},
+		{"empty alias before comma", "id,entityState.name as ,name", "without an alias"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateSelect(tt.expr)
+			if tt.expect == "" {
+				if got != "" {
+					t.Errorf("expected no warning, got %q", got)
+				}
+				return
+			}
+			if !strings.Contains(got, tt.expect) {
+				t.Errorf("expected warning containing %q, got %q", tt.expect, got)
+			}
+		})
+	}
+}
+
+// patternByName returns the known pattern with the given name.
+func patternByName(t *testing.T, name string) errorPattern {
+	t.Helper()
+	for _, p := range knownPatterns {
+		if p.Name == name {
+			return p
+		}
+	}
+	t.Fatalf("no known pattern named %q", name)
+	return errorPattern{}
+}
+
+func TestKnownPatterns_V2Mistakes(t *testing.T) {
+	tests := []struct {
+		pattern string
+		status  int
+		body    string
+		path    string
+		params  map[string]string
+		want    bool
+	}{
+		{"single-equals", 400, "mismatched input '='", "/api/v2/Bug", map[string]string{"where": `name="Login"`}, true},
+		{"single-equals", 400, "", "/api/v2/Bug", map[string]string{"where": "effort>=3 and name!='a=b'"}, false},
+		{"single-equals", 400, "", "/api/v2/Bug", map[string]string{"where": "id==5"}, false},
+		{"capitalized-boolean", 400, "", "/api/v2/Bug", map[string]string{"where": "entityState.isFinal==True"}, true},
+		{"capitalized-boolean", 400, "", "/api/v2/Bug", map[string]string{"where": "isNext==FALSE"}, true},
+		{"capitalized-boolean", 400, "", "/api/v2/Bug", map[string]string{"where": "entityState.isFinal==true and name=='True'"}, false},
+		{"bare-collection", 400, "issues with generated report", "/api/v2/Feature", map[string]string{"select": "id,userStories"}, true},
+		{"bare-collection", 400, "", "/api/v2/Feature", map[string]string{"where": "tasks!=null"}, true},
+		{"bare-collection", 400, "", "/api/v2/Feature", map[string]string{"select": "id,userStories.count as n", "where": "tasks.where(effort>0).count>0"}, false},
+		{"take-over-limit", 400, "", "/api/v2/Bug", map[string]string{"take": "5000"}, true},
+		{"take-over-limit", 400, "", "/api/v2/Bug?take=2000&skip=0", nil, true},
+		{"take-over-limit", 400, "", "/api/v2/Bug", map[string]string{"take": "1000"}, false},
+		{"take-over-limit", 200, "", "/api/v2/Bug", map[string]string{"take": "5000"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			p := patternByName(t, tt.pattern)
+			params := tt.params
+			if params == nil {
+				params = map[string]string{}
+			}
+			got := p.Match(&APIError{StatusCode: tt.status, Body: tt.body}, tt.path, params)
+			if got != tt.want {
+				t.Errorf("%s.Match(%v) = %v, want %v", tt.pattern, params, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnhanceError_AuthStatus(t *testing.T) {
+	tests := []struct {
+		status  int
+		pattern string
+		hint    string
+	}{
+		{401, "unauthorized", "tp login"},
+		{403, "forbidden", "lacks permission"},
+	}
+	for _, tt := range tests {
+		// include would match include-in-v2 too; the status pattern must win.
+		got := EnhanceError(&APIError{StatusCode: tt.status, Body: "<html>denied</html>"}, "/api/v2/Bug", map[string]string{"include": "Owner"})
+		var hintErr *HintError
+		if !errors.As(got, &hintErr) {
+			t.Fatalf("status %d: expected *HintError, got %T", tt.status, got)
+		}
+		if hintErr.Pattern != tt.pattern || !strings.Contains(hintErr.Hint, tt.hint) {
+			t.Errorf("status %d: got pattern %q hint %q, want %q containing %q", tt.status, hintErr.Pattern, hintErr.Hint, tt.pattern, tt.hint)
+		}
+	}
+	if got := EnhanceError(&APIError{StatusCode: 500, Body: "boom"}, "/api/v1/Bugs", nil); errors.As(got, new(*HintError)) {
+		t.Errorf("status 500 should not get an auth hint, got %v", got)
+	}
+}
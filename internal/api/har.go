@@ -0,0 +1,235 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HAR 1.2 document types, limited to the fields tp fills in.
+type (
+	harLog struct {
+		Log harBody `json:"log"`
+	}
+	harBody struct {
+		Version string     `json:"version"`
+		Creator harCreator `json:"creator"`
+		Entries []harEntry `json:"entries"`
+	}
+	harCreator struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	harEntry struct {
+		StartedDateTime time.Time   `json:"startedDateTime"`
+		Time            int64       `json:"time"`
+		Request         harRequest  `json:"request"`
+		Response        harResponse `json:"response"`
+		Cache           struct{}    `json:"cache"`
+		Timings         harTimings  `json:"timings"`
+		Comment         string      `json:"comment,omitempty"`
+	}
+	harRequest struct {
+		Method      string       `json:"method"`
+		URL         string       `json:"url"`
+		HTTPVersion string       `json:"httpVersion"`
+		Cookies     []struct{}   `json:"cookies"`
+		Headers     []harNameVal `json:"headers"`
+		QueryString []harNameVal `json:"queryString"`
+		PostData    *harPostData `json:"postData,omitempty"`
+		HeadersSize int          `json:"headersSize"`
+		BodySize    int          `json:"bodySize"`
+	}
+	harResponse struct {
+		Status      int          `json:"status"`
+		StatusText  string       `json:"statusText"`
+		HTTPVersion string       `json:"httpVersion"`
+		Cookies     []struct{}   `json:"cookies"`
+		Headers     []harNameVal `json:"headers"`
+		Content     harContent   `json:"content"`
+		RedirectURL string       `json:"redirectURL"`
+		HeadersSize int          `json:"headersSize"`
+		BodySize    int          `json:"bodySize"`
+	}
+	harNameVal struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+	harPostData struct {
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text"`
+	}
+	harContent struct {
+		Size     int    `json:"size"`
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text"`
+	}
+	harTimings struct {
+		Send    int64 `json:"send"`
+		Wait    int64 `json:"wait"`
+		Receive int64 `json:"receive"`
+	}
+)
+
+// HARTransport is an http.RoundTripper that records every HTTP attempt as a
+// HAR 1.2 entry and rewrites the document at Path after each one, so the
+// file is complete even if the process exits early. Credentials are
+// redacted from URLs, query strings, and headers the same way as debug
+// output. Response bodies are buffered in memory.
+type HARTransport struct {
+	Base http.RoundTripper
+	Path string
+
+	mu      sync.Mutex
+	entries []harEntry
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *HARTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	entry := harEntry{
+		StartedDateTime: start,
+		Request:         harRequestFor(req),
+	}
+
+	resp, err := t.base().RoundTrip(req)
+	if err != nil {
+		entry.Time = time.Since(start).Milliseconds()
+		entry.Timings.Wait = entry.Time
+		entry.Comment = redactURLError(err).Error()
+		entry.Response = harResponse{HTTPVersion: "HTTP/1.1", Cookies: []struct{}{}, Headers: []harNameVal{}, BodySize: -1, HeadersSize: -1}
+		t.record(entry)
+		return nil, err
+	}
+	waited := time.Since(start)
+
+	body, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if readErr != nil {
+		return nil, fmt.Errorf("reading response: %w", readErr)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	entry.Time = time.Since(start).Milliseconds()
+	entry.Timings = harTimings{Wait: waited.Milliseconds(), Receive: entry.Time - waited.Milliseconds()}
+	entry.Response = harResponse{
+		Status:      resp.StatusCode,
+		StatusText:  http.StatusText(resp.StatusCode),
+		HTTPVersion: resp.Proto,
+		Cookies:     []struct{}{},
+		Headers:     harHeaders(resp.Header),
+		Content: harContent{
+			Size:     len(body),
+			MimeType: resp.Header.Get("Content-Type"),
+			Text:     string(body),
+		},
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+	t.record(entry)
+	return resp, nil
+}
+
+func (t *HARTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// record appends entry and rewrites the HAR file. Writing is best-effort;
+// a failed trace must not fail the request.
+func (t *HARTransport) record(entry harEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = append(t.entries, entry)
+	doc := harLog{Log: harBody{
+		Version: "1.2",
+		Creator: harCreator{Name: "tp-cli", Version: clientVersion},
+		Entries: t.entries,
+	}}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return
+	}
+	_ = os.WriteFile(t.Path, buf.Bytes(), 0o600)
+}
+
+// harRequestFor describes req with credentials redacted. The body is read
+// through GetBody so the request itself is left untouched.
+func harRequestFor(req *http.Request) harRequest {
+	redacted := redactToken(req.URL.String())
+	hr := harRequest{
+		Method:      req.Method,
+		URL:         redacted,
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []struct{}{},
+		Headers:     harHeaders(req.Header),
+		QueryString: []harNameVal{},
+		HeadersSize: -1,
+		BodySize:    0,
+	}
+	if u, err := url.Parse(redacted); err == nil {
+		hr.QueryString = harQuery(u.Query())
+	}
+	if req.GetBody != nil && req.ContentLength != 0 {
+		if rc, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(rc)
+			rc.Close()
+			hr.BodySize = len(data)
+			hr.PostData = &harPostData{MimeType: req.Header.Get("Content-Type"), Text: string(data)}
+		}
+	}
+	return hr
+}
+
+// harHeaders lists headers sorted by name, with credential-bearing values
+// replaced.
+func harHeaders(h http.Header) []harNameVal {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	out := []harNameVal{}
+	for _, name := range names {
+		for _, value := range h[name] {
+			if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+				value = redactedValue
+			}
+			out = append(out, harNameVal{Name: name, Value: value})
+		}
+	}
+	return out
+}
+
+func harQuery(q url.Values) []harNameVal {
+	names := make([]string, 0, len(q))
+	for name := range q {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	out := []harNameVal{}
+	for _, name := range names {
+		for _, value := range q[name] {
+			out = append(out, harNameVal{Name: name, Value: value})
+		}
+	}
+	return out
+}
+
+// RecordHAR writes every HTTP attempt the client makes to a HAR file at
+// path. Like LogTo, call it before CacheTo.
+func (c *Client) RecordHAR(path string) {
+	c.rc.HTTPClient.Transport = &HARTransport{Base: c.rc.HTTPClient.Transport, Path: path}
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordHAR_WritesRedactedEntries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"Id":7}`)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "trace.har")
+	c := NewClient(srv.URL, "secret-token", false)
+	c.AuthMode = AuthBearer
+	c.RecordHAR(path)
+	if _, err := c.QueryV2(context.Background(), "Bug", V2Params{Take: 1}); err != nil {
+		t.Fatalf("QueryV2() error = %v", err)
+	}
+	if _, err := c.CreateEntity(context.Background(), "Bug", map[string]any{"Name": "x"}); err != nil {
+		t.Fatalf("CreateEntity() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading HAR: %v", err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Fatalf("HAR leaks the token: %s", data)
+	}
+	var doc harLog
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("HAR is not JSON: %v", err)
+	}
+	if doc.Log.Version != "1.2" || len(doc.Log.Entries) != 2 {
+		t.Fatalf("log version = %q, entries = %d; want 1.2 with 2 entries", doc.Log.Version, len(doc.Log.Entries))
+	}
+
+	get := doc.Log.Entries[0]
+	if get.Request.Method != http.MethodGet || !strings.Contains(get.Request.URL, "/api/v2/Bug") {
+		t.Errorf("first request = %s %s, want GET on v2 Bug", get.Request.Method, get.Request.URL)
+	}
+	if get.Response.Status != http.StatusOK || get.Response.Content.Text != `{"Id":7}` {
+		t.Errorf("first response = %d %q", get.Response.Status, get.Response.Content.Text)
+	}
+	for _, h := range get.Request.Headers {
+		if h.Name == "Authorization" && h.Value != redactedValue {
+			t.Errorf("Authorization header = %q, want redacted", h.Value)
+		}
+	}
+
+	post := doc.Log.Entries[1]
+	if post.Request.Method != http.MethodPost || post.Request.PostData == nil || !strings.Contains(post.Request.PostData.Text, `"Name"`) {
+		t.Errorf("second request = %+v, want POST with the JSON body", post.Request)
+	}
+}
+
+func TestRecordHAR_RedactsResponseCookies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Add("Set-Cookie", "session=secret-session; HttpOnly")
+		w.Header().Set("WWW-Authenticate", `Bearer realm="secret-realm"`)
+		fmt.Fprint(w, `{"items":[]}`)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "trace.har")
+	c := NewClient(srv.URL, "tok", false)
+	c.RecordHAR(path)
+	if _, err := c.QueryV2(context.Background(), "Bug", V2Params{Take: 1}); err != nil {
+		t.Fatalf("QueryV2() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading HAR: %v", err)
+	}
+	if strings.Contains(string(data), "secret-session") || strings.Contains(string(data), "secret-realm") {
+		t.Fatalf("HAR leaks response credentials: %s", data)
+	}
+	var doc harLog
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("HAR is not JSON: %v", err)
+	}
+	found := false
+	for _, h := range doc.Log.Entries[0].Response.Headers {
+		if h.Name == "Set-Cookie" {
+			found = true
+			if h.Value != redactedValue {
+				t.Errorf("Set-Cookie = %q, want redacted", h.Value)
+			}
+		}
+	}
+	if !found {
+		t.Error("Set-Cookie header missing from the HAR response; it should be listed, redacted")
+	}
+}
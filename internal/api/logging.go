@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// requestLog is one JSON line written by LoggingTransport.
+type requestLog struct {
+	Time       time.Time `json:"time"`
+	Method     string    `json:"method"`
+	URL        string    `json:"url"`
+	Status     int       `json:"status,omitempty"`
+	Bytes      int64     `json:"bytes"`
+	DurationMS int64     `json:"durationMs"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// LoggingTransport is an http.RoundTripper that writes one JSON line per HTTP
+// attempt to W: method, token-redacted URL, status, response byte count, and
+// duration. Sitting below retryablehttp, it logs every retry separately. The
+// entry is written once the response body is closed, so bytes and duration
+// cover the full read.
+type LoggingTransport struct {
+	Base http.RoundTripper
+	W    io.Writer
+
+	mu sync.Mutex
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	entry := requestLog{
+		Time:   time.Now(),
+		Method: req.Method,
+		URL:    redactToken(req.URL.String()),
+	}
+	resp, err := t.base().RoundTrip(req)
+	if err != nil {
+		entry.Error = err.Error()
+		t.write(entry)
+		return nil, err
+	}
+	entry.Status = resp.StatusCode
+	resp.Body = &loggedBody{ReadCloser: resp.Body, t: t, entry: entry}
+	return resp, nil
+}
+
+func (t *LoggingTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func (t *LoggingTransport) write(entry requestLog) {
+	entry.DurationMS = time.Since(entry.Time).Milliseconds()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	enc := json.NewEncoder(t.W)
+	enc.SetEscapeHTML(false)
+	// Logging is best-effort; a full disk must not fail the request.
+	_ = enc.Encode(entry)
+}
+
+// loggedBody counts bytes read and writes the log entry on first Close.
+type loggedBody struct {
+	io.ReadCloser
+	t      *LoggingTransport
+	entry  requestLog
+	closed bool
+}
+
+func (b *loggedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.entry.Bytes += int64(n)
+	return n, err
+}
+
+func (b *loggedBody) Close() error {
+	err := b.ReadCloser.Close()
+	if !b.closed {
+		b.closed = true
+		b.t.write(b.entry)
+	}
+	return err
+}
+
+// LogTo writes a JSON line for every HTTP attempt the client makes to w.
+func (c *Client) LogTo(w io.Writer) {
+	c.rc.HTTPClient.Transport = &LoggingTransport{Base: c.rc.HTTPClient.Transport, W: w}
+}
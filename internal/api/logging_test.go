@@ -0,0 +1,40 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLogTo_WritesRedactedJSONLines(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items":[]}`)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	c := NewClient(srv.URL, "secret-token", false)
+	c.LogTo(&buf)
+	if _, err := c.QueryV2(context.Background(), "Bug", V2Params{Take: 1}); err != nil {
+		t.Fatalf("QueryV2() error = %v", err)
+	}
+
+	if strings.Contains(buf.String(), "secret-token") {
+		t.Fatalf("log leaks the token: %s", buf.String())
+	}
+	var entry requestLog
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("log line is not JSON: %v (%q)", err, buf.String())
+	}
+	if entry.Method != http.MethodGet || entry.Status != http.StatusOK || entry.Bytes != int64(len(`{"items":[]}`)) {
+		t.Errorf("unexpected log entry: %+v", entry)
+	}
+	if !strings.Contains(entry.URL, "/api/v2/Bug") || !strings.Contains(entry.URL, "REDACTED") {
+		t.Errorf("URL = %q, want redacted v2 Bug URL", entry.URL)
+	}
+}
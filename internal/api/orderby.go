@@ -0,0 +1,71 @@
+package api //nolint:revive // package name "api" is intentional
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// regexAggregate matches an aggregate in an order field, e.g. tasks.count,
+// bugs.sum(effort), or count(), but not a field like accountName.
+var regexAggregate = regexp.MustCompile(`(?i)(?:^|\.)(?:count|sum|avg|min|max)(?:\(|$)`)
+
+// isAggregateOrder reports whether an order field is an aggregate, which v2
+// cannot sort by.
+func isAggregateOrder(field string) bool {
+	return regexAggregate.MatchString(strings.TrimSpace(field))
+}
+
+// hasAggregateOrder reports whether any field of an orderBy is an aggregate.
+func hasAggregateOrder(orderBy string) bool {
+	for _, item := range strings.Split(orderBy, ",") {
+		if fields := strings.Fields(item); len(fields) > 0 && isAggregateOrder(fields[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeOrderBy tidies a v2 orderBy such as "priority.importance DESC ,
+// name": whitespace is collapsed, items are joined with "," and directions
+// are lower-cased. It returns the normalized value and a warning (or "") for
+// empty items, unknown directions, and aggregate fields.
+func NormalizeOrderBy(orderBy string) (string, string) {
+	if strings.TrimSpace(orderBy) == "" {
+		return "", ""
+	}
+
+	var items, problems []string
+	for _, item := range strings.Split(orderBy, ",") {
+		fields := strings.Fields(item)
+		switch {
+		case len(fields) == 0:
+			problems = append(problems, "empty item (stray comma)")
+			continue
+		case len(fields) > 2:
+			problems = append(problems, fmt.Sprintf("%q has more than a field and a direction (separate fields with commas)", strings.Join(fields, " ")))
+		case len(fields) == 2:
+			dir := strings.ToLower(fields[1])
+			if dir == "asc" || dir == "desc" {
+				fields[1] = dir
+			} else {
+				problems = append(problems, fmt.Sprintf("%q: direction must be asc or desc", strings.Join(fields, " ")))
+			}
+		}
+		if isAggregateOrder(fields[0]) {
+			problems = append(problems, fmt.Sprintf("%s is an aggregate; v2 cannot order by it (sort client-side instead)", fields[0]))
+		}
+		items = append(items, strings.Join(fields, " "))
+	}
+
+	normalized := strings.Join(items, ",")
+	if len(problems) == 0 {
+		return normalized, ""
+	}
+	var sb strings.Builder
+	sb.WriteString("Warning: The order expression looks wrong:\n")
+	for _, p := range problems {
+		fmt.Fprintf(&sb, "  - %s\n", p)
+	}
+	return normalized, sb.String()
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeOrderBy(t *testing.T) {
+	tests := []struct {
+		in       string
+		want     string
+		wantWarn string
+	}{
+		{"", "", ""},
+		{"createDate desc", "createDate desc", ""},
+		{" priority.importance  DESC ,  name Asc ", "priority.importance desc,name asc", ""},
+		{"name,,id", "name,id", "stray comma"},
+		{"name descending", "name descending", "direction must be asc or desc"},
+		{"name desc id", "name desc id", "separate fields with commas"},
+		{"userStories.count desc", "userStories.count desc", "userStories.count is an aggregate"},
+		{"bugs.sum(effort)", "bugs.sum(effort)", "is an aggregate"},
+		{"accountName", "accountName", ""},
+	}
+	for _, tt := range tests {
+		got, warn := NormalizeOrderBy(tt.in)
+		if got != tt.want {
+			t.Errorf("NormalizeOrderBy(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		if tt.wantWarn == "" && warn != "" {
+			t.Errorf("NormalizeOrderBy(%q) warned %q, want none", tt.in, warn)
+		}
+		if tt.wantWarn != "" && !strings.Contains(warn, tt.wantWarn) {
+			t.Errorf("NormalizeOrderBy(%q) warning = %q, want it to contain %q", tt.in, warn, tt.wantWarn)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package api //nolint:revive // package name "api" is intentional
+
+import (
+	"context"
+	"fmt"
+)
+
+// Project is an accessible project with its process.
+type Project struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	IsActive bool   `json:"isActive"`
+	Process  string `json:"process"`
+}
+
+// projectsSelect projects what ListProjects returns for each project.
+const projectsSelect = "id,name,isActive,process.name as process"
+
+// ListProjects returns the projects the client's credentials can see,
+// sorted by name. With activeOnly, inactive projects are left out.
+func (c *Client) ListProjects(ctx context.Context, activeOnly bool) ([]Project, error) {
+	params := V2Params{Select: projectsSelect, OrderBy: "name", Take: 1000}
+	if activeOnly {
+		params.Where = "isActive==true"
+	}
+	items, err := c.QueryV2All(ctx, "Project", params)
+	if err != nil {
+		return nil, fmt.Errorf("listing projects: %w", err)
+	}
+
+	projects := make([]Project, 0, len(items))
+	for _, item := range items {
+		p := Project{}
+		if id, ok := item["id"].(float64); ok {
+			p.ID = int(id)
+		}
+		p.Name, _ = item["name"].(string)
+		p.IsActive, _ = item["isActive"].(bool)
+		p.Process, _ = item["process"].(string)
+		projects = append(projects, p)
+	}
+	return projects, nil
+}
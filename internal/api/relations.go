@@ -0,0 +1,142 @@
+package api //nolint:revive // package name "api" is intentional
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Relation directions, from the point of view of the entity whose relations
+// were listed.
+const (
+	// RelationOutbound means the entity is the master: it blocks, or is
+	// depended on by, the other entity.
+	RelationOutbound = "outbound"
+	// RelationInbound means the entity is the slave of the relation.
+	RelationInbound = "inbound"
+)
+
+// RelatedEntity is the entity at the other end of a relation.
+type RelatedEntity struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Relation is one link between two entities.
+type Relation struct {
+	ID        int           `json:"id"`
+	Type      string        `json:"type"`
+	Direction string        `json:"direction"`
+	Entity    RelatedEntity `json:"entity"`
+}
+
+// relationTypeAliases maps verb forms people type to the relation type names
+// Targetprocess ships with.
+var relationTypeAliases = map[string]string{
+	"blocks":     "Blocker",
+	"block":      "Blocker",
+	"depends":    "Dependency",
+	"depends on": "Dependency",
+	"relates":    "Relation",
+	"relates to": "Relation",
+	"related":    "Relation",
+	"duplicates": "Duplicate",
+	"links":      "Link",
+}
+
+// ResolveRelationType returns the ID of the relation type named name. Names
+// match case-insensitively, a numeric name is taken as an ID, and verb forms
+// such as "blocks" map to the built-in types.
+func (c *Client) ResolveRelationType(ctx context.Context, name string) (int, error) {
+	name = strings.TrimSpace(name)
+	if id, err := strconv.Atoi(name); err == nil && id > 0 {
+		return id, nil
+	}
+
+	types, err := c.SearchEntities(ctx, "RelationType", "", nil, 100, nil)
+	if err != nil {
+		return 0, fmt.Errorf("listing relation types: %w", err)
+	}
+
+	want := strings.ToLower(name)
+	if alias, ok := relationTypeAliases[want]; ok {
+		want = strings.ToLower(alias)
+	}
+	var names []string
+	for _, t := range types {
+		typeName, _ := t["Name"].(string)
+		if strings.ToLower(typeName) == want {
+			if id, ok := t["Id"].(float64); ok {
+				return int(id), nil
+			}
+		}
+		names = append(names, typeName)
+	}
+	sort.Strings(names)
+	return 0, fmt.Errorf("unknown relation type %q; available: %s", name, strings.Join(names, ", "))
+}
+
+// CreateRelation links masterID to slaveID with the given relation type:
+// for "Blocker", masterID blocks slaveID.
+func (c *Client) CreateRelation(ctx context.Context, masterID, slaveID, relationTypeID int) (Entity, error) {
+	return c.CreateEntity(ctx, "Relation", RelationFields(masterID, slaveID, relationTypeID))
+}
+
+// RelationFields is the request body CreateRelation sends.
+func RelationFields(masterID, slaveID, relationTypeID int) map[string]any {
+	return map[string]any{
+		"Master":       map[string]any{"Id": masterID},
+		"Slave":        map[string]any{"Id": slaveID},
+		"RelationType": map[string]any{"Id": relationTypeID},
+	}
+}
+
+// relationInclude fetches both ends of a relation with their names and types.
+var relationInclude = []string{"RelationType", "Master[Id,Name,EntityType]", "Slave[Id,Name,EntityType]"}
+
+// ListRelations returns every relation id takes part in: those where it is
+// the master first, then those where it is the slave.
+func (c *Client) ListRelations(ctx context.Context, id int) ([]Relation, error) {
+	var relations []Relation
+	for _, side := range []struct {
+		field, other, direction string
+	}{
+		{"Master", "Slave", RelationOutbound},
+		{"Slave", "Master", RelationInbound},
+	} {
+		where := fmt.Sprintf("%s.Id eq %d", side.field, id)
+		items, err := c.SearchEntities(ctx, "Relation", where, relationInclude, 1000, nil)
+		if err != nil {
+			return nil, fmt.Errorf("listing relations of #%d: %w", id, err)
+		}
+		for _, item := range items {
+			r := Relation{Direction: side.direction}
+			if v, ok := item["Id"].(float64); ok {
+				r.ID = int(v)
+			}
+			if rt, ok := item["RelationType"].(map[string]any); ok {
+				r.Type, _ = rt["Name"].(string)
+			}
+			if other, ok := item[side.other].(map[string]any); ok {
+				r.Entity = relatedEntity(other)
+			}
+			relations = append(relations, r)
+		}
+	}
+	return relations, nil
+}
+
+func relatedEntity(m map[string]any) RelatedEntity {
+	e := RelatedEntity{}
+	if v, ok := m["Id"].(float64); ok {
+		e.ID = int(v)
+	}
+	e.Name, _ = m["Name"].(string)
+	if et, ok := m["EntityType"].(map[string]any); ok {
+		e.Type, _ = et["Name"].(string)
+	}
+	return e
+}
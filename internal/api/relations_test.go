@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/testutil"
+)
+
+func TestResolveRelationType(t *testing.T) {
+	ss := testutil.NewSimulationServer(&testutil.Simulation{Pairs: []testutil.Pair{{
+		Request: testutil.Request{Method: "GET", Path: "/api/v1/RelationTypes"},
+		Response: testutil.Response{Status: 200, Body: json.RawMessage(`{"Items":[
+			{"Id":1,"Name":"Dependency"},{"Id":2,"Name":"Blocker"},{"Id":3,"Name":"Relation"}
+		]}`)},
+	}}})
+	defer ss.Close()
+
+	client := NewClient(ss.URL(), "tok", false)
+	for name, want := range map[string]int{"Blocker": 2, "blocker": 2, "blocks": 2, "depends on": 1, "7": 7} {
+		got, err := client.ResolveRelationType(context.Background(), name)
+		if err != nil {
+			t.Errorf("ResolveRelationType(%q) error = %v", name, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ResolveRelationType(%q) = %d, want %d", name, got, want)
+		}
+	}
+
+	_, err := client.ResolveRelationType(context.Background(), "Clones")
+	if err == nil || !strings.Contains(err.Error(), "Blocker, Dependency, Relation") {
+		t.Errorf("unknown type error = %v, want the available names", err)
+	}
+}
+
+func TestListRelations(t *testing.T) {
+	ss := testutil.NewSimulationServer(&testutil.Simulation{Pairs: []testutil.Pair{
+		{
+			Request: testutil.Request{Method: "GET", Path: "/api/v1/Relations", Query: map[string]string{"where": "Master.Id eq 123"}},
+			Response: testutil.Response{Status: 200, Body: json.RawMessage(`{"Items":[
+				{"Id":10,"RelationType":{"Id":2,"Name":"Blocker"},"Master":{"Id":123,"Name":"Login"},"Slave":{"Id":456,"Name":"Signup","EntityType":{"Name":"UserStory"}}}
+			]}`)},
+		},
+		{
+			Request: testutil.Request{Method: "GET", Path: "/api/v1/Relations", Query: map[string]string{"where": "Slave.Id eq 123"}},
+			Response: testutil.Response{Status: 200, Body: json.RawMessage(`{"Items":[
+				{"Id":11,"RelationType":{"Id":3,"Name":"Relation"},"Master":{"Id":789,"Name":"Crash","EntityType":{"Name":"Bug"}},"Slave":{"Id":123,"Name":"Login"}}
+			]}`)},
+		},
+	}})
+	defer ss.Close()
+
+	got, err := NewClient(ss.URL(), "tok", false).ListRelations(context.Background(), 123)
+	if err != nil {
+		t.Fatalf("ListRelations() error = %v", err)
+	}
+	want := []Relation{
+		{ID: 10, Type: "Blocker", Direction: RelationOutbound, Entity: RelatedEntity{ID: 456, Name: "Signup", Type: "UserStory"}},
+		{ID: 11, Type: "Relation", Direction: RelationInbound, Entity: RelatedEntity{ID: 789, Name: "Crash", Type: "Bug"}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d relations, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("relation %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package api //nolint:revive // package name "api" is intentional
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	regexSelectAlias = regexp.MustCompile(`\sas\s+(\w+)$`)
+	regexPlainField  = regexp.MustCompile(`^[a-zA-Z_]\w*$`)
+)
+
+// SelectField is one top-level item of a v2 select expression.
+type SelectField struct {
+	Expr string
+	// Key is the result key the value comes back under: the alias after
+	// "as", or the name of a plain field. It is empty for anything else.
+	Key string
+	// Dropped is set for a dot-path without an alias, which the API
+	// silently leaves out (see WarnSelectDotPaths).
+	Dropped bool
+}
+
+// SelectFields splits a select expression into its top-level items, with
+// the key each one comes back under.
+func SelectFields(selectExpr string) []SelectField {
+	var fields []SelectField
+	for _, item := range topLevelSelectItems(selectExpr) {
+		expr := strings.TrimSpace(item)
+		flat := strings.TrimSpace(flattenNested(item))
+		field := SelectField{Expr: expr}
+		switch {
+		case regexSelectAlias.MatchString(flat):
+			field.Key = regexSelectAlias.FindStringSubmatch(flat)[1]
+		case regexPlainField.MatchString(flat):
+			field.Key = flat
+		case regexDotPath.MatchString(flat):
+			field.Dropped = true
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// RedactURL returns rawURL with credential query values (access_token and
+// similar) and any userinfo replaced, for showing to the user.
+func RedactURL(rawURL string) string {
+	return redactToken(rawURL)
+}
@@ -0,0 +1,32 @@
+package api //nolint:revive // package name "api" is intentional
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSelectFields(t *testing.T) {
+	got := SelectFields(`{id, Name, entityState.name as state, project.name, userStories.where(name.contains("a,b")).count as open, tasks.count()}`)
+	want := []SelectField{
+		{Expr: "id", Key: "id"},
+		{Expr: "Name", Key: "Name"},
+		{Expr: "entityState.name as state", Key: "state"},
+		{Expr: "project.name", Dropped: true},
+		{Expr: `userStories.where(name.contains("a,b")).count as open`, Key: "open"},
+		{Expr: "tasks.count()"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SelectFields() =\n%+v\nwant\n%+v", got, want)
+	}
+	if got := SelectFields(""); got != nil {
+		t.Errorf("SelectFields(\"\") = %+v, want nil", got)
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	got := RedactURL("https://x.tpondemand.com/api/v2/Bug?access_token=abc123&take=1")
+	if strings.Contains(got, "abc123") || !strings.Contains(got, "take=1") {
+		t.Errorf("RedactURL() = %q", got)
+	}
+}
@@ -0,0 +1,118 @@
+package api //nolint:revive // package name "api" is intentional
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// EntityState is one workflow state of an entity type.
+type EntityState struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	IsInitial bool   `json:"isInitial"`
+	IsFinal   bool   `json:"isFinal"`
+	IsPlanned bool   `json:"isPlanned"`
+	Process   string `json:"process"`
+}
+
+// entityStatesSelect projects what ListEntityStates returns for each state.
+const entityStatesSelect = "id,name,isInitial,isFinal,isPlanned,process.name as process"
+
+// StateFilter narrows ListEntityStates to one workflow. Set at most one
+// field; with neither, states from every process are returned.
+type StateFilter struct {
+	// ProjectID selects the process that project uses.
+	ProjectID int
+	// Process is a process ID or name.
+	Process string
+}
+
+// ListEntityStates returns the states of entityType in workflow order,
+// limited to one process when filter names it.
+func (c *Client) ListEntityStates(ctx context.Context, entityType string, filter StateFilter) ([]EntityState, error) {
+	where := fmt.Sprintf("entityType.name==%q", entityType)
+	switch {
+	case filter.ProjectID > 0:
+		processID, err := c.projectProcessID(ctx, filter.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		where += fmt.Sprintf(" and process.id==%d", processID)
+	case filter.Process != "":
+		if id, err := strconv.Atoi(filter.Process); err == nil {
+			where += fmt.Sprintf(" and process.id==%d", id)
+		} else {
+			where += fmt.Sprintf(" and process.name==%q", filter.Process)
+		}
+	}
+
+	params := V2Params{Where: where, Select: entityStatesSelect, OrderBy: "numericPriority", Take: 1000}
+	items, err := c.QueryV2All(ctx, "EntityState", params)
+	if err != nil {
+		return nil, fmt.Errorf("listing entity states: %w", err)
+	}
+
+	states := make([]EntityState, 0, len(items))
+	for _, item := range items {
+		s := EntityState{}
+		if id, ok := item["id"].(float64); ok {
+			s.ID = int(id)
+		}
+		s.Name, _ = item["name"].(string)
+		s.IsInitial, _ = item["isInitial"].(bool)
+		s.IsFinal, _ = item["isFinal"].(bool)
+		s.IsPlanned, _ = item["isPlanned"].(bool)
+		s.Process, _ = item["process"].(string)
+		states = append(states, s)
+	}
+	return states, nil
+}
+
+// projectProcessID returns the ID of the process a project uses.
+func (c *Client) projectProcessID(ctx context.Context, projectID int) (int, error) {
+	data, err := c.QueryV2Entity(ctx, "Project", projectID, "process.id as processId")
+	if err != nil {
+		return 0, fmt.Errorf("looking up process of project %d: %w", projectID, err)
+	}
+	var resp struct {
+		Items []struct {
+			ProcessID int `json:"processId"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return 0, fmt.Errorf("parsing project %d: %w", projectID, err)
+	}
+	if len(resp.Items) == 0 {
+		return 0, fmt.Errorf("project %d not found", projectID)
+	}
+	if resp.Items[0].ProcessID == 0 {
+		return 0, fmt.Errorf("project %d has no process", projectID)
+	}
+	return resp.Items[0].ProcessID, nil
+}
+
+// nextStatesSelect projects the states a workflow allows after a state.
+const nextStatesSelect = "nextStates.select({id,name}) as nextStates"
+
+// NextStates returns the states the workflow allows an entity to move to
+// from stateID.
+func (c *Client) NextStates(ctx context.Context, stateID int) ([]EntityState, error) {
+	data, err := c.QueryV2Entity(ctx, "EntityState", stateID, nextStatesSelect)
+	if err != nil {
+		return nil, fmt.Errorf("looking up transitions from state %d: %w", stateID, err)
+	}
+	var resp struct {
+		Items []struct {
+			NextStates []EntityState `json:"nextStates"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parsing state %d: %w", stateID, err)
+	}
+	if len(resp.Items) == 0 {
+		return nil, fmt.Errorf("entity state %d not found", stateID)
+	}
+	return resp.Items[0].NextStates, nil
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/testutil"
+)
+
+func TestListEntityStates_ByProject(t *testing.T) {
+	ss := testutil.NewSimulationServer(&testutil.Simulation{Pairs: []testutil.Pair{
+		{
+			Request:  testutil.Request{Method: "GET", Path: "/api/v2/Project/42"},
+			Response: testutil.Response{Status: 200, Body: json.RawMessage(`{"items":[{"processId":7}]}`)},
+		},
+		{
+			Request: testutil.Request{Method: "GET", Path: "/api/v2/EntityState", Query: map[string]string{
+				"where":   `entityType.name=="Bug" and process.id==7`,
+				"orderBy": "numericPriority",
+			}},
+			Response: testutil.Response{Status: 200, Body: json.RawMessage(`{"items":[
+				{"id":100,"name":"Open","isInitial":true,"isFinal":false,"isPlanned":false,"process":"Scrum"},
+				{"id":102,"name":"Done","isInitial":false,"isFinal":true,"isPlanned":false,"process":"Scrum"}
+			]}`)},
+		},
+	}})
+	defer ss.Close()
+
+	states, err := NewClient(ss.URL(), "tok", false).ListEntityStates(context.Background(), "Bug", StateFilter{ProjectID: 42})
+	if err != nil {
+		t.Fatalf("ListEntityStates() error = %v", err)
+	}
+	want := []EntityState{
+		{ID: 100, Name: "Open", IsInitial: true, Process: "Scrum"},
+		{ID: 102, Name: "Done", IsFinal: true, Process: "Scrum"},
+	}
+	if len(states) != len(want) {
+		t.Fatalf("got %d states, want %d", len(states), len(want))
+	}
+	for i := range want {
+		if states[i] != want[i] {
+			t.Errorf("state %d = %+v, want %+v", i, states[i], want[i])
+		}
+	}
+}
+
+func TestNextStates(t *testing.T) {
+	ss := testutil.NewSimulationServer(&testutil.Simulation{Pairs: []testutil.Pair{{
+		Request: testutil.Request{Method: "GET", Path: "/api/v2/EntityState/100", Query: map[string]string{
+			"select": "{nextStates.select({id,name}) as nextStates}",
+		}},
+		Response: testutil.Response{Status: 200, Body: json.RawMessage(`{"items":[{"nextStates":[{"id":101,"name":"In Progress"},{"id":102,"name":"Done"}]}]}`)},
+	}}})
+	defer ss.Close()
+
+	next, err := NewClient(ss.URL(), "tok", false).NextStates(context.Background(), 100)
+	if err != nil {
+		t.Fatalf("NextStates() error = %v", err)
+	}
+	if len(next) != 2 || next[0] != (EntityState{ID: 101, Name: "In Progress"}) || next[1] != (EntityState{ID: 102, Name: "Done"}) {
+		t.Errorf("NextStates() = %+v", next)
+	}
+}
+
+func TestListEntityStates_ByProcess(t *testing.T) {
+	ss := testutil.NewSimulationServer(&testutil.Simulation{Pairs: []testutil.Pair{
+		{
+			Request:  testutil.Request{Method: "GET", Path: "/api/v2/EntityState", Query: map[string]string{"where": `entityType.name=="Bug" and process.name=="Kanban"`}},
+			Response: testutil.Response{Status: 200, Body: json.RawMessage(`{"items":[{"id":200,"name":"Backlog","process":"Kanban"}]}`)},
+		},
+		{
+			Request:  testutil.Request{Method: "GET", Path: "/api/v2/EntityState", Query: map[string]string{"where": `entityType.name=="Bug" and process.id==7`}},
+			Response: testutil.Response{Status: 200, Body: json.RawMessage(`{"items":[{"id":100,"name":"Open","process":"Scrum"}]}`)},
+		},
+	}})
+	defer ss.Close()
+
+	client := NewClient(ss.URL(), "tok", false)
+	for process, wantID := range map[string]int{"Kanban": 200, "7": 100} {
+		states, err := client.ListEntityStates(context.Background(), "Bug", StateFilter{Process: process})
+		if err != nil {
+			t.Fatalf("ListEntityStates(%q) error = %v", process, err)
+		}
+		if len(states) != 1 || states[0].ID != wantID {
+			t.Errorf("ListEntityStates(%q) = %+v, want state %d", process, states, wantID)
+		}
+	}
+}
@@ -2,10 +2,12 @@ package api //nolint:revive // package name "api" is intentional
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 // V2Params holds the query parameters for a v2 API request.
@@ -17,13 +19,37 @@ type V2Params struct {
 	Skip    int
 }
 
+// QuoteV2String returns s as a double-quoted v2 string literal, escaping
+// backslashes, quotes, and control characters.
+func QuoteV2String(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\', '"':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
 // BuildV2URL constructs the full v2 URL without executing the request.
 // Useful for --dry-run to inspect the URL that would be called.
 func (c *Client) BuildV2URL(entityType string, params V2Params) string {
 	path := fmt.Sprintf("/api/v2/%s", entityType)
 
 	q := url.Values{}
-	q.Set("access_token", c.Token)
+	c.setTokenParam(q)
 	if params.Where != "" {
 		q.Set("where", params.Where)
 	}
@@ -55,7 +81,7 @@ func (c *Client) BuildV2EntityURL(entityType string, id int, selectExpr string)
 	path := fmt.Sprintf("/api/v2/%s/%d", entityType, id)
 
 	q := url.Values{}
-	q.Set("access_token", c.Token)
+	c.setTokenParam(q)
 	if selectExpr != "" {
 		q.Set("select", "{"+selectExpr+"}")
 	}
@@ -68,3 +94,80 @@ func (c *Client) QueryV2Entity(ctx context.Context, entityType string, id int, s
 	fullURL := c.BuildV2EntityURL(entityType, id, selectExpr)
 	return c.request(ctx, http.MethodGet, fullURL, nil)
 }
+
+// maxV2Pages bounds QueryV2All so a misbehaving "next" link cannot loop forever.
+const maxV2Pages = 1000
+
+// QueryV2All executes a v2 query and follows the response's "next" links
+// until every page has been fetched, returning the combined items.
+func (c *Client) QueryV2All(ctx context.Context, entityType string, params V2Params) ([]Entity, error) {
+	var all []Entity
+	err := c.QueryV2Pages(ctx, entityType, params, func(items []Entity) error {
+		all = append(all, items...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// QueryV2Pages executes a v2 query and calls fn with the items of each page
+// as it arrives, following "next" links until the last page. Returning an
+// error from fn stops paging. Use it to stream large result sets without
+// holding every page in memory.
+func (c *Client) QueryV2Pages(ctx context.Context, entityType string, params V2Params, fn func([]Entity) error) error {
+	fullURL := c.BuildV2URL(entityType, params)
+	for page := 0; fullURL != ""; page++ {
+		if page >= maxV2Pages {
+			return fmt.Errorf("stopped after %d pages; narrow the query or raise --take", maxV2Pages)
+		}
+
+		data, err := c.request(ctx, http.MethodGet, fullURL, nil)
+		if err != nil {
+			return err
+		}
+
+		var resp struct {
+			Items []Entity `json:"items"`
+			Next  string   `json:"next"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return fmt.Errorf("parsing v2 page %d: %w", page+1, err)
+		}
+		if err := fn(resp.Items); err != nil {
+			return err
+		}
+
+		if resp.Next == "" || len(resp.Items) == 0 {
+			break
+		}
+		fullURL, err = c.resolveNextURL(resp.Next)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveNextURL turns a v2 "next" link into a request URL against the
+// configured base URL. Links pointing at another host are rejected so the
+// access token is never sent elsewhere.
+func (c *Client) resolveNextURL(next string) (string, error) {
+	base, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing base URL: %w", err)
+	}
+	ref, err := url.Parse(next)
+	if err != nil {
+		return "", fmt.Errorf("parsing next link %q: %w", next, err)
+	}
+	u := base.ResolveReference(ref)
+	if u.Host != base.Host {
+		return "", fmt.Errorf("refusing to follow next link to different host %q", u.Host)
+	}
+	q := u.Query()
+	c.setTokenParam(q)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
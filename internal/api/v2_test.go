@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lifedraft/targetprocess-cli/internal/testutil"
+)
+
+func TestQueryV2All_FollowsNext(t *testing.T) {
+	var tokens []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.URL.Query().Get("access_token"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("skip") == "2" {
+			fmt.Fprint(w, `{"items":[{"id":3}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"items":[{"id":1},{"id":2}],"next":"/api/v2/Bug?take=2&skip=2"}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "tok", false)
+	items, err := c.QueryV2All(context.Background(), "Bug", V2Params{Take: 2})
+	if err != nil {
+		t.Fatalf("QueryV2All() error = %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("got %d items, want 3", len(items))
+	}
+	if len(tokens) != 2 || tokens[1] != "tok" {
+		t.Errorf("expected 2 requests carrying the token, got %v", tokens)
+	}
+}
+
+func TestQueryV2All_PagedSimulation(t *testing.T) {
+	items := []map[string]any{{"id": 1}, {"id": 2}, {"id": 3}, {"id": 4}, {"id": 5}}
+	sim, err := testutil.PagedSimulation("/api/v2/Bug", 2, items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss := testutil.NewSimulationServer(sim)
+	defer ss.Close()
+
+	c := NewClient(ss.URL(), "tok", false)
+	got, err := c.QueryV2All(context.Background(), "Bug", V2Params{Take: 2})
+	if err != nil {
+		t.Fatalf("QueryV2All() error = %v", err)
+	}
+	if len(got) != len(items) {
+		t.Fatalf("got %d items, want %d", len(got), len(items))
+	}
+	if reqs := ss.Requests(); len(reqs) != 3 {
+		t.Errorf("made %d requests, want 3 pages", len(reqs))
+	}
+}
+
+func TestResolveNextURL_RejectsOtherHost(t *testing.T) {
+	c := NewClient("https://example.tpondemand.com", "tok", false)
+	if _, err := c.resolveNextURL("https://evil.example.com/api/v2/Bug?skip=25"); err == nil {
+		t.Error("expected error for next link on a different host")
+	}
+}
+
+func TestBackoff_CapsRetryAfter(t *testing.T) {
+	c := NewClient("https://example.tpondemand.com", "tok", false)
+	c.MaxRetryWait = 5 * time.Second
+
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"3600"}}}
+	if got := c.backoff(time.Second, 30*time.Second, 0, resp); got != 5*time.Second {
+		t.Errorf("backoff with huge Retry-After = %s, want 5s", got)
+	}
+
+	resp.Header.Set("Retry-After", "2")
+	if got := c.backoff(time.Second, 30*time.Second, 0, resp); got != 2*time.Second {
+		t.Errorf("backoff with small Retry-After = %s, want 2s", got)
+	}
+}
+
+func TestQueryV2Pages_StopsOnCallbackError(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"items":[{"id":1}],"next":"/api/v2/Bug?take=1&skip=1"}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "tok", false)
+	stop := errors.New("write failed")
+	var pages int
+	err := c.QueryV2Pages(context.Background(), "Bug", V2Params{Take: 1}, func(items []Entity) error {
+		pages++
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("QueryV2Pages() error = %v, want callback error", err)
+	}
+	if pages != 1 || requests != 1 {
+		t.Errorf("expected paging to stop after the first page, got %d pages, %d requests", pages, requests)
+	}
+}
+
+func TestCurrentUser(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/Context" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"Version":"3.13.0","LoggedUser":{"Id":7,"FirstName":"Ada","LastName":"Lovelace","Login":"ada"}}`)
+	}))
+	defer srv.Close()
+
+	u, err := NewClient(srv.URL, "tok", false).CurrentUser(context.Background())
+	if err != nil {
+		t.Fatalf("CurrentUser() error = %v", err)
+	}
+	if u.ID != 7 || u.FirstName != "Ada" || u.Login != "ada" {
+		t.Errorf("CurrentUser() = %+v", u)
+	}
+}
+
+func TestListProjects(t *testing.T) {
+	var where string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		where = r.URL.Query().Get("where")
+		fmt.Fprint(w, `{"items":[{"id":1,"name":"Alpha","isActive":true,"process":"Scrum"},{"id":2,"name":"Beta","isActive":true,"process":null}]}`)
+	}))
+	defer srv.Close()
+
+	projects, err := NewClient(srv.URL, "tok", false).ListProjects(context.Background(), true)
+	if err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	if where != "isActive==true" {
+		t.Errorf("where = %q, want isActive==true", where)
+	}
+	if len(projects) != 2 || projects[0] != (Project{ID: 1, Name: "Alpha", IsActive: true, Process: "Scrum"}) || projects[1].Process != "" {
+		t.Errorf("ListProjects() = %+v", projects)
+	}
+}
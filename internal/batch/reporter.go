@@ -0,0 +1,112 @@
+// Package batch reports per-item outcomes of bulk operations.
+package batch
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/lifedraft/targetprocess-cli/internal/output"
+)
+
+// Item statuses reported in Result.Status.
+const (
+	StatusOK    = "ok"
+	StatusError = "error"
+)
+
+// Result is the outcome of a single item in a batch operation. ID is the
+// entity the item acted on or created; Row identifies input rows, such as
+// CSV lines, that have no entity yet when they fail.
+type Result struct {
+	ID      int    `json:"id,omitempty"`
+	Row     int    `json:"row,omitempty"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// Reporter records batch results. In text mode each result is written as a
+// line as soon as it is recorded; with a json or yaml format results are
+// collected and written as a single array by Flush, so partial failures stay
+// machine-parseable.
+type Reporter struct {
+	w       io.Writer
+	format  string
+	results []Result
+	failed  int
+}
+
+// NewReporter creates a Reporter writing to w. format is the --output value;
+// json and yaml select structured output, anything else text.
+func NewReporter(w io.Writer, format string) *Reporter {
+	return &Reporter{w: w, format: format}
+}
+
+// OK records a successful item.
+func (r *Reporter) OK(id int, message string) {
+	r.Add(Result{ID: id, Status: StatusOK, Message: message})
+}
+
+// Fail records a failed item.
+func (r *Reporter) Fail(id int, err error) {
+	r.Add(Result{ID: id, Status: StatusError, Message: err.Error()})
+}
+
+// Add records a result built by the caller, for items identified by Row.
+func (r *Reporter) Add(res Result) {
+	if res.Status == StatusError {
+		r.failed++
+	}
+	r.results = append(r.results, res)
+	if r.structured() {
+		return
+	}
+	label := fmt.Sprintf("#%d", res.ID)
+	if res.Row > 0 {
+		label = fmt.Sprintf("row %d", res.Row)
+	}
+	if res.Message != "" {
+		fmt.Fprintf(r.w, "%-5s  %s  %s\n", res.Status, label, res.Message)
+	} else {
+		fmt.Fprintf(r.w, "%-5s  %s\n", res.Status, label)
+	}
+}
+
+func (r *Reporter) structured() bool {
+	return r.format == "json" || r.format == "yaml"
+}
+
+// Results returns the results recorded so far.
+func (r *Reporter) Results() []Result {
+	return r.results
+}
+
+// Succeeded returns how many recorded items succeeded.
+func (r *Reporter) Succeeded() int {
+	return len(r.results) - r.failed
+}
+
+// Flush writes the collected results as an array in structured mode, and in
+// text mode the summary line, if not empty.
+func (r *Reporter) Flush(summary string) error {
+	if !r.structured() {
+		if summary != "" {
+			fmt.Fprintln(r.w, summary)
+		}
+		return nil
+	}
+	results := r.results
+	if results == nil {
+		results = []Result{}
+	}
+	return output.Print(r.w, r.format, results)
+}
+
+// Err returns an error summarizing failed items, such as "2 of 5 comments
+// failed", or nil if all succeeded. Commands return it so the exit code
+// reflects overall success.
+func (r *Reporter) Err(noun string) error {
+	if r.failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d %s failed", r.failed, len(r.results), noun)
+}
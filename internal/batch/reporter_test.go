@@ -0,0 +1,80 @@
+package batch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReporterJSON(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewReporter(&buf, "json")
+	r.OK(1, "")
+	r.Fail(2, errors.New("boom"))
+
+	if buf.Len() != 0 {
+		t.Fatalf("JSON mode should not write before Flush, got %q", buf.String())
+	}
+	if err := r.Flush("Done."); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []Result
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, buf.String())
+	}
+	want := []Result{
+		{ID: 1, Status: StatusOK},
+		{ID: 2, Status: StatusError, Message: "boom"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d results, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("result %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if err := r.Err("items"); err == nil || err.Error() != "1 of 2 items failed" {
+		t.Errorf("Err() = %v, want summary of 1 of 2 failures", err)
+	}
+}
+
+func TestReporterText(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewReporter(&buf, "")
+	r.OK(1, "created")
+	r.Add(Result{Row: 3, Status: StatusError, Message: "bad name"})
+	if err := r.Flush("Created 1 of 2."); err != nil {
+		t.Fatal(err)
+	}
+	want := "ok     #1  created\nerror  row 3  bad name\nCreated 1 of 2.\n"
+	if got := buf.String(); got != want {
+		t.Errorf("text output = %q, want %q", got, want)
+	}
+	if r.Succeeded() != 1 {
+		t.Errorf("Succeeded() = %d, want 1", r.Succeeded())
+	}
+}
+
+func TestReporterAllOK(t *testing.T) {
+	r := NewReporter(io.Discard, "")
+	r.OK(1, "")
+	if err := r.Err("items"); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
+
+func TestReporterJSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewReporter(&buf, "json").Flush(""); err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("empty JSON output = %q, want []", buf.String())
+	}
+}
@@ -0,0 +1,25 @@
+// Package browser opens URLs in the user's default web browser.
+package browser
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// Open opens rawURL with the platform's default browser handler.
+func Open(ctx context.Context, rawURL string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.CommandContext(ctx, "open", rawURL)
+	case "linux":
+		cmd = exec.CommandContext(ctx, "xdg-open", rawURL)
+	case "windows":
+		cmd = exec.CommandContext(ctx, "cmd", "/c", "start", rawURL)
+	default:
+		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	}
+	return cmd.Run()
+}
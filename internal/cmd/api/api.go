@@ -5,13 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 
 	"github.com/urfave/cli/v3"
 
 	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
-	"github.com/lifedraft/targetprocess-cli/internal/output"
 )
 
 func NewCmd(f *cmdutil.Factory) *cli.Command {
@@ -19,9 +19,18 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
 		Name:      "api",
 		Usage:     "Make raw API requests to Targetprocess",
 		ArgsUsage: "<method> <path>",
+		UsageText: `# GET a v1 resource
+  tp api /api/v1/UserStories/123
+
+  # POST a JSON body
+  tp api POST /api/v1/Bugs --body '{"Name":"Crash","Project":{"Id":42}}'
+
+  # POST a form-encoded body (repeat --form for more fields or repeated keys)
+  tp api POST /api/v1/SomeEndpoint --form name=value --form tag=a --form tag=b`,
 		Flags: []cli.Flag{
 			cmdutil.OutputFlag(),
 			&cli.StringFlag{Name: "body", Usage: "Request body (JSON string)"},
+			&cli.StringSliceFlag{Name: "form", Usage: "Form field as key=value, sent form-encoded (repeatable; cannot be combined with --body)"},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			client, err := f.Client()
@@ -44,15 +53,23 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
 			}
 
 			bodyStr := cmd.String("body")
-			var bodyReader *strings.Reader
-			if bodyStr != "" {
-				bodyReader = strings.NewReader(bodyStr)
+			formFields := cmd.StringSlice("form")
+			if bodyStr != "" && len(formFields) > 0 {
+				return errors.New("--form and --body cannot be combined")
 			}
 
 			var data []byte
-			if bodyReader != nil {
-				data, err = client.Raw(ctx, method, path, bodyReader)
-			} else {
+			switch {
+			case len(formFields) > 0:
+				var form url.Values
+				form, err = parseForm(formFields)
+				if err != nil {
+					return err
+				}
+				data, err = client.RawWithContentType(ctx, method, path, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+			case bodyStr != "":
+				data, err = client.Raw(ctx, method, path, strings.NewReader(bodyStr))
+			default:
 				data, err = client.Raw(ctx, method, path, nil)
 			}
 			if err != nil {
@@ -69,7 +86,21 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
 				return fmt.Errorf("parsing JSON response: %w", err)
 			}
 
-			return output.PrintJSON(os.Stdout, parsed)
+			return cmdutil.PrintStructured(cmd, parsed)
 		},
 	}
 }
+
+// parseForm builds form values from key=value pairs, keeping repeated keys.
+// Values are split at the first '=' and may themselves contain '='.
+func parseForm(fields []string) (url.Values, error) {
+	form := url.Values{}
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --form %q: expected key=value", field)
+		}
+		form.Add(key, value)
+	}
+	return form, nil
+}
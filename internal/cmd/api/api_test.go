@@ -0,0 +1,19 @@
+package api //nolint:revive // package name matches directory
+
+import "testing"
+
+func TestParseForm(t *testing.T) {
+	form, err := parseForm([]string{"name=Fix & ship", "tag=a", "tag=b", "expr=x=1"})
+	if err != nil {
+		t.Fatalf("parseForm() error = %v", err)
+	}
+	if want := "expr=x%3D1&name=Fix+%26+ship&tag=a&tag=b"; form.Encode() != want {
+		t.Errorf("Encode() = %q, want %q", form.Encode(), want)
+	}
+
+	for _, bad := range []string{"novalue", "=value"} {
+		if _, err := parseForm([]string{bad}); err == nil {
+			t.Errorf("parseForm(%q) should fail", bad)
+		}
+	}
+}
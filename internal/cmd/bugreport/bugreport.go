@@ -14,6 +14,7 @@ import (
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/lifedraft/targetprocess-cli/internal/browser"
 	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
 	"github.com/lifedraft/targetprocess-cli/internal/config"
 )
@@ -129,21 +130,6 @@ func BuildIssueURL(info Info) string {
 	return result
 }
 
-func openBrowser(ctx context.Context, rawURL string) error {
-	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.CommandContext(ctx, "open", rawURL)
-	case "linux":
-		cmd = exec.CommandContext(ctx, "xdg-open", rawURL)
-	case "windows":
-		cmd = exec.CommandContext(ctx, "cmd", "/c", "start", rawURL)
-	default:
-		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
-	}
-	return cmd.Run()
-}
-
 func copyToClipboard(ctx context.Context, text string) error {
 	var cmd *exec.Cmd
 	switch runtime.GOOS {
@@ -283,14 +269,14 @@ func NewCmd(f *cmdutil.Factory, version string) *cli.Command {
 				return enc.Encode(info)
 			case "open":
 				issueURL := BuildIssueURL(info)
-				fmt.Fprintln(os.Stderr, "Opening GitHub issue form in your browser...")
-				return openBrowser(ctx, issueURL)
+				fmt.Fprintln(f.Warnings(), "Opening GitHub issue form in your browser...")
+				return browser.Open(ctx, issueURL)
 			case "clipboard":
 				text := FormatText(info)
 				if err := copyToClipboard(ctx, text); err != nil {
 					return fmt.Errorf("copying to clipboard: %w", err)
 				}
-				fmt.Fprintln(os.Stderr, "Diagnostic info copied to clipboard.")
+				fmt.Fprintln(f.Warnings(), "Diagnostic info copied to clipboard.")
 			default:
 				return fmt.Errorf("unknown mode %q (valid: terminal, open, clipboard, json)", mode)
 			}
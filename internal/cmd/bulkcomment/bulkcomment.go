@@ -0,0 +1,229 @@
+package bulkcomment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/batch"
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+	"github.com/lifedraft/targetprocess-cli/internal/output"
+	"github.com/lifedraft/targetprocess-cli/internal/resolve"
+	"github.com/lifedraft/targetprocess-cli/internal/text"
+)
+
+// target is an entity that will receive the comment.
+type target struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// result is the outcome of commenting on one target.
+type result struct {
+	EntityID  int
+	CommentID int
+	Error     string
+}
+
+// NewCmd creates the "bulk-comment" command.
+func NewCmd(f *cmdutil.Factory) *cli.Command {
+	return &cli.Command{
+		Name:  "bulk-comment",
+		Usage: "Post the same comment to every entity matching a query",
+		UsageText: `# Preview which bugs would get the comment
+  tp bulk-comment --type Bug -w 'entityState.isInitial==true' --body "Please triage @timo" --dry-run
+
+  # Post it without the confirmation prompt
+  tp bulk-comment --type Bug -w 'entityState.isInitial==true' --body "Please triage @timo" --yes
+
+  # Per-entity placeholders: {id} and {name}
+  tp bulk-comment --type UserStory -w 'release.name=="2.4"' --body "{name} (#{id}) ships in 2.4"`,
+		Description: `@mentions are resolved and markdown applied once, then {id} and {name} are
+filled in for each entity. On a terminal you are asked to confirm; when stdin
+is not a terminal, --yes is required. At most --max entities are commented
+on; a query matching more is refused so a loose filter can't spam a project.`,
+		Flags: []cli.Flag{
+			cmdutil.OutputFlag(),
+			&cli.StringFlag{Name: "type", Required: true, Usage: "Entity type to comment on (e.g. Bug)"},
+			&cli.StringFlag{Name: "where", Aliases: []string{"w"}, Usage: "v2 filter selecting the entities"},
+			&cli.StringFlag{Name: "body", Required: true, Usage: "Comment text; {id} and {name} are replaced per entity"},
+			&cli.IntFlag{Name: "max", Value: 100, Usage: "Refuse to comment if more than this many entities match"},
+			&cli.IntFlag{Name: "concurrency", Value: 4, Usage: "Comments posted in parallel"},
+			&cli.BoolFlag{Name: "no-mention-resolve", Usage: "Send @mentions as typed without looking up users"},
+			&cli.BoolFlag{Name: "dry-run", Usage: "List the target entities and the comment without posting"},
+			&cli.BoolFlag{Name: "yes", Aliases: []string{"y"}, Usage: "Post without asking for confirmation"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			entityType := resolve.EntityType(cmd.String("type"))
+			if err := api.ValidateEntityType(entityType); err != nil {
+				return err
+			}
+			maxTargets := cmd.Int("max")
+			if maxTargets <= 0 {
+				return fmt.Errorf("max must be positive, got %d", maxTargets)
+			}
+			concurrency := cmd.Int("concurrency")
+			if concurrency <= 0 {
+				return fmt.Errorf("concurrency must be positive, got %d", concurrency)
+			}
+
+			client, err := f.Client()
+			if err != nil {
+				return err
+			}
+
+			targets, err := findTargets(ctx, client, entityType, cmd.String("where"), maxTargets)
+			if err != nil {
+				return err
+			}
+			if len(targets) == 0 {
+				fmt.Fprintln(f.Warnings(), "No matching entities; nothing to comment on.")
+				return nil
+			}
+
+			// Resolve mentions and apply markdown once for all targets.
+			fields := map[string]any{"Description": cmd.String("body")}
+			if err := text.PrepareFields(ctx, client, fields, text.PrepareOptions{
+				SkipMentions: cmd.Bool("no-mention-resolve"),
+				Warn:         f.Warnings(),
+			}); err != nil {
+				return fmt.Errorf("preparing comment: %w", err)
+			}
+			body, _ := fields["Description"].(string)
+
+			if cmd.Bool("dry-run") {
+				return printTargets(cmd, entityType, targets, body)
+			}
+
+			if !cmd.Bool("yes") {
+				if !cmdutil.IsInteractive() {
+					return fmt.Errorf("refusing to comment on %d entities without --yes when stdin is not a terminal", len(targets))
+				}
+				prompt := fmt.Sprintf("Post this comment to %d %s entities?", len(targets), entityType)
+				if !cmdutil.Confirm(os.Stdin, os.Stderr, prompt) {
+					return errors.New("bulk comment cancelled")
+				}
+			}
+
+			progress := f.Progress()
+			results := postAll(ctx, client, targets, body, concurrency, progress)
+			progress.Done()
+			return printResults(cmd, results)
+		},
+	}
+}
+
+// findTargets fetches the id and name of every matching entity, refusing
+// when more than maxTargets match.
+func findTargets(ctx context.Context, client *api.Client, entityType, where string, maxTargets int) ([]target, error) {
+	items, err := client.QueryV2All(ctx, entityType, api.V2Params{
+		Where:   where,
+		Select:  "id,name",
+		OrderBy: "id",
+		Take:    min(maxTargets+1, 1000),
+	})
+	if err != nil {
+		return nil, api.EnhanceError(err, "/api/v2/"+entityType, map[string]string{"where": where})
+	}
+	if len(items) > maxTargets {
+		return nil, fmt.Errorf("more than %d %s entities match; narrow the filter or raise --max", maxTargets, entityType)
+	}
+	targets := make([]target, 0, len(items))
+	for _, item := range items {
+		t := target{}
+		if id, ok := item["id"].(float64); ok {
+			t.ID = int(id)
+		}
+		t.Name, _ = item["name"].(string)
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+// fillPlaceholders replaces {id} and {name} in body for one target.
+func fillPlaceholders(body string, t target) string {
+	return strings.NewReplacer("{id}", fmt.Sprint(t.ID), "{name}", t.Name).Replace(body)
+}
+
+// postAll comments on each target with at most concurrency requests in
+// flight, counting finished posts on progress. Results are in target order;
+// failures are recorded, not fatal.
+func postAll(ctx context.Context, client *api.Client, targets []target, body string, concurrency int, progress *output.Progress) []result {
+	results := make([]result, len(targets))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var done atomic.Int32
+	for i, t := range targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = postOne(ctx, client, t, body)
+			progress.Update("posted %d of %d comments…", done.Add(1), len(targets))
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func postOne(ctx context.Context, client *api.Client, t target, body string) result {
+	r := result{EntityID: t.ID}
+	entity, err := client.CreateEntity(ctx, "Comment", map[string]any{
+		"Description": fillPlaceholders(body, t),
+		"General":     map[string]any{"Id": t.ID},
+	})
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	if id, ok := entity["Id"].(float64); ok {
+		r.CommentID = int(id)
+	}
+	return r
+}
+
+func printTargets(cmd *cli.Command, entityType string, targets []target, body string) error {
+	if cmdutil.IsStructured(cmd) {
+		return cmdutil.PrintStructured(cmd, map[string]any{
+			"dryRun":  true,
+			"targets": targets,
+			"count":   len(targets),
+			"comment": body,
+		})
+	}
+	fmt.Fprintf(os.Stdout, "Would comment on %d %s entities:\n", len(targets), entityType)
+	tw := output.NewTabWriter(os.Stdout)
+	fmt.Fprintln(tw, "ID\tNAME")
+	for _, t := range targets {
+		fmt.Fprintf(tw, "%d\t%s\n", t.ID, t.Name)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "\nComment:\n%s\n", body)
+	return nil
+}
+
+// printResults reports each post and returns an error if any failed.
+func printResults(cmd *cli.Command, results []result) error {
+	rep := batch.NewReporter(os.Stdout, cmd.String("output"))
+	for _, r := range results {
+		if r.Error != "" {
+			rep.Add(batch.Result{ID: r.EntityID, Status: batch.StatusError, Message: r.Error})
+			continue
+		}
+		rep.OK(r.EntityID, fmt.Sprintf("comment %d", r.CommentID))
+	}
+	if err := rep.Flush(fmt.Sprintf("Posted %d of %d comments.", rep.Succeeded(), len(results))); err != nil {
+		return err
+	}
+	return rep.Err("comments")
+}
@@ -0,0 +1,61 @@
+package bulkcomment
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/output"
+)
+
+func TestFillPlaceholders(t *testing.T) {
+	got := fillPlaceholders("{name} (#{id}) ships; see #{id}", target{ID: 42, Name: "Login"})
+	if want := "Login (#42) ships; see #42"; got != want {
+		t.Errorf("fillPlaceholders() = %q, want %q", got, want)
+	}
+}
+
+func TestPostAll(t *testing.T) {
+	var mu sync.Mutex
+	bodies := map[int]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var fields struct {
+			Description string
+			General     struct {
+				ID int `json:"Id"`
+			}
+		}
+		if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+		if fields.General.ID == 2 {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		mu.Lock()
+		bodies[fields.General.ID] = fields.Description
+		mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]any{"Id": 1000 + fields.General.ID})
+	}))
+	defer srv.Close()
+
+	client := api.NewClient(srv.URL, "tok", false)
+	targets := []target{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}}
+	results := postAll(context.Background(), client, targets, "hi {name}", 2, output.NewProgress(io.Discard))
+
+	if len(results) != 3 || results[0].CommentID != 1001 || results[2].CommentID != 1003 {
+		t.Errorf("unexpected results %+v", results)
+	}
+	if results[1].Error == "" || !strings.Contains(results[1].Error, "403") {
+		t.Errorf("failure for entity 2 should be recorded, got %+v", results[1])
+	}
+	if bodies[1] != "hi a" || bodies[3] != "hi c" {
+		t.Errorf("placeholders not filled per entity: %v", bodies)
+	}
+}
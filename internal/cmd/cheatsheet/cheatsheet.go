@@ -8,7 +8,6 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
-	"github.com/lifedraft/targetprocess-cli/internal/output"
 )
 
 const markdownCheatsheet = `# tp CLI — Quick Reference
@@ -18,61 +17,192 @@ const markdownCheatsheet = `# tp CLI — Quick Reference
 ### tp show <id> [flags]
 Show a single entity by ID (auto-detects type).
   --type          Entity type (skip auto-detection)
+  --no-detect     Fail if --type is missing instead of auto-detecting
   --include       Related data to include (e.g. Project,Team)
-  -o, --output    Output format: text, json
+  -o, --output    Output format: text, json, yaml
 
 ### tp search <type> [flags]
 Search entities using v2 API.
   -w, --where     Filter expression (e.g. 'entityState.isFinal!=true')
   -s, --select    Fields to return (e.g. 'id,name,entityState.name as state')
-  --preset        Use a preset filter (run 'tp presets' to list)
+  --preset        Use a preset filter (run 'tp presets' to list; comma-separate to stack)
+  --where-preset  Apply only a preset's where clause (keep your own select/order)
+  --where-file    Read the where expression from a file (multi-line, # comments; ANDed with -w)
+  --view          Named select preset (summary, detailed, planning, timeline)
   -t, --take      Max results (default 25, max 1000)
+  --skip          Skip N results (page with --take)
+  --page N --page-size M  Page N of M results (page size defaults to --take)
   --order-by      Sort expression (e.g. 'createDate desc')
-
-### tp create <type> <name> --project-id <ID>
+  --all           Fetch every page (--take is the page size)
+  --since-id      Only entities with id > N, ordered by id (incremental sync)
+  --created-by    Only entities created by a user (login, first or full name, or ID)
+  --modified-by   Only entities last modified by a user (login, name, or ID)
+  --eq/--ne/--in/--not-in/--gt/--gte/--lt/--lte/--between/--contains  Filter builder, as in tp query
+  --auto-quote    Quote bare words in --where comparisons (name==login → name=="login")
+  --dry-run       Show the URL without calling it
+  --explain       Annotated breakdown instead of the URL; sends nothing
+  --explain-errors  On failure, list every matching error pattern and hint
+
+### tp create <type> <name> [--project-id <ID>]
 Create a new entity.
-  --project-id    Project ID (required)
+  --project-id    Project ID (defaults to default_project_id from config)
   --description   Entity description
   --team-id       Team ID
   --assigned-user-id  Assigned user ID
+  --parent        Parent entity ID (Feature for a UserStory, UserStory for a Task, ...)
+  --custom "Name=value"  Set a custom field (repeatable); numbers/true/false typed, "quoted" stays text, empty clears
+  --strict        Fail (not just warn) if required fields from the type's metadata are unset
+  --dry-run       Print the request (method, path, JSON body) without sending it
+  --from-csv FILE --type <Type>  One entity per row; header names fields (Name required, Team.Id = reference)
 
 ### tp update <id> [flags]
-Update an entity (auto-detects type).
+Update an entity (auto-detects type). Shows a before/after diff and asks to confirm on a TTY.
   --type          Entity type (skip auto-detection)
+  --no-detect     Fail if --type is missing instead of auto-detecting
   --name          New name
   --description   New description
   --state-id      New entity state ID
+  --validate-transition  Reject --state-id locally if the workflow doesn't allow the move
   --assigned-user-id  New assigned user ID
+  --custom "Name=value"  Set a custom field (repeatable; the diff shows CustomFields.<name>)
+  -y, --yes       Skip the confirmation prompt
+  --confirm       Always confirm; fail without a TTY unless --yes (config: confirm_destructive)
+  --dry-run       Print the request (method, path, JSON body) without sending it
 
 ### tp comment list <entity-id>
-List comments on an entity.
+List comments on an entity, oldest first.
+  --sort asc|desc  Order by creation date
+  --limit N       At most N comments (after sorting)
 
 ### tp comment add <entity-id> <body>
 Add a comment (auto-markdown, @mention resolution).
 
 ### tp comment delete <comment-id>
-Delete a comment by ID.
+Delete a comment by ID. --dry-run prints the request instead; --confirm asks first.
+
+### tp time log <entity-id> --spent <duration> [flags]
+Log time against an entity. Durations like 2h, 90m, 1h30m (bare number = hours).
+  --remaining     Time left on the entity after this entry
+  --date          Day of the work, YYYY-MM-DD (default today)
+  --description   What the time was spent on (auto-markdown, @mentions)
+
+### tp time list <entity-id>
+List time logged on an entity (date, user, spent, remaining) with a total.
+
+### tp relate <from-id> <to-id> [--type Blocker|Dependency|Relation|...]
+Link two entities; <from-id> is the master (with Blocker, it blocks <to-id>). Default type: Relation.
+  --type          Relation type name, verb ("blocks", "depends on"), or ID
+  --dry-run       Print the request without sending it
+
+### tp relations <id>
+List an entity's relations: direction (→ outbound / ← inbound), type, and the other entity.
+
+### tp open <id> [--print]
+Open an entity in the web UI (--print just prints the URL).
 
 ### tp presets
-List available search presets.
+List available search presets and select views. Team presets go in the config
+file (presets: name: {description, where, select, orderBy}; where required)
+and show with source "config"; a built-in name is replaced, with a warning.
 
 ### tp query <Type>[/<id>] [flags]
 Query entities using v2 API with powerful filtering and projections.
   -s, --select    Fields to return (e.g., 'id,name,entityState.name as state')
   -w, --where     Filter expression
+  --where-preset  Apply a preset's where clause (see tp presets)
+  --where-file    Read the where expression from a file (multi-line, # comments; ANDed with -w)
+  --eq field:value  Exact match with safe quoting (repeatable, ANDed); null matches unset
+  --ne/--gt/--gte/--lt/--lte field:value  Other comparisons (numbers unquoted for ordering)
+  --in/--not-in field:a,b,c  Membership; --between field:lo,hi; --contains field:text
+  --auto-quote    Quote bare words in --where comparisons (name==login → name=="login")
+  --view          Named select preset (summary, detailed, planning, timeline)
+  --include A,B   v1-style includes: A.name as a, or B.count as bCount for collections
   --order         Sort (e.g., 'createDate desc')
   -t, --take      Max results (default 25, max 1000)
   --skip          Skip N results
+  --all           Fetch every page (--take is the page size)
+  --since-id      Only entities with id > N, ordered by id (incremental sync)
+  --changed-since Only entities modified since 7d or a timestamp (modifyDate)
+  --flatten       Flatten nested objects into dot-separated columns
+  --columns-order Table column display order (e.g. 'id,state,name')
+  --omit-empty-columns  Hide table columns that are blank in every row
+  --transpose     Show the first result as field/value lines (auto for one wide row)
+  -o jsonl        One JSON object per line (streams pages with --all)
+  -o tsv          Tab-separated columns with a header row (for cut/awk)
+  -o parquet --out FILE  Write results as a Parquet file
+  --as-assignee-report  Group all matches by assignee with item counts and total effort
+  --group-summary FIELD  Count and effort per FIELD value over all matches, plus a total row
+  --distinct FIELD  Sorted unique values of FIELD over all matches, with counts
+  --group-by FIELD  Count per FIELD value over all matches, most common first (as tp report)
+  --with-schema   With -o json, add a field-type schema (inferred from values)
+  --assert        Exit non-zero unless 'count <op> <n>' holds (e.g. 'count>=1')
+  --explain       Annotated breakdown (type, select keys, where, order, paging, redacted URL); sends nothing
+  --explain-errors  On failure, list every matching error pattern and hint
   --dry-run       Show URL without executing
 
-### tp inspect types|properties|details|discover
-Inspect Targetprocess API metadata.
-
-### tp api [METHOD] <path> [--body JSON]
+### tp export <Type> --file PATH [--format csv|json|jsonl] [query flags]
+Stream every matching entity to a file, page by page (progress on stderr).
+  --file PATH     File to write (required)
+  --format        csv, json, or jsonl (default from the extension, else csv)
+  -s, -w, --where-file, --where-preset, --order, --changed-since, --since-id, --eq/--in/...  As in tp query
+  --take N        Page size (default 1000)
+  --flatten       Dot-separated keys for json/jsonl (CSV always flattens)
+
+### tp bulk-comment --type <Type> -w <filter> --body <text> [flags]
+Post one comment to every matching entity (mentions resolved once; {id}/{name} filled per entity).
+  --dry-run       List targets and the prepared comment without posting
+  -y, --yes       Skip the confirmation prompt (required when not on a terminal)
+  --max           Refuse if more entities match (default 100)
+  --concurrency   Comments posted in parallel (default 4)
+  -o json         One result array: [{"id":1,"status":"ok"},{"id":2,"status":"error","message":"..."}]
+
+### tp projects [--active]
+List the projects your token can access (id, name, process, active), sorted by name.
+
+### tp states --type <Type> [--project <id> | --process <id|name>]
+List a type's workflow states (id, name, process, initial/planned/final): the IDs --state-id takes.
+Also available as tp inspect states.
+
+### tp report <Type> --group-by <field> [--metric count|sum(f)|avg(f)] [-w filter]
+Group matching entities client-side (avoids v2 groupBy) and print group → value.
+
+### tp rollup <feature-id>
+Total, completed, and remaining effort across the feature's user stories, with percent done.
+
+### tp inspect types|properties|details|discover|states|priorities|severities|whoami-projects
+Inspect Targetprocess API metadata, or list the projects your token can access.
+  states --type <Type> [--project <id> | --process <id|name>]   Workflow states (same as tp states)
+  priorities [--type <Type>] | severities   IDs, names, and importance for Priority / Severity
+  properties --settable|--gettable|--required   Filter fields by capability
+  properties --kind values|references|collections   Only one kind of field
+  properties --type <Type> <text> [--in-description]   Fields whose name contains text
+  types|properties|details -o xml   The metadata XML exactly as the API returns it
+
+### tp api [METHOD] <path> [--body JSON | --form key=value ...]
 Make raw API requests.
 
-### tp config get|set|list|path
+### tp watch-changes --type <Type> [flags]
+Poll for entities modified since the last poll; prints one JSON object per line.
+  --interval      Time between polls (default 30s)
+  -w, --where     Extra filter
+  --once          Poll once and exit (cursor kept in a state file)
+
+### tp whoami
+Show the authenticated user (login, name, id) and domain; a quick auth check.
+
+### tp version [--check]
+Print the CLI version. --check asks GitHub for a newer release (cached 24h);
+--no-check or TP_NO_UPDATE_CHECK keeps it offline (cached result only).
+
+### tp login [--domain <url>]
+Prompt for domain and token (hidden), verify them against the API, and save them
+(token in the system keychain when available).
+
+### tp config get|set|set-default-project|list|path|doctor
 Manage configuration.
+  set-default-project <id>  Project used by create when --project-id is omitted
+  set quiet true  Like the global --quiet / TP_QUIET: no warnings or hints on stderr
+  doctor          Check config file, token source, keychain, DNS, and an authenticated call; pass/warn/fail with hints
 
 ## Entity Types
 Common: UserStory, Bug, Task, Feature, Epic, Request
@@ -90,14 +220,14 @@ Other: Project, Team, Iteration, TeamIteration, Release, Program, Comment, Time,
   tasks.sum(effort) as total             — aggregation (sum/avg/min/max)
 
 ### Where Operators
-  ==, !=, >, <, >=, <=                   — comparison
+  ==, !=, >, <, >=, <=                   — comparison (NOT a single =)
   and, or, not(...)                      — logical
   entityState.name in ["Open","Done"]    — membership
   name.contains("text")                  — substring
   name.startsWith("prefix")             — prefix match
   name.toLower().contains("text")        — case-insensitive
   field==null, field!=null               — null check (NOT 'is null')
-  entityState.isFinal==true              — done states
+  entityState.isFinal==true              — done states (booleans are lower-case)
   entityState.isInitial==true            — open states
   assignments.any(generalUser.id==123)   — collection predicate
 
@@ -111,6 +241,7 @@ Other: Project, Team, Iteration, TeamIteration, Release, Program, Comment, Time,
 ### OrderBy
   createDate desc                        — descending
   priority.importance desc,name asc      — multiple fields
+  NOTE: aggregates (tasks.count) cannot be ordered by; tp warns and you sort client-side
 
 ## Search Presets
   open, inProgress, done, unassigned, highPriority,
@@ -159,8 +290,8 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
 			cmdutil.OutputFlag(),
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			if cmdutil.IsJSON(cmd) {
-				return output.PrintJSON(os.Stdout, jsonCheatsheet())
+			if cmdutil.IsStructured(cmd) {
+				return cmdutil.PrintStructured(cmd, jsonCheatsheet())
 			}
 			fmt.Fprint(os.Stdout, markdownCheatsheet)
 			return nil
@@ -189,7 +320,12 @@ func jsonCheatsheet() map[string]any {
 					{"name": "-s, --select", "usage": "Fields to return"},
 					{"name": "--preset", "usage": "Use a preset filter"},
 					{"name": "-t, --take", "usage": "Max results (default 25, max 1000)"},
+					{"name": "--skip", "usage": "Skip N results"},
+					{"name": "--page", "usage": "Page number, starting at 1"},
+					{"name": "--page-size", "usage": "Results per page for --page (default: --take)"},
 					{"name": "--order-by", "usage": "Sort expression"},
+					{"name": "--created-by", "usage": "Only entities created by a user"},
+					{"name": "--modified-by", "usage": "Only entities last modified by a user"},
 				},
 			},
 			{
@@ -197,10 +333,12 @@ func jsonCheatsheet() map[string]any {
 				"usage": "Create a new entity",
 				"args":  "<type> <name>",
 				"flags": []map[string]string{
-					{"name": "--project-id", "usage": "Project ID (required)"},
+					{"name": "--project-id", "usage": "Project ID (defaults to default_project_id from config)"},
 					{"name": "--description", "usage": "Entity description"},
 					{"name": "--team-id", "usage": "Team ID"},
 					{"name": "--assigned-user-id", "usage": "Assigned user ID"},
+					{"name": "--custom", "usage": "Set a custom field as Name=value (repeatable, typed)"},
+					{"name": "--from-csv", "usage": "Create one entity per CSV row (with --type)"},
 				},
 			},
 			{
@@ -213,6 +351,7 @@ func jsonCheatsheet() map[string]any {
 					{"name": "--description", "usage": "New description"},
 					{"name": "--state-id", "usage": "New state ID"},
 					{"name": "--assigned-user-id", "usage": "Assigned user ID"},
+					{"name": "--custom", "usage": "Set a custom field as Name=value (repeatable, typed)"},
 				},
 			},
 			{
@@ -230,6 +369,44 @@ func jsonCheatsheet() map[string]any {
 				"usage": "Delete a comment by ID",
 				"args":  "<comment-id>",
 			},
+			{
+				"name":  "tp time log",
+				"usage": "Log time against an entity",
+				"args":  "<entity-id>",
+				"flags": []map[string]string{
+					{"name": "--spent", "usage": "Time spent, e.g. 2h or 90m (required)"},
+					{"name": "--remaining", "usage": "Time left after this entry"},
+					{"name": "--date", "usage": "Day of the work, YYYY-MM-DD"},
+					{"name": "--description", "usage": "What the time was spent on"},
+				},
+			},
+			{
+				"name":  "tp time list",
+				"usage": "List time logged on an entity",
+				"args":  "<entity-id>",
+			},
+			{
+				"name":  "tp relate",
+				"usage": "Link two entities with a relation",
+				"args":  "<from-id> <to-id>",
+				"flags": []map[string]string{
+					{"name": "--type", "usage": "Relation type name, verb, or ID (default Relation)"},
+					{"name": "--dry-run", "usage": "Print the request without sending it"},
+				},
+			},
+			{
+				"name":  "tp relations",
+				"usage": "List an entity's relations with direction and the related entity",
+				"args":  "<id>",
+			},
+			{
+				"name":  "tp open",
+				"usage": "Open an entity in the web UI",
+				"args":  "<id>",
+				"flags": []map[string]string{
+					{"name": "--print", "usage": "Print the URL instead of opening it"},
+				},
+			},
 			{
 				"name":  "tp presets",
 				"usage": "List available search presets",
@@ -247,20 +424,101 @@ func jsonCheatsheet() map[string]any {
 					{"name": "--dry-run", "usage": "Show URL without executing"},
 				},
 			},
+			{
+				"name":  "tp bulk-comment",
+				"usage": "Post the same comment to every entity matching a query",
+				"flags": []map[string]string{
+					{"name": "--type", "usage": "Entity type (required)"},
+					{"name": "-w, --where", "usage": "Filter expression"},
+					{"name": "--body", "usage": "Comment text; {id} and {name} are filled per entity"},
+					{"name": "--dry-run", "usage": "List targets without posting"},
+					{"name": "-y, --yes", "usage": "Skip confirmation"},
+				},
+			},
+			{
+				"name":  "tp projects",
+				"usage": "List accessible projects",
+				"flags": []map[string]string{
+					{"name": "--active", "usage": "Only active projects"},
+				},
+			},
+			{
+				"name":  "tp states",
+				"usage": "List an entity type's workflow states and their IDs",
+				"flags": []map[string]string{
+					{"name": "--type", "usage": "Entity type (required)"},
+					{"name": "--project", "usage": "Only states of this project's process"},
+					{"name": "--process", "usage": "Only states of this process (ID or name)"},
+				},
+			},
+			{
+				"name":  "tp export",
+				"usage": "Stream every entity a query matches to a CSV, JSON, or JSON Lines file",
+				"flags": []map[string]string{
+					{"name": "--file", "usage": "File to write (required)"},
+					{"name": "--format", "usage": "csv, json, or jsonl (default from the extension)"},
+					{"name": "-s, --select", "usage": "Fields to export"},
+					{"name": "-w, --where", "usage": "Filter expression"},
+					{"name": "--take", "usage": "Page size (default 1000)"},
+				},
+			},
+			{
+				"name":  "tp report",
+				"usage": "Group entities client-side and aggregate a metric per group",
+				"flags": []map[string]string{
+					{"name": "--group-by", "usage": "Field path to group on (required)"},
+					{"name": "--metric", "usage": "count, sum(field), or avg(field)"},
+					{"name": "-w, --where", "usage": "Filter expression"},
+				},
+			},
+			{
+				"name":  "tp rollup",
+				"usage": "Show total vs done effort across a feature's user stories",
+			},
 			{
 				"name":  "tp inspect",
-				"usage": "Inspect API metadata (types, properties, details, discover)",
+				"usage": "Inspect API metadata (types, properties, details, discover, states, priorities, severities, whoami-projects)",
 			},
 			{
 				"name":  "tp api",
 				"usage": "Make raw API requests",
 				"flags": []map[string]string{
 					{"name": "--body", "usage": "Request body (JSON string)"},
+					{"name": "--form", "usage": "Form field key=value, form-encoded (repeatable)"},
+				},
+			},
+			{
+				"name":  "tp watch-changes",
+				"usage": "Poll for modified entities and print them as JSON lines",
+				"flags": []map[string]string{
+					{"name": "--type", "usage": "Entity type to watch (required)"},
+					{"name": "--interval", "usage": "Time between polls (default 30s)"},
+					{"name": "-w, --where", "usage": "Extra filter"},
+					{"name": "--once", "usage": "Poll once and exit"},
 				},
 			},
 			{
 				"name":  "tp config",
-				"usage": "Manage configuration (get, set, list, path)",
+				"usage": "Manage configuration (get, set, set-default-project, list, path, doctor)",
+			},
+			{
+				"name":  "tp whoami",
+				"usage": "Show the authenticated user and domain",
+			},
+			{
+				"name":  "tp version",
+				"usage": "Print the CLI version; --check reports a newer GitHub release",
+				"flags": []map[string]string{
+					{"name": "--check", "usage": "Check GitHub for a newer release (cached 24h)"},
+					{"name": "--no-check", "usage": "Stay offline; only a cached result is shown (env: TP_NO_UPDATE_CHECK)"},
+				},
+			},
+			{
+				"name":  "tp login",
+				"usage": "Prompt for domain and token, verify them, and save them",
+				"flags": []map[string]string{
+					{"name": "--domain", "usage": "Domain (prompted if omitted)"},
+				},
 			},
 		},
 		"entityTypes": []string{
@@ -280,14 +538,14 @@ func jsonCheatsheet() map[string]any {
 				"tasks.sum(effort) as total — aggregation (sum/avg/min/max)",
 			},
 			"where": []string{
-				"==, !=, >, <, >=, <= — comparison",
+				"==, !=, >, <, >=, <= — comparison (NOT a single =)",
 				"and, or, not(...) — logical",
 				"entityState.name in [\"Open\",\"Done\"] — membership",
 				"name.contains(\"text\") — substring",
 				"name.startsWith(\"prefix\") — prefix match",
 				"name.toLower().contains(\"text\") — case-insensitive",
 				"field==null, field!=null — null check (NOT 'is null')",
-				"entityState.isFinal==true — done states",
+				"entityState.isFinal==true — done states (booleans are lower-case)",
 				"entityState.isInitial==true — open states",
 				"assignments.any(generalUser.id==123) — collection predicate",
 			},
@@ -301,6 +559,7 @@ func jsonCheatsheet() map[string]any {
 			"orderBy": []string{
 				"createDate desc — descending",
 				"priority.importance desc,name asc — multiple fields",
+				"NOTE: aggregates (tasks.count) cannot be ordered by; tp warns and you sort client-side",
 			},
 		},
 		"presets": []string{
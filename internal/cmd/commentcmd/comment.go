@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -27,6 +29,15 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
   # Add a comment with @mentions
   tp comment add 342236 "Hey @timo, this looks good"
 
+  # The three most recent comments
+  tp comment list 342236 --sort desc --limit 3
+
+  # Only comments by a given author (partial name or login)
+  tp comment list 342236 --author timo
+
+  # Reply to an existing comment
+  tp comment add 342236 "Agreed, merging now" --reply-to 1001
+
   # Delete a comment
   tp comment delete 99999`,
 		Commands: []*cli.Command{
@@ -45,6 +56,9 @@ func newListCmd(f *cmdutil.Factory) *cli.Command {
 		Flags: []cli.Flag{
 			cmdutil.OutputFlag(),
 			&cli.IntFlag{Name: "entity-id", Usage: "Entity ID (alternative to positional argument)"},
+			&cli.StringFlag{Name: "author", Usage: "Only show comments whose owner matches (partial name, login, or user ID)"},
+			&cli.StringFlag{Name: "sort", Value: "asc", Usage: "Order by creation date: asc (oldest first) or desc"},
+			&cli.IntFlag{Name: "limit", Usage: "Show at most N comments, taken after sorting (0 for all)"},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			entityID, err := resolveEntityID(cmd)
@@ -52,21 +66,46 @@ func newListCmd(f *cmdutil.Factory) *cli.Command {
 				return err
 			}
 
+			order := cmd.String("sort")
+			if order != "asc" && order != "desc" {
+				return fmt.Errorf("invalid --sort %q: must be asc or desc", order)
+			}
+			limit := cmd.Int("limit")
+			if limit < 0 {
+				return fmt.Errorf("limit must be non-negative, got %d", limit)
+			}
+
 			client, err := f.Client()
 			if err != nil {
 				return err
 			}
 
 			where := fmt.Sprintf("General.Id eq %d", entityID)
-			include := []string{"Description", "CreateDate", "Owner"}
+			include := []string{"Description", "CreateDate", "Owner", "ParentId"}
 
 			comments, err := client.SearchEntities(ctx, "Comment", where, include, 0, nil)
 			if err != nil {
 				return fmt.Errorf("listing comments: %w", err)
 			}
 
-			if cmdutil.IsJSON(cmd) {
-				return output.PrintJSON(os.Stdout, map[string]any{
+			if author := cmd.String("author"); author != "" {
+				comments = filterByAuthor(comments, author)
+			}
+
+			sortByCreateDate(comments, order == "desc")
+			if limit > 0 && len(comments) > limit {
+				comments = comments[:limit]
+			}
+
+			// Always expose ParentId so JSON consumers can rebuild the thread tree.
+			for _, c := range comments {
+				if _, ok := c["ParentId"]; !ok {
+					c["ParentId"] = nil
+				}
+			}
+
+			if cmdutil.IsStructured(cmd) {
+				return cmdutil.PrintStructured(cmd, map[string]any{
 					"items": comments,
 					"count": len(comments),
 				})
@@ -86,6 +125,8 @@ func newAddCmd(f *cmdutil.Factory) *cli.Command {
 		Flags: []cli.Flag{
 			cmdutil.OutputFlag(),
 			&cli.IntFlag{Name: "entity-id", Usage: "Entity ID (alternative to positional argument)"},
+			&cli.IntFlag{Name: "reply-to", Usage: "Parent comment ID to reply to"},
+			&cli.BoolFlag{Name: "no-mention-resolve", Usage: "Send @mentions as typed without looking up users"},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			args := cmd.Args().Slice()
@@ -104,8 +145,18 @@ func newAddCmd(f *cmdutil.Factory) *cli.Command {
 				"Description": body,
 				"General":     map[string]any{"Id": entityID},
 			}
+			if cmd.IsSet("reply-to") {
+				parentID := cmd.Int("reply-to")
+				if parentID <= 0 {
+					return fmt.Errorf("reply-to comment ID must be positive, got %d", parentID)
+				}
+				fields["ParentId"] = parentID
+			}
 
-			if prepErr := text.PrepareFields(ctx, client, fields); prepErr != nil {
+			if prepErr := text.PrepareFields(ctx, client, fields, text.PrepareOptions{
+				SkipMentions: cmd.Bool("no-mention-resolve"),
+				Warn:         f.Warnings(),
+			}); prepErr != nil {
 				return fmt.Errorf("preparing comment fields: %w", prepErr)
 			}
 
@@ -114,8 +165,8 @@ func newAddCmd(f *cmdutil.Factory) *cli.Command {
 				return fmt.Errorf("adding comment: %w", err)
 			}
 
-			if cmdutil.IsJSON(cmd) {
-				return output.PrintJSON(os.Stdout, entity)
+			if cmdutil.IsStructured(cmd) {
+				return cmdutil.PrintStructured(cmd, entity)
 			}
 
 			output.PrintEntity(os.Stdout, entity)
@@ -129,6 +180,11 @@ func newDeleteCmd(f *cmdutil.Factory) *cli.Command {
 		Name:      "delete",
 		Usage:     "Delete a comment by ID",
 		ArgsUsage: "<comment-id>",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "dry-run", Usage: "Print the request that would be sent without deleting anything"},
+			cmdutil.ConfirmFlag(),
+			&cli.BoolFlag{Name: "yes", Aliases: []string{"y"}, Usage: "Delete without asking, even with --confirm"},
+		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			args := cmd.Args().Slice()
 			if len(args) == 0 {
@@ -143,11 +199,30 @@ func newDeleteCmd(f *cmdutil.Factory) *cli.Command {
 				return fmt.Errorf("comment ID must be positive, got %d", id)
 			}
 
+			if cmd.Bool("dry-run") {
+				if err := cmdutil.PrintDryRun(os.Stdout, cmd, http.MethodDelete, api.EntityPath("Comment", id), nil); err != nil {
+					return err
+				}
+				fmt.Fprintf(os.Stdout, "Would delete comment %d\n", id)
+				return nil
+			}
+
 			client, err := f.Client()
 			if err != nil {
 				return err
 			}
 
+			if f.ConfirmRequired(cmd) {
+				comment, err := client.GetEntity(ctx, "Comment", id, nil)
+				if err != nil {
+					return fmt.Errorf("fetching comment: %w", err)
+				}
+				fmt.Fprintln(os.Stderr, cmdutil.EntitySummary("Comment", id, comment))
+				if err := cmdutil.ConfirmDestructive(cmd.Bool("yes"), "Delete this comment?"); err != nil {
+					return fmt.Errorf("delete: %w", err)
+				}
+			}
+
 			if _, err := client.DeleteEntity(ctx, "Comment", id); err != nil {
 				return fmt.Errorf("deleting comment: %w", err)
 			}
@@ -211,14 +286,10 @@ func printCommentTable(comments []api.Entity) {
 	tw := output.NewTabWriter(os.Stdout)
 	fmt.Fprintln(tw, "ID\tOWNER\tDATE\tDESCRIPTION")
 
-	for _, c := range comments {
+	for _, tc := range threadComments(comments) {
+		c := tc.comment
 		id := c["Id"]
-		owner := ""
-		if o, ok := c["Owner"].(map[string]any); ok {
-			if name, ok := o["Name"]; ok {
-				owner = fmt.Sprintf("%v", name)
-			}
-		}
+		owner := ownerName(c)
 		date := ""
 		if d, ok := c["CreateDate"]; ok {
 			date = fmt.Sprintf("%v", d)
@@ -227,13 +298,142 @@ func printCommentTable(comments []api.Entity) {
 		if d, ok := c["Description"]; ok {
 			desc = fmt.Sprintf("%v", d)
 		}
-		desc = strings.TrimPrefix(desc, "<!--markdown-->")
-		desc = strings.TrimSpace(desc)
-		if len(desc) > 80 {
-			desc = desc[:77] + "..."
+		// Render for the terminal only; JSON output keeps the stored form.
+		desc = strings.Join(strings.Fields(text.MarkdownToPlain(desc)), " ")
+		if r := []rune(desc); len(r) > 80 {
+			desc = string(r[:77]) + "..."
+		}
+		if tc.depth > 0 {
+			desc = strings.Repeat("  ", tc.depth-1) + "↳ " + desc
 		}
 
 		fmt.Fprintf(tw, "%v\t%s\t%s\t%s\n", id, owner, date, desc)
 	}
 	tw.Flush()
 }
+
+// ownerName returns a display name for a comment's owner, preferring the
+// Name field and falling back to FirstName LastName.
+func ownerName(c api.Entity) string {
+	o, ok := c["Owner"].(map[string]any)
+	if !ok {
+		return ""
+	}
+	if name, ok := o["Name"]; ok && name != nil {
+		return fmt.Sprintf("%v", name)
+	}
+	var parts []string
+	for _, key := range []string{"FirstName", "LastName"} {
+		if v, ok := o[key].(string); ok && v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// filterByAuthor keeps comments whose owner matches author: an exact user ID,
+// or a case-insensitive substring of the owner's name or login.
+func filterByAuthor(comments []api.Entity, author string) []api.Entity {
+	needle := strings.ToLower(strings.TrimPrefix(author, "@"))
+	authorID, _ := strconv.Atoi(needle)
+
+	var matched []api.Entity
+	for _, c := range comments {
+		o, ok := c["Owner"].(map[string]any)
+		if !ok {
+			continue
+		}
+		if authorID > 0 && entityID(o["Id"]) == authorID {
+			matched = append(matched, c)
+			continue
+		}
+		login, _ := o["Login"].(string)
+		if strings.Contains(strings.ToLower(ownerName(c)), needle) ||
+			strings.Contains(strings.ToLower(login), needle) {
+			matched = append(matched, c)
+		}
+	}
+	return matched
+}
+
+// sortByCreateDate orders comments by CreateDate, oldest first unless desc.
+// Dates are compared as instants, not strings; comments without a parseable
+// date go last, keeping their relative order.
+func sortByCreateDate(comments []api.Entity, desc bool) {
+	sort.SliceStable(comments, func(i, j int) bool {
+		ti, iok := api.ParseDate(comments[i]["CreateDate"])
+		tj, jok := api.ParseDate(comments[j]["CreateDate"])
+		if !iok || !jok {
+			return iok && !jok
+		}
+		if desc {
+			return ti.After(tj)
+		}
+		return ti.Before(tj)
+	})
+}
+
+// threadedComment is a comment paired with its nesting depth in the reply tree.
+type threadedComment struct {
+	comment api.Entity
+	depth   int
+}
+
+// threadComments orders comments depth-first so replies follow their parent.
+// Comments whose parent is not in the set are treated as top-level, so a
+// partial result still renders every comment exactly once.
+func threadComments(comments []api.Entity) []threadedComment {
+	present := make(map[int]bool, len(comments))
+	for _, c := range comments {
+		present[entityID(c["Id"])] = true
+	}
+
+	children := make(map[int][]api.Entity)
+	var roots []api.Entity
+	for _, c := range comments {
+		parent := entityID(c["ParentId"])
+		if parent > 0 && present[parent] && parent != entityID(c["Id"]) {
+			children[parent] = append(children[parent], c)
+			continue
+		}
+		roots = append(roots, c)
+	}
+
+	result := make([]threadedComment, 0, len(comments))
+	visited := make(map[int]bool, len(comments))
+	var walk func(c api.Entity, depth int)
+	walk = func(c api.Entity, depth int) {
+		id := entityID(c["Id"])
+		if visited[id] {
+			return
+		}
+		visited[id] = true
+		result = append(result, threadedComment{comment: c, depth: depth})
+		for _, child := range children[id] {
+			walk(child, depth+1)
+		}
+	}
+	for _, c := range roots {
+		walk(c, 0)
+	}
+
+	// Anything left over is part of a parent cycle; append it flat.
+	for _, c := range comments {
+		if !visited[entityID(c["Id"])] {
+			result = append(result, threadedComment{comment: c})
+		}
+	}
+	return result
+}
+
+// entityID converts a JSON-decoded ID value to an int, returning 0 if absent.
+func entityID(v any) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	default:
+		return 0
+	}
+}
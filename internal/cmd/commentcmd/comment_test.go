@@ -0,0 +1,96 @@
+package commentcmd
+
+import (
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+func TestThreadComments(t *testing.T) {
+	comments := []api.Entity{
+		{"Id": float64(1)},
+		{"Id": float64(2)},
+		{"Id": float64(3), "ParentId": float64(1)},
+		{"Id": float64(4), "ParentId": float64(3)},
+		{"Id": float64(5), "ParentId": float64(99)}, // parent not in set
+		{"Id": float64(6), "ParentId": nil},
+	}
+
+	got := threadComments(comments)
+
+	want := []struct {
+		id    int
+		depth int
+	}{
+		{1, 0}, {3, 1}, {4, 2}, {2, 0}, {5, 0}, {6, 0},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d comments, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if id := entityID(got[i].comment["Id"]); id != w.id || got[i].depth != w.depth {
+			t.Errorf("position %d: got id=%d depth=%d, want id=%d depth=%d", i, id, got[i].depth, w.id, w.depth)
+		}
+	}
+}
+
+func TestFilterByAuthor(t *testing.T) {
+	comments := []api.Entity{
+		{"Id": float64(1), "Owner": map[string]any{"Id": float64(285), "FirstName": "Stan", "LastName": "Mueller"}},
+		{"Id": float64(2), "Owner": map[string]any{"Id": float64(994), "FirstName": "Timo", "LastName": "Litzius", "Login": "tlitzius"}},
+		{"Id": float64(3)},
+	}
+
+	tests := []struct {
+		author string
+		want   []int
+	}{
+		{"timo", []int{2}},
+		{"@MUELLER", []int{1}},
+		{"tlitz", []int{2}},
+		{"285", []int{1}},
+		{"nobody", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.author, func(t *testing.T) {
+			got := filterByAuthor(comments, tt.author)
+			if len(got) != len(tt.want) {
+				t.Fatalf("filterByAuthor(%q) returned %d comments, want %d", tt.author, len(got), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if entityID(got[i]["Id"]) != id {
+					t.Errorf("filterByAuthor(%q)[%d] = %v, want %d", tt.author, i, got[i]["Id"], id)
+				}
+			}
+		})
+	}
+}
+
+func TestSortByCreateDate(t *testing.T) {
+	newComments := func() []api.Entity {
+		return []api.Entity{
+			// String order differs from time order: 9e11 ms sorts after 1.7e12 as text.
+			{"Id": float64(1), "CreateDate": "/Date(1700000000000+0100)/"},
+			{"Id": float64(2), "CreateDate": "/Date(900000000000+0100)/"},
+			{"Id": float64(3)},
+			{"Id": float64(4), "CreateDate": "/Date(1800000000000+0200)/"},
+		}
+	}
+
+	tests := []struct {
+		desc bool
+		want []int
+	}{
+		{false, []int{2, 1, 4, 3}},
+		{true, []int{4, 1, 2, 3}},
+	}
+	for _, tt := range tests {
+		comments := newComments()
+		sortByCreateDate(comments, tt.desc)
+		for i, id := range tt.want {
+			if got := entityID(comments[i]["Id"]); got != id {
+				t.Errorf("desc=%v: position %d has id %d, want %d", tt.desc, i, got, id)
+			}
+		}
+	}
+}
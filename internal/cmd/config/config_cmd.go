@@ -4,13 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
+	"strconv"
 
 	"github.com/urfave/cli/v3"
 
 	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
 	internalconfig "github.com/lifedraft/targetprocess-cli/internal/config"
-	"github.com/lifedraft/targetprocess-cli/internal/output"
 )
 
 func NewCmd(f *cmdutil.Factory) *cli.Command {
@@ -20,8 +19,10 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
 		Commands: []*cli.Command{
 			newGetCmd(f),
 			newSetCmd(f),
+			newSetDefaultProjectCmd(f),
 			newListCmd(f),
 			newPathCmd(),
+			newDoctorCmd(f),
 		},
 	}
 }
@@ -35,7 +36,7 @@ func newGetCmd(f *cmdutil.Factory) *cli.Command {
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			key := cmd.Args().First()
 			if key == "" {
-				return errors.New("key argument is required (valid keys: domain, token)")
+				return fmt.Errorf("key argument is required (valid keys: %s)", internalconfig.ValidKeys)
 			}
 			if key == "token" {
 				cfg, err := internalconfig.Load(f.ConfigPath)
@@ -43,8 +44,8 @@ func newGetCmd(f *cmdutil.Factory) *cli.Command {
 					return err
 				}
 				configured := cfg.Token != ""
-				if cmdutil.IsJSON(cmd) {
-					return output.PrintJSON(os.Stdout, map[string]any{
+				if cmdutil.IsStructured(cmd) {
+					return cmdutil.PrintStructured(cmd, map[string]any{
 						"configured": configured,
 						"source":     string(cfg.TokenSource),
 					})
@@ -56,12 +57,32 @@ func newGetCmd(f *cmdutil.Factory) *cli.Command {
 				}
 				return nil
 			}
+			if key == "password" {
+				// Like the token, never print the password itself.
+				cfg, err := internalconfig.Load(f.ConfigPath)
+				if err != nil {
+					return err
+				}
+				configured := cfg.Password != ""
+				if cmdutil.IsStructured(cmd) {
+					return cmdutil.PrintStructured(cmd, map[string]any{
+						"configured": configured,
+						"source":     string(cfg.PasswordSource),
+					})
+				}
+				if configured {
+					fmt.Printf("Password is configured (source: %s)\n", cfg.PasswordSource)
+				} else {
+					fmt.Println("Password is not configured")
+				}
+				return nil
+			}
 			val, err := internalconfig.Get(f.ConfigPath, key)
 			if err != nil {
 				return err
 			}
-			if cmdutil.IsJSON(cmd) {
-				return output.PrintJSON(os.Stdout, map[string]string{key: val})
+			if cmdutil.IsStructured(cmd) {
+				return cmdutil.PrintStructured(cmd, map[string]string{key: val})
 			}
 			fmt.Println(val)
 			return nil
@@ -81,18 +102,22 @@ func newSetCmd(f *cmdutil.Factory) *cli.Command {
 			key := cmd.Args().Get(0)
 			value := cmd.Args().Get(1)
 
-			if key == "token" {
-				source, err := internalconfig.SetToken(f.ConfigPath, value)
+			if key == "token" || key == "password" {
+				set, label := internalconfig.SetToken, "Token"
+				if key == "password" {
+					set, label = internalconfig.SetPassword, "Password"
+				}
+				source, err := set(f.ConfigPath, value)
 				if err != nil {
 					return err
 				}
 				switch source {
 				case internalconfig.TokenSourceKeyring:
-					fmt.Fprintln(os.Stderr, "Token stored in system keychain")
+					fmt.Fprintf(f.Warnings(), "%s stored in system keychain\n", label)
 				case internalconfig.TokenSourceFile:
-					fmt.Fprintf(os.Stderr, "Warning: keychain unavailable, token stored in plain text at %s\n", internalconfig.DefaultPath())
+					fmt.Fprintf(f.Warnings(), "Warning: keychain unavailable, %s stored in plain text at %s\n", key, internalconfig.DefaultPath())
 				case internalconfig.TokenSourceNone, internalconfig.TokenSourceEnv:
-					// Not reachable from SetToken, but satisfy exhaustive check.
+					// Not reachable from SetToken or SetPassword, but satisfy exhaustive check.
 				}
 				return nil
 			}
@@ -100,7 +125,43 @@ func newSetCmd(f *cmdutil.Factory) *cli.Command {
 			if err := internalconfig.Set(f.ConfigPath, key, value); err != nil {
 				return err
 			}
-			fmt.Fprintf(os.Stderr, "Set %s successfully\n", key)
+			fmt.Fprintf(f.Warnings(), "Set %s successfully\n", key)
+			return nil
+		},
+	}
+}
+
+func newSetDefaultProjectCmd(f *cmdutil.Factory) *cli.Command {
+	return &cli.Command{
+		Name:      "set-default-project",
+		Usage:     "Set the project used by create when --project-id is omitted",
+		ArgsUsage: "<project-id>",
+		UsageText: `# Use project 42 for all creates
+  tp config set-default-project 42
+
+  # Clear the default project
+  tp config set-default-project 0`,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			arg := cmd.Args().First()
+			if arg == "" {
+				return errors.New("project ID is required; usage: tp config set-default-project <project-id>")
+			}
+			id, err := strconv.Atoi(arg)
+			if err != nil {
+				return fmt.Errorf("invalid project ID %q: must be an integer", arg)
+			}
+			if id < 0 {
+				return fmt.Errorf("project ID must be non-negative, got %d", id)
+			}
+
+			if err := internalconfig.Set(f.ConfigPath, "default_project_id", arg); err != nil {
+				return err
+			}
+			if id == 0 {
+				fmt.Fprintln(f.Warnings(), "Cleared default project")
+			} else {
+				fmt.Fprintf(f.Warnings(), "Default project set to %d\n", id)
+			}
 			return nil
 		},
 	}
@@ -118,15 +179,56 @@ func newListCmd(f *cmdutil.Factory) *cli.Command {
 			}
 			token := redactToken(cfg.Token)
 			source := string(cfg.TokenSource)
-			if cmdutil.IsJSON(cmd) {
-				return output.PrintJSON(os.Stdout, map[string]string{
-					"domain":       cfg.Domain,
-					"token":        token,
-					"token_source": source,
+			if cmdutil.IsStructured(cmd) {
+				return cmdutil.PrintStructured(cmd, map[string]any{
+					"domain":              cfg.Domain,
+					"token":               token,
+					"token_source":        source,
+					"default_project_id":  cfg.DefaultProjectID,
+					"max_retry_wait":      cfg.MaxRetryWait,
+					"timezone":            cfg.Timezone,
+					"auth_mode":           cfg.AuthMode,
+					"username":            cfg.Username,
+					"password":            redactToken(cfg.Password),
+					"password_source":     string(cfg.PasswordSource),
+					"output_width":        cfg.OutputWidth,
+					"confirm_destructive": cfg.ConfirmDestructive,
+					"timeout":             cfg.Timeout,
+					"quiet":               cfg.Quiet,
 				})
 			}
 			fmt.Printf("domain: %s\n", cfg.Domain)
 			fmt.Printf("token:  %s (source: %s)\n", token, source)
+			if cfg.DefaultProjectID > 0 {
+				fmt.Printf("default_project_id: %d\n", cfg.DefaultProjectID)
+			}
+			if cfg.MaxRetryWait > 0 {
+				fmt.Printf("max_retry_wait: %d\n", cfg.MaxRetryWait)
+			}
+			if cfg.Timezone != "" {
+				fmt.Printf("timezone: %s\n", cfg.Timezone)
+			}
+			if cfg.AuthMode != "" {
+				fmt.Printf("auth_mode: %s\n", cfg.AuthMode)
+			}
+			if cfg.Username != "" {
+				fmt.Printf("username: %s\n", cfg.Username)
+			}
+			if cfg.Password != "" {
+				fmt.Printf("password: %s (source: %s)\n", redactToken(cfg.Password), cfg.PasswordSource)
+			}
+			if cfg.OutputWidth > 0 {
+				fmt.Printf("output_width: %d\n", cfg.OutputWidth)
+			}
+			if cfg.ConfirmDestructive {
+				fmt.Println("confirm_destructive: true")
+			}
+			if cfg.Timeout != "" {
+				fmt.Printf("timeout: %s\n", cfg.Timeout)
+			}
+			if cfg.Quiet {
+				fmt.Println("quiet: true")
+			}
 			return nil
 		},
 	}
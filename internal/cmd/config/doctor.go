@@ -0,0 +1,293 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/bugreport"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/search"
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+	internalconfig "github.com/lifedraft/targetprocess-cli/internal/config"
+)
+
+// Check outcomes, worst last.
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+// check is the result of one doctor check.
+type check struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+func newDoctorCmd(f *cmdutil.Factory) *cli.Command {
+	return &cli.Command{
+		Name:  "doctor",
+		Usage: "Check the config, credentials, keychain, and connection to Targetprocess",
+		UsageText: `# First thing to run when tp isn't working
+  tp config doctor
+
+  # Machine-readable results
+  tp config doctor -o json`,
+		Description: `Runs each check in turn and prints pass, warn, or fail with a hint on how to
+fix it: the config file is readable, any presets in it are valid, a token
+is set (and from where), the system keychain is usable, the domain resolves
+and answers, and the credentials are accepted by an authenticated call.
+Network checks are skipped once an earlier one fails. Exits non-zero if any
+check fails.`,
+		Flags: []cli.Flag{cmdutil.OutputFlag()},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			checks := runDoctor(ctx, f)
+
+			failed := 0
+			for _, c := range checks {
+				if c.Status == checkFail {
+					failed++
+				}
+			}
+
+			if cmdutil.IsStructured(cmd) {
+				if err := cmdutil.PrintStructured(cmd, map[string]any{
+					"ok":     failed == 0,
+					"checks": checks,
+				}); err != nil {
+					return err
+				}
+			} else {
+				for _, c := range checks {
+					fmt.Printf("%-4s  %-12s %s\n", strings.ToUpper(c.Status), c.Name, c.Message)
+					if c.Hint != "" {
+						fmt.Printf("      %-12s hint: %s\n", "", c.Hint)
+					}
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+			}
+			return nil
+		},
+	}
+}
+
+// runDoctor runs the checks in order. The environment collection is shared
+// with bug-report; on top of it come the credential, keychain, DNS, and
+// authentication checks.
+func runDoctor(ctx context.Context, f *cmdutil.Factory) []check {
+	info := bugreport.Collect(f, "")
+	cfg, cfgErr := f.Config()
+
+	checks := []check{configFileCheck(info, cfg, cfgErr)}
+	if cfgErr != nil {
+		return checks
+	}
+	if len(cfg.Presets) > 0 {
+		checks = append(checks, presetsCheck(cfg, info.ConfigPath))
+	}
+
+	creds := credentialsCheck(cfg, info.ConfigPath)
+	checks = append(checks, creds, keyringCheck(cfg))
+
+	if cfg.Domain == "" {
+		return append(checks, check{
+			Name:    "domain",
+			Status:  checkFail,
+			Message: "no domain configured",
+			Hint:    "run: tp login, or: tp config set domain https://your-instance.tpondemand.com",
+		})
+	}
+
+	dns := domainCheck(cfg.Domain)
+	checks = append(checks, dns)
+	if dns.Status == checkFail {
+		return checks
+	}
+
+	if info.APIStatus != "reachable" {
+		return append(checks, check{
+			Name:    "connection",
+			Status:  checkFail,
+			Message: fmt.Sprintf("%s did not answer", info.Domain),
+			Hint:    "check your network, VPN, or proxy settings, and that the domain is your Targetprocess instance",
+		})
+	}
+	checks = append(checks, check{Name: "connection", Status: checkPass, Message: fmt.Sprintf("%s is reachable", info.Domain)})
+
+	if creds.Status == checkFail {
+		return checks
+	}
+	return append(checks, authCheck(ctx, f))
+}
+
+func configFileCheck(info bugreport.Info, cfg *internalconfig.Config, cfgErr error) check {
+	c := check{Name: "config file"}
+	if info.ConfigFound {
+		if _, err := os.ReadFile(info.ConfigPath); err != nil {
+			c.Status = checkFail
+			c.Message = fmt.Sprintf("%s is not readable: %v", info.ConfigPath, err)
+			c.Hint = "fix its permissions, e.g.: chmod 600 " + info.ConfigPath
+			return c
+		}
+	}
+	if cfgErr != nil {
+		c.Status = checkFail
+		c.Message = cfgErr.Error()
+		c.Hint = "fix the YAML in " + info.ConfigPath + ", or move it aside and run: tp login"
+		return c
+	}
+	switch {
+	case info.ConfigFound:
+		c.Status = checkPass
+		c.Message = info.ConfigPath
+	case cfg.Domain != "":
+		c.Status = checkPass
+		c.Message = fmt.Sprintf("no file at %s; using environment variables", info.ConfigPath)
+	default:
+		c.Status = checkWarn
+		c.Message = fmt.Sprintf("no file at %s", info.ConfigPath)
+		c.Hint = "run: tp login, or set TP_DOMAIN and TP_TOKEN"
+	}
+	return c
+}
+
+func presetsCheck(cfg *internalconfig.Config, path string) check {
+	c := check{Name: "presets"}
+	replaced, err := search.CheckUserPresets(cfg.Presets)
+	switch {
+	case err != nil:
+		c.Status = checkFail
+		c.Message = err.Error()
+		c.Hint = "fix the presets section in " + path + "; only tp search and tp query --where-preset need it"
+	case len(replaced) > 0:
+		c.Status = checkWarn
+		c.Message = fmt.Sprintf("%d from the config file; replaces the built-in %s", len(cfg.Presets), strings.Join(replaced, ", "))
+		c.Hint = "rename them in " + path + " to keep the built-in presets"
+	default:
+		c.Status = checkPass
+		c.Message = fmt.Sprintf("%d from the config file", len(cfg.Presets))
+	}
+	return c
+}
+
+func credentialsCheck(cfg *internalconfig.Config, path string) check {
+	switch cfg.AuthMode {
+	case "", internalconfig.AuthModeTokenQuery, internalconfig.AuthModeBearer:
+	case internalconfig.AuthModeBasic:
+		c := check{Name: "credentials", Status: checkPass, Message: "basic auth as " + cfg.Username}
+		switch {
+		case cfg.Username == "" || cfg.Password == "":
+			c.Status = checkFail
+			c.Message = "auth_mode basic needs both a username and a password"
+			c.Hint = "run: tp config set username <login> and tp config set password <password>"
+		case cfg.PasswordSource == internalconfig.TokenSourceFile && internalconfig.KeyringAvailable():
+			c.Status = checkWarn
+			c.Message += "; the password is stored in plain text in " + path
+			c.Hint = "run: tp config set password <password> to move it to the system keychain"
+		}
+		return c
+	default:
+		return check{
+			Name:    "credentials",
+			Status:  checkFail,
+			Message: fmt.Sprintf("unknown auth_mode %q", cfg.AuthMode),
+			Hint:    fmt.Sprintf("run: tp config set auth_mode %s (or %s, %s)", internalconfig.AuthModeTokenQuery, internalconfig.AuthModeBearer, internalconfig.AuthModeBasic),
+		}
+	}
+
+	c := check{Name: "token"}
+	switch {
+	case cfg.Token == "":
+		c.Status = checkFail
+		c.Message = "no token configured"
+		c.Hint = "run: tp login, or: tp config set token <token>"
+	case cfg.TokenSource == internalconfig.TokenSourceFile && internalconfig.KeyringAvailable():
+		c.Status = checkWarn
+		c.Message = "stored in plain text in " + path
+		c.Hint = "run: tp config set token <token> to move it to the system keychain"
+	default:
+		c.Status = checkPass
+		c.Message = fmt.Sprintf("set (source: %s)", cfg.TokenSource)
+	}
+	return c
+}
+
+func keyringCheck(cfg *internalconfig.Config) check {
+	c := check{Name: "keychain"}
+	if internalconfig.KeyringAvailable() {
+		c.Status = checkPass
+		c.Message = "system keychain is available"
+		return c
+	}
+	c.Message = "system keychain is unavailable"
+	if cfg.TokenSource == internalconfig.TokenSourceEnv {
+		// The token comes from TP_TOKEN, so the keychain is not needed.
+		c.Status = checkPass
+		c.Message += "; the token comes from TP_TOKEN"
+		return c
+	}
+	c.Status = checkWarn
+	c.Hint = "tokens and passwords are saved in plain text in the config file; set TP_TOKEN instead, or unlock or install a keychain (e.g. gnome-keyring)"
+	return c
+}
+
+func domainCheck(domain string) check {
+	c := check{Name: "domain"}
+	raw := domain
+	if !strings.HasPrefix(raw, "http") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Hostname() == "" {
+		c.Status = checkFail
+		c.Message = fmt.Sprintf("%q is not a valid URL", domain)
+		c.Hint = "run: tp config set domain https://your-instance.tpondemand.com"
+		return c
+	}
+	if _, err := net.LookupHost(u.Hostname()); err != nil {
+		c.Status = checkFail
+		c.Message = fmt.Sprintf("%s does not resolve", u.Hostname())
+		c.Hint = "check the spelling with: tp config get domain, and your DNS or VPN"
+		return c
+	}
+	c.Status = checkPass
+	c.Message = fmt.Sprintf("%s resolves", u.Hostname())
+	return c
+}
+
+func authCheck(ctx context.Context, f *cmdutil.Factory) check {
+	c := check{Name: "auth"}
+	client, err := f.Client()
+	if err == nil {
+		var user api.User
+		user, err = client.CurrentUser(ctx)
+		if err == nil {
+			c.Status = checkPass
+			c.Message = fmt.Sprintf("authenticated as %s", user.Login)
+			return c
+		}
+	}
+	c.Status = checkFail
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden) {
+		c.Message = fmt.Sprintf("the credentials were rejected (HTTP %d)", apiErr.StatusCode)
+		c.Hint = "create a new access token in Targetprocess and run: tp login"
+		return c
+	}
+	c.Message = err.Error()
+	c.Hint = "rerun with --verbose to see the request"
+	return c
+}
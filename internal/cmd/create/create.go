@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/lifedraft/targetprocess-cli/internal/api"
 	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
 	"github.com/lifedraft/targetprocess-cli/internal/output"
 	"github.com/lifedraft/targetprocess-cli/internal/resolve"
@@ -23,35 +25,78 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
 		UsageText: `# Create a new user story
   tp create UserStory "Implement login page" --project-id 42
 
+  # Create in the default project (see: tp config set-default-project)
+  tp create Bug "Fix typo on landing page"
+
   # Create a bug with description and team
   tp create Bug "Fix crash on startup" --project-id 42 --description "App crashes when..."
 
   # Create a task assigned to a user
-  tp create Task "Write unit tests" --project-id 42 --assigned-user-id 15`,
+  tp create Task "Write unit tests" --project-id 42 --assigned-user-id 15
+
+  # Create a task under user story 1234 (the parent type is checked)
+  tp create Task "Write unit tests" --parent 1234
+
+  # Set custom fields; numbers and true/false are typed, quote to keep text
+  tp create Bug "Fix crash on startup" --custom "Severity Score=8" --custom 'Ticket="00123"'
+
+  # Show the request without creating anything
+  tp create Bug "Fix typo on landing page" --dry-run
+
+  # One story per CSV row (header: Name,Description,Effort,Team.Id,...)
+  tp create --from-csv stories.csv --type UserStory --project-id 42
+
+  # Preview the requests the CSV would send
+  tp create --from-csv stories.csv --type UserStory --dry-run`,
+		Description: `With --from-csv, each row of the file becomes one entity of --type. The
+header names the fields (Name is required); a column like Team.Id sets a
+reference, and numeric cells other than Name and Description are sent as
+numbers. The whole file is checked before anything is created. Rows that fail
+are listed in the summary and do not stop the rest.`,
 		Flags: []cli.Flag{
 			cmdutil.OutputFlag(),
-			&cli.IntFlag{Name: "project-id", Required: true, Usage: "Project ID"},
+			&cli.IntFlag{Name: "project-id", Usage: "Project ID (defaults to default_project_id from config)"},
 			&cli.StringFlag{Name: "description", Usage: "Entity description"},
 			&cli.IntFlag{Name: "team-id", Usage: "Team ID"},
 			&cli.IntFlag{Name: "assigned-user-id", Usage: "Assigned user ID"},
+			&cli.IntFlag{Name: "parent", Usage: "Parent entity ID (Epic for a Feature, Feature for a UserStory, UserStory for a Task or Bug)"},
+			cmdutil.CustomFlag(),
+			&cli.BoolFlag{Name: "strict", Usage: "Fail instead of warning when the type's metadata lists required fields that are not set"},
+			&cli.BoolFlag{Name: "dry-run", Usage: "Print the request that would be sent (method, path, JSON body) without sending it"},
+			&cli.StringFlag{Name: "from-csv", Usage: "Create one entity per row of this CSV file (header row names the fields)"},
+			&cli.StringFlag{Name: "type", Usage: "Entity type for --from-csv"},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.IsSet("from-csv") {
+				return createFromCSV(ctx, f, cmd)
+			}
+			if cmd.IsSet("type") {
+				return errors.New("--type is only used with --from-csv; give the type as the first argument")
+			}
+
 			args := cmd.Args().Slice()
 			if len(args) < 2 {
 				return errors.New("entity type and name are required; usage: tp create <type> <name>")
 			}
 
 			entityType := resolve.EntityType(args[0])
+			if err := resolve.CheckEntityType(entityType); err != nil {
+				return err
+			}
 			name := args[1]
+			custom, err := cmdutil.CustomFields(cmd)
+			if err != nil {
+				return err
+			}
 
 			client, err := f.Client()
 			if err != nil {
 				return err
 			}
 
-			projectID := cmd.Int("project-id")
-			if projectID <= 0 {
-				return fmt.Errorf("project ID must be positive, got %d", projectID)
+			projectID, err := resolveProjectID(f, cmd)
+			if err != nil {
+				return err
 			}
 
 			fields := map[string]any{
@@ -68,18 +113,52 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
 			if userID := cmd.Int("assigned-user-id"); userID > 0 {
 				fields["AssignedUser"] = map[string]any{"Id": userID}
 			}
+			if custom != nil {
+				fields["CustomFields"] = custom
+			}
+
+			if cmd.IsSet("parent") {
+				parentID := cmd.Int("parent")
+				if parentID <= 0 {
+					return fmt.Errorf("parent ID must be positive, got %d", parentID)
+				}
+				var parentType, field string
+				parentType, err = client.ResolveEntityType(ctx, parentID)
+				if err != nil {
+					return err
+				}
+				field, err = parentField(entityType, parentType)
+				if err != nil {
+					return err
+				}
+				fields[field] = map[string]any{"Id": parentID}
+			}
 
-			if prepErr := text.PrepareFields(ctx, client, fields); prepErr != nil {
+			if missing := missingRequired(ctx, client, entityType, fields); len(missing) > 0 {
+				if cmd.Bool("strict") {
+					return fmt.Errorf("--strict: %s", requiredMessage(entityType, missing))
+				}
+				fmt.Fprintf(f.Warnings(), "Warning: %s\n", requiredMessage(entityType, missing))
+			}
+
+			if prepErr := text.PrepareFields(ctx, client, fields, text.PrepareOptions{Warn: f.Warnings()}); prepErr != nil {
 				return prepErr
 			}
 
+			if cmd.Bool("dry-run") {
+				if err := api.ValidateEntityType(entityType); err != nil {
+					return err
+				}
+				return cmdutil.PrintDryRun(os.Stdout, cmd, http.MethodPost, api.EntityPath(entityType, 0), fields)
+			}
+
 			entity, err := client.CreateEntity(ctx, entityType, fields)
 			if err != nil {
 				return err
 			}
 
-			if cmdutil.IsJSON(cmd) {
-				return output.PrintJSON(os.Stdout, entity)
+			if cmdutil.IsStructured(cmd) {
+				return cmdutil.PrintStructured(cmd, entity)
 			}
 
 			output.PrintEntity(os.Stdout, entity)
@@ -87,3 +166,24 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
 		},
 	}
 }
+
+// resolveProjectID returns the --project-id flag value, falling back to the
+// configured default project when the flag is omitted.
+func resolveProjectID(f *cmdutil.Factory, cmd *cli.Command) (int, error) {
+	if cmd.IsSet("project-id") {
+		projectID := cmd.Int("project-id")
+		if projectID <= 0 {
+			return 0, fmt.Errorf("project ID must be positive, got %d", projectID)
+		}
+		return projectID, nil
+	}
+
+	cfg, err := f.Config()
+	if err != nil {
+		return 0, err
+	}
+	if cfg.DefaultProjectID > 0 {
+		return cfg.DefaultProjectID, nil
+	}
+	return 0, errors.New("project is required; pass --project-id or set a default with: tp config set-default-project <id>")
+}
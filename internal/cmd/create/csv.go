@@ -0,0 +1,272 @@
+package create
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/batch"
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+	"github.com/lifedraft/targetprocess-cli/internal/resolve"
+	"github.com/lifedraft/targetprocess-cli/internal/text"
+)
+
+// csvTextFields are always sent as strings, even when a cell looks numeric.
+var csvTextFields = map[string]bool{"Name": true, "Description": true}
+
+// csvRow is one data row of a --from-csv file, already mapped to fields.
+type csvRow struct {
+	Row    int
+	Fields map[string]any
+}
+
+// csvResult reports what happened to one row.
+type csvResult struct {
+	Row   int
+	ID    int
+	Name  string
+	Error string
+}
+
+// readCSVRows parses a CSV whose header names entity fields. A header like
+// Team.Id becomes a reference ({"Team": {"Id": n}}); other numeric cells are
+// sent as numbers, except Name and Description. Empty cells are left out.
+// Every row is checked before any is returned, so a bad file creates nothing.
+func readCSVRows(r io.Reader) ([]csvRow, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("CSV is empty; the first row must be a header with at least Name")
+	}
+
+	header, err := csvHeader(records[0])
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]csvRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		row := i + 1
+		fields := map[string]any{}
+		for col, value := range record {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			name := header[col]
+			if ref, ok := strings.CutSuffix(name, ".Id"); ok {
+				id, err := strconv.Atoi(value)
+				if err != nil || id <= 0 {
+					return nil, fmt.Errorf("row %d: %s must be a positive ID, got %q", row, name, value)
+				}
+				fields[ref] = map[string]any{"Id": id}
+				continue
+			}
+			if !csvTextFields[name] {
+				if n, err := strconv.ParseFloat(value, 64); err == nil {
+					fields[name] = n
+					continue
+				}
+			}
+			fields[name] = value
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		if _, ok := fields["Name"]; !ok {
+			return nil, fmt.Errorf("row %d: Name is empty", row)
+		}
+		rows = append(rows, csvRow{Row: row, Fields: fields})
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("CSV has a header but no rows")
+	}
+	return rows, nil
+}
+
+// csvHeader validates the header row. v1 field names are PascalCase, so the
+// first letter is upper-cased and an .id suffix becomes .Id.
+func csvHeader(record []string) ([]string, error) {
+	header := make([]string, len(record))
+	seen := map[string]bool{}
+	for i, h := range record {
+		h = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+		if h != "" {
+			h = strings.ToUpper(h[:1]) + h[1:]
+		}
+		if len(h) > 3 && strings.EqualFold(h[len(h)-3:], ".id") {
+			h = h[:len(h)-3] + ".Id"
+		}
+		if h == "" {
+			return nil, fmt.Errorf("CSV header column %d is empty", i+1)
+		}
+		if seen[h] {
+			return nil, fmt.Errorf("CSV header has %s twice", h)
+		}
+		seen[h] = true
+		header[i] = h
+	}
+	if !seen["Name"] {
+		return nil, fmt.Errorf("CSV header must include a Name column, got: %s", strings.Join(header, ", "))
+	}
+	return header, nil
+}
+
+// createFromCSV creates one entity per row of the --from-csv file. Failed
+// rows are reported and do not stop the rest.
+func createFromCSV(ctx context.Context, f *cmdutil.Factory, cmd *cli.Command) error {
+	if cmd.Args().Len() > 0 {
+		return errors.New("--from-csv takes names from the file; pass the type with --type instead of arguments")
+	}
+	for _, flag := range []string{"description", "team-id", "assigned-user-id", "parent"} {
+		if cmd.IsSet(flag) {
+			return fmt.Errorf("--%s cannot be combined with --from-csv; add a column to the CSV instead", flag)
+		}
+	}
+	if cmd.IsSet("custom") {
+		return errors.New("--custom cannot be combined with --from-csv")
+	}
+	entityType := resolve.EntityType(cmd.String("type"))
+	if entityType == "" {
+		return errors.New("--from-csv requires --type")
+	}
+	if err := api.ValidateEntityType(entityType); err != nil {
+		return err
+	}
+	if err := resolve.CheckEntityType(entityType); err != nil {
+		return err
+	}
+
+	file, err := os.Open(cmd.String("from-csv"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	rows, err := readCSVRows(file)
+	if err != nil {
+		return err
+	}
+
+	client, err := f.Client()
+	if err != nil {
+		return err
+	}
+
+	// Required-field check on every column any row sets.
+	allFields := map[string]any{}
+	needProject := false
+	for _, r := range rows {
+		for k, v := range r.Fields {
+			allFields[k] = v
+		}
+		if _, ok := r.Fields["Project"]; !ok {
+			needProject = true
+		}
+	}
+	var projectID int
+	if needProject {
+		if projectID, err = resolveProjectID(f, cmd); err != nil {
+			return err
+		}
+		allFields["Project"] = map[string]any{"Id": projectID}
+	}
+	if missing := missingRequired(ctx, client, entityType, allFields); len(missing) > 0 {
+		if cmd.Bool("strict") {
+			return fmt.Errorf("--strict: %s", requiredMessage(entityType, missing))
+		}
+		fmt.Fprintf(f.Warnings(), "Warning: %s\n", requiredMessage(entityType, missing))
+	}
+
+	path := api.EntityPath(entityType, 0)
+	var bodies []map[string]any
+	var results []csvResult
+	progress := f.Progress()
+	for _, r := range rows {
+		fields := r.Fields
+		if _, ok := fields["Project"]; !ok {
+			fields["Project"] = map[string]any{"Id": projectID}
+		}
+		name, _ := fields["Name"].(string)
+		res := csvResult{Row: r.Row, Name: name}
+
+		prepErr := text.PrepareFields(ctx, client, fields, text.PrepareOptions{Warn: f.Warnings()})
+		if cmd.Bool("dry-run") {
+			if prepErr != nil {
+				return fmt.Errorf("row %d: %w", r.Row, prepErr)
+			}
+			bodies = append(bodies, fields)
+			continue
+		}
+		if prepErr != nil {
+			res.Error = prepErr.Error()
+			results = append(results, res)
+			continue
+		}
+
+		entity, err := client.CreateEntity(ctx, entityType, fields)
+		if err != nil {
+			res.Error = err.Error()
+		} else if id, ok := entity["Id"].(float64); ok {
+			res.ID = int(id)
+		}
+		results = append(results, res)
+		progress.Update("created %d of %d rows…", len(results), len(rows))
+	}
+	progress.Done()
+
+	if cmd.Bool("dry-run") {
+		return printCSVDryRun(cmd, path, bodies)
+	}
+	return printCSVResults(cmd, entityType, results)
+}
+
+// printCSVDryRun prints the request each row would send.
+func printCSVDryRun(cmd *cli.Command, path string, bodies []map[string]any) error {
+	if cmdutil.IsStructured(cmd) {
+		return cmdutil.PrintStructured(cmd, map[string]any{
+			"dryRun": true,
+			"method": http.MethodPost,
+			"path":   path,
+			"bodies": bodies,
+			"count":  len(bodies),
+		})
+	}
+	for _, body := range bodies {
+		if err := cmdutil.PrintDryRun(os.Stdout, cmd, http.MethodPost, path, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printCSVResults reports each row and returns an error if any failed.
+func printCSVResults(cmd *cli.Command, entityType string, results []csvResult) error {
+	rep := batch.NewReporter(os.Stdout, cmd.String("output"))
+	for _, r := range results {
+		res := batch.Result{ID: r.ID, Row: r.Row, Status: batch.StatusOK, Message: r.Name}
+		if r.Error != "" {
+			res.Status = batch.StatusError
+			res.Message = fmt.Sprintf("%s: %s", r.Name, r.Error)
+		} else {
+			res.Message = fmt.Sprintf("#%d %s", r.ID, r.Name)
+		}
+		rep.Add(res)
+	}
+	if err := rep.Flush(fmt.Sprintf("Created %d of %d %s entities.", rep.Succeeded(), len(results), entityType)); err != nil {
+		return err
+	}
+	return rep.Err("rows")
+}
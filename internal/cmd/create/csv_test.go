@@ -0,0 +1,52 @@
+package create
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadCSVRows(t *testing.T) {
+	in := "\ufeffname,description,Effort,team.id,Tags\n" +
+		"Login page,\"Build it, then test\",3,12,\n" +
+		",,,,\n" +
+		"2024,,,,ux\n"
+
+	rows, err := readCSVRows(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("readCSVRows() error = %v", err)
+	}
+	want := []csvRow{
+		{Row: 1, Fields: map[string]any{
+			"Name":        "Login page",
+			"Description": "Build it, then test",
+			"Effort":      float64(3),
+			"Team":        map[string]any{"Id": 12},
+		}},
+		{Row: 3, Fields: map[string]any{"Name": "2024", "Tags": "ux"}},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("readCSVRows() =\n%#v\nwant\n%#v", rows, want)
+	}
+}
+
+func TestReadCSVRows_Errors(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"no name column", "Title,Effort\nx,1\n", "must include a Name column"},
+		{"empty name", "Name,Effort\n,1\n", "row 1: Name is empty"},
+		{"bad reference", "Name,Team.Id\nx,core\n", "Team.Id must be a positive ID"},
+		{"duplicate column", "Name,name\nx,y\n", "Name twice"},
+		{"header only", "Name\n", "no rows"},
+		{"empty", "", "CSV is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readCSVRows(strings.NewReader(tt.in))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
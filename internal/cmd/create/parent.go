@@ -0,0 +1,41 @@
+package create
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// parentTypes lists, per entity type, the entity types that may be its
+// parent. In the v1 API the parent reference field is named after the
+// parent type (e.g. a Task's "UserStory").
+var parentTypes = map[string][]string{
+	"Feature":   {"Epic"},
+	"UserStory": {"Feature"},
+	"Task":      {"UserStory"},
+	"Bug":       {"UserStory", "Feature"},
+}
+
+// parentField returns the reference field that links an entityType to a
+// parent of parentType, or an error if the combination is not supported.
+func parentField(entityType, parentType string) (string, error) {
+	allowed, ok := parentTypes[entityType]
+	if !ok {
+		return "", fmt.Errorf("--parent is not supported for %s; supported types: %s", entityType, strings.Join(childTypeNames(), ", "))
+	}
+	for _, t := range allowed {
+		if t == parentType {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("a %s cannot be the parent of a %s; expected %s", parentType, entityType, strings.Join(allowed, " or "))
+}
+
+func childTypeNames() []string {
+	names := make([]string, 0, len(parentTypes))
+	for name := range parentTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,33 @@
+package create
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParentField(t *testing.T) {
+	tests := []struct {
+		entityType, parentType string
+		want                   string
+		wantErr                string
+	}{
+		{"UserStory", "Feature", "Feature", ""},
+		{"Task", "UserStory", "UserStory", ""},
+		{"Feature", "Epic", "Epic", ""},
+		{"Bug", "Feature", "Feature", ""},
+		{"Task", "Feature", "", "cannot be the parent of a Task"},
+		{"Project", "Epic", "", "not supported for Project"},
+	}
+	for _, tt := range tests {
+		got, err := parentField(tt.entityType, tt.parentType)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parentField(%s, %s) error = %v, want containing %q", tt.entityType, tt.parentType, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parentField(%s, %s) = %q, %v; want %q", tt.entityType, tt.parentType, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package create
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+// serverDefaulted are required fields Targetprocess fills in itself when a
+// create omits them (initial state, default priority, the current user).
+var serverDefaulted = map[string]bool{
+	"EntityState": true,
+	"Priority":    true,
+	"Owner":       true,
+	"LastEditor":  true,
+	"EntityType":  true,
+}
+
+// typeFields lists the settable value and reference fields from a type's
+// metadata XML.
+type typeFields struct {
+	Values     []metaField `xml:"ResourceMetadataPropertiesDescription>ResourceMetadataPropertiesResourceValuesDescription>ResourceFieldMetadataDescription"`
+	References []metaField `xml:"ResourceMetadataPropertiesDescription>ResourceMetadataPropertiesResourceReferencesDescription>ResourceFieldMetadataDescription"`
+}
+
+type metaField struct {
+	Name       string `xml:"Name,attr"`
+	CanSet     string `xml:"CanSet,attr"`
+	IsRequired string `xml:"IsRequired,attr"`
+}
+
+// missingRequired returns, sorted, the settable required fields of
+// entityType that fields does not provide and the server does not default.
+// If metadata is unavailable it returns nothing: the check is advisory.
+func missingRequired(ctx context.Context, client *api.Client, entityType string, fields map[string]any) []string {
+	data, err := client.GetTypeMeta(ctx, entityType)
+	if err != nil {
+		return nil
+	}
+	return requiredMissing(data, fields)
+}
+
+func requiredMissing(meta []byte, fields map[string]any) []string {
+	var tf typeFields
+	if err := xml.Unmarshal(meta, &tf); err != nil {
+		return nil
+	}
+	var missing []string
+	for _, f := range append(tf.Values, tf.References...) {
+		if f.IsRequired != "true" || f.CanSet != "true" || serverDefaulted[f.Name] {
+			continue
+		}
+		if _, ok := fields[f.Name]; !ok {
+			missing = append(missing, f.Name)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// requiredMessage describes missing required fields for a warning or error.
+func requiredMessage(entityType string, missing []string) string {
+	return fmt.Sprintf("%s requires %s, which %s not set; the server may reject the create",
+		entityType, strings.Join(missing, ", "), pluralVerb(len(missing)))
+}
+
+func pluralVerb(n int) string {
+	if n == 1 {
+		return "is"
+	}
+	return "are"
+}
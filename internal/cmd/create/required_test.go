@@ -0,0 +1,33 @@
+package create
+
+import (
+	"strings"
+	"testing"
+)
+
+const bugMeta = `<ResourceMetadataDescription Name="Bug">
+  <ResourceMetadataPropertiesDescription>
+    <ResourceMetadataPropertiesResourceValuesDescription>
+      <ResourceFieldMetadataDescription Name="Id" CanSet="true" IsRequired="false" />
+      <ResourceFieldMetadataDescription Name="Name" CanSet="true" IsRequired="true" />
+      <ResourceFieldMetadataDescription Name="Severity" CanSet="true" IsRequired="true" />
+      <ResourceFieldMetadataDescription Name="CreateDate" CanSet="false" IsRequired="true" />
+    </ResourceMetadataPropertiesResourceValuesDescription>
+    <ResourceMetadataPropertiesResourceReferencesDescription>
+      <ResourceFieldMetadataDescription Name="Project" CanSet="true" IsRequired="true" />
+      <ResourceFieldMetadataDescription Name="EntityState" CanSet="true" IsRequired="true" />
+      <ResourceFieldMetadataDescription Name="Build" CanSet="true" IsRequired="true" />
+    </ResourceMetadataPropertiesResourceReferencesDescription>
+  </ResourceMetadataPropertiesDescription>
+</ResourceMetadataDescription>`
+
+func TestRequiredMissing(t *testing.T) {
+	fields := map[string]any{"Name": "Crash", "Project": map[string]any{"Id": 1}}
+	got := requiredMissing([]byte(bugMeta), fields)
+	if strings.Join(got, ",") != "Build,Severity" {
+		t.Errorf("requiredMissing() = %v, want [Build Severity]", got)
+	}
+	if got := requiredMissing([]byte("not xml"), fields); got != nil {
+		t.Errorf("requiredMissing(bad XML) = %v, want nil", got)
+	}
+}
@@ -1,11 +1,13 @@
 package inspect
 
 import (
+	"bytes"
 	"context"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -13,6 +15,8 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/projects"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/states"
 	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
 	"github.com/lifedraft/targetprocess-cli/internal/output"
 )
@@ -60,6 +64,115 @@ func (tp *typeProperties) allFields() []fieldMeta {
 	return all
 }
 
+// fieldKinds lists the --kind values, in the order allFields combines them.
+var fieldKinds = []string{"values", "references", "collections"}
+
+// fieldFilter narrows the properties listing. Zero value keeps every field.
+type fieldFilter struct {
+	Kind     string
+	Settable bool
+	Gettable bool
+	Required bool
+
+	// Match keeps fields whose name contains it, ignoring case. With
+	// InDescription, a match in the description counts too.
+	Match         string
+	InDescription bool
+}
+
+func (ff fieldFilter) matches(f fieldMeta) bool {
+	if ff.Match == "" {
+		return true
+	}
+	needle := strings.ToLower(ff.Match)
+	if strings.Contains(strings.ToLower(f.Name), needle) {
+		return true
+	}
+	return ff.InDescription && strings.Contains(strings.ToLower(f.Description), needle)
+}
+
+// fields returns the field metadata matching ff. An empty or unknown Kind
+// matches every kind; callers validate it against fieldKinds.
+func (tp *typeProperties) fields(ff fieldFilter) []fieldMeta {
+	var candidates []fieldMeta
+	switch ff.Kind {
+	case "values":
+		candidates = tp.Values
+	case "references":
+		candidates = tp.References
+	case "collections":
+		candidates = tp.Collections
+	default:
+		candidates = tp.allFields()
+	}
+
+	out := make([]fieldMeta, 0, len(candidates))
+	for _, f := range candidates {
+		if ff.Settable && f.CanSet != "true" {
+			continue
+		}
+		if ff.Gettable && f.CanGet != "true" {
+			continue
+		}
+		if ff.Required && f.IsRequired != "true" {
+			continue
+		}
+		if !ff.matches(f) {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// isXML reports whether -o xml asked for the metadata as the API sent it.
+func isXML(cmd *cli.Command) bool {
+	return cmd.String("output") == "xml"
+}
+
+// printRawXML writes metadata bytes to stdout unchanged, ending the output
+// with a newline.
+func printRawXML(data []byte) error {
+	if _, err := os.Stdout.Write(data); err != nil {
+		return err
+	}
+	if !bytes.HasSuffix(data, []byte("\n")) {
+		_, err := fmt.Fprintln(os.Stdout)
+		return err
+	}
+	return nil
+}
+
+// rawFieldElement returns the verbatim XML element describing the field
+// named name in type metadata, with every attribute the parsed view drops.
+func rawFieldElement(data []byte, name string) ([]byte, bool) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		start := dec.InputOffset()
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, false
+		}
+		el, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch el.Name.Local {
+		case "ResourceFieldMetadataDescription", "ResourceCollecitonFieldMetadataDescription":
+		default:
+			continue
+		}
+		for _, attr := range el.Attr {
+			if attr.Name.Local == "Name" && attr.Value == name {
+				if err := dec.Skip(); err != nil {
+					return nil, false
+				}
+				return data[start:dec.InputOffset()], true
+			}
+		}
+	}
+}
+
 func NewCmd(f *cmdutil.Factory) *cli.Command {
 	return &cli.Command{
 		Name:  "inspect",
@@ -69,6 +182,10 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
 			newPropertiesCmd(f),
 			newDetailsCmd(f),
 			newDiscoverCmd(f),
+			states.NewInspectCmd(f),
+			newReferenceListCmd(f, priorityList),
+			newReferenceListCmd(f, severityList),
+			newWhoamiProjectsCmd(f),
 		},
 	}
 }
@@ -77,7 +194,7 @@ func newTypesCmd(f *cmdutil.Factory) *cli.Command {
 	return &cli.Command{
 		Name:  "types",
 		Usage: "List all available entity types",
-		Flags: []cli.Flag{cmdutil.OutputFlag()},
+		Flags: []cli.Flag{cmdutil.OutputFlag("xml")},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			client, err := f.Client()
 			if err != nil {
@@ -88,13 +205,16 @@ func newTypesCmd(f *cmdutil.Factory) *cli.Command {
 			if err != nil {
 				return fmt.Errorf("fetching metadata: %w", err)
 			}
+			if isXML(cmd) {
+				return printRawXML(data)
+			}
 
 			var index metaIndex
 			if err := xml.Unmarshal(data, &index); err != nil {
 				return fmt.Errorf("parsing metadata XML: %w", err)
 			}
 
-			if cmdutil.IsJSON(cmd) {
+			if cmdutil.IsStructured(cmd) {
 				types := make([]map[string]string, len(index.Types))
 				for i, t := range index.Types {
 					types[i] = map[string]string{
@@ -102,7 +222,7 @@ func newTypesCmd(f *cmdutil.Factory) *cli.Command {
 						"description": t.Description,
 					}
 				}
-				return output.PrintJSON(os.Stdout, map[string]any{"types": types})
+				return cmdutil.PrintStructured(cmd, map[string]any{"types": types})
 			}
 
 			names := make([]string, len(index.Types))
@@ -121,10 +241,48 @@ func newPropertiesCmd(f *cmdutil.Factory) *cli.Command {
 		Name:  "properties",
 		Usage: "List properties of an entity type",
 		Flags: []cli.Flag{
-			cmdutil.OutputFlag(),
+			cmdutil.OutputFlag("xml"),
 			&cli.StringFlag{Name: "type", Required: true, Usage: "Entity type (e.g. UserStory)"},
+			&cli.BoolFlag{Name: "settable", Usage: "Only fields that can be set on create/update"},
+			&cli.BoolFlag{Name: "gettable", Usage: "Only fields that can be read"},
+			&cli.BoolFlag{Name: "required", Usage: "Only required fields"},
+			&cli.StringFlag{Name: "kind", Usage: "Only one kind of field: values, references, or collections"},
+			&cli.StringFlag{Name: "filter", Usage: "Only fields whose name contains this text, ignoring case (also the first argument)"},
+			&cli.BoolFlag{Name: "in-description", Usage: "Let --filter match the field description too"},
 		},
+		ArgsUsage: "[filter]",
+		UsageText: `tp inspect properties --type UserStory
+tp inspect properties --type UserStory effort
+tp inspect properties --type Bug --filter custom --in-description
+tp inspect properties --type UserStory -o xml
+tp inspect properties --type Bug --settable --kind references
+tp inspect properties --type Task --required -o json`,
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			filter := fieldFilter{
+				Kind:     strings.ToLower(cmd.String("kind")),
+				Settable: cmd.Bool("settable"),
+				Gettable: cmd.Bool("gettable"),
+				Required: cmd.Bool("required"),
+
+				Match:         cmd.String("filter"),
+				InDescription: cmd.Bool("in-description"),
+			}
+			if cmd.Args().Len() > 1 {
+				return errors.New("expected at most one filter argument")
+			}
+			if arg := cmd.Args().First(); arg != "" {
+				if filter.Match != "" && filter.Match != arg {
+					return errors.New("give the filter either as an argument or with --filter, not both")
+				}
+				filter.Match = arg
+			}
+			if isXML(cmd) && filter != (fieldFilter{}) {
+				return errors.New("--output xml prints the metadata verbatim and cannot be combined with filters")
+			}
+			if filter.Kind != "" && !slices.Contains(fieldKinds, filter.Kind) {
+				return fmt.Errorf("invalid --kind %q: must be one of %s", cmd.String("kind"), strings.Join(fieldKinds, ", "))
+			}
+
 			client, err := f.Client()
 			if err != nil {
 				return err
@@ -135,15 +293,18 @@ func newPropertiesCmd(f *cmdutil.Factory) *cli.Command {
 			if err != nil {
 				return fmt.Errorf("fetching type metadata: %w", err)
 			}
+			if isXML(cmd) {
+				return printRawXML(data)
+			}
 
 			var meta typeMeta
 			if err := xml.Unmarshal(data, &meta); err != nil {
 				return fmt.Errorf("parsing type metadata XML: %w", err)
 			}
 
-			allFields := meta.Properties.allFields()
+			allFields := meta.Properties.fields(filter)
 
-			if cmdutil.IsJSON(cmd) {
+			if cmdutil.IsStructured(cmd) {
 				fields := make([]map[string]string, len(allFields))
 				for i, f := range allFields {
 					fields[i] = map[string]string{
@@ -155,7 +316,7 @@ func newPropertiesCmd(f *cmdutil.Factory) *cli.Command {
 						"description": f.Description,
 					}
 				}
-				return output.PrintJSON(os.Stdout, map[string]any{"properties": fields})
+				return cmdutil.PrintStructured(cmd, map[string]any{"properties": fields})
 			}
 
 			props := make([]map[string]string, len(allFields))
@@ -193,8 +354,8 @@ func newDiscoverCmd(f *cmdutil.Factory) *cli.Command {
 				}
 			}
 
-			if cmdutil.IsJSON(cmd) {
-				return output.PrintJSON(os.Stdout, map[string]any{
+			if cmdutil.IsStructured(cmd) {
+				return cmdutil.PrintStructured(cmd, map[string]any{
 					"entityTypes": types,
 					"source":      source,
 				})
@@ -269,9 +430,9 @@ func newDetailsCmd(f *cmdutil.Factory) *cli.Command {
 		Name:  "details",
 		Usage: "Get detailed info about an entity property",
 		Flags: []cli.Flag{
-			cmdutil.OutputFlag(),
+			cmdutil.OutputFlag("xml"),
 			&cli.StringFlag{Name: "type", Required: true, Usage: "Entity type (e.g. UserStory)"},
-			&cli.StringFlag{Name: "property", Required: true, Usage: "Property name (e.g. EntityState)"},
+			&cli.StringFlag{Name: "property", Required: true, Usage: "Property name, any case (e.g. EntityState or entitystate)"},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			client, err := f.Client()
@@ -293,24 +454,57 @@ func newDetailsCmd(f *cmdutil.Factory) *cli.Command {
 			}
 
 			allFields := meta.Properties.allFields()
-			for _, f := range allFields {
-				if f.Name == propName {
-					detail := map[string]any{
-						"Name":        f.Name,
-						"Type":        f.Type,
-						"CanSet":      f.CanSet,
-						"CanGet":      f.CanGet,
-						"IsRequired":  f.IsRequired,
-						"Description": f.Description,
-					}
-					if cmdutil.IsJSON(cmd) {
-						return output.PrintJSON(os.Stdout, detail)
-					}
-					output.PrintEntity(os.Stdout, detail)
-					return nil
+			field, ok := findField(allFields, propName)
+			if !ok {
+				msg := fmt.Sprintf("property %q not found on type %q", propName, entityType)
+				if names := suggestFields(allFields, propName); len(names) > 0 {
+					msg += "; did you mean " + strings.Join(names, ", ") + "?"
+				}
+				return errors.New(msg)
+			}
+
+			if isXML(cmd) {
+				raw, ok := rawFieldElement(data, field.Name)
+				if !ok {
+					return fmt.Errorf("property %q not found in the metadata XML of type %q", field.Name, entityType)
 				}
+				return printRawXML(raw)
+			}
+
+			detail := map[string]any{
+				"Name":        field.Name,
+				"Type":        field.Type,
+				"CanSet":      field.CanSet,
+				"CanGet":      field.CanGet,
+				"IsRequired":  field.IsRequired,
+				"Description": field.Description,
+			}
+			if cmdutil.IsStructured(cmd) {
+				return cmdutil.PrintStructured(cmd, detail)
+			}
+			output.PrintEntity(os.Stdout, detail)
+			return nil
+		},
+	}
+}
+
+func newWhoamiProjectsCmd(f *cmdutil.Factory) *cli.Command {
+	return &cli.Command{
+		Name:  "whoami-projects",
+		Usage: "List the projects (and their processes) the current token can access",
+		Description: `Projects are filtered by the token's permissions, so this shows exactly
+what queries can see. Start here when a query returns fewer results than expected.`,
+		Flags: []cli.Flag{cmdutil.OutputFlag()},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			client, err := f.Client()
+			if err != nil {
+				return err
+			}
+			list, err := client.ListProjects(ctx, false)
+			if err != nil {
+				return err
 			}
-			return fmt.Errorf("property %q not found on type %q", propName, entityType)
+			return projects.Print(cmd, list)
 		},
 	}
 }
@@ -0,0 +1,116 @@
+package inspect
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFieldsFilter(t *testing.T) {
+	props := typeProperties{
+		Values: []fieldMeta{
+			{Name: "Id", CanSet: "false", CanGet: "true"},
+			{Name: "Name", CanSet: "true", CanGet: "true", IsRequired: "true"},
+			{Name: "Password", CanSet: "true", CanGet: "false"},
+			{Name: "InitialEstimate", CanSet: "true", CanGet: "true", Description: "Effort estimated up front"},
+		},
+		References: []fieldMeta{
+			{Name: "Project", CanSet: "true", CanGet: "true", IsRequired: "true"},
+			{Name: "EntityType", CanSet: "false", CanGet: "true"},
+		},
+		Collections: []fieldMeta{
+			{Name: "Tasks", CanSet: "false", CanGet: "true"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		filter fieldFilter
+		want   []string
+	}{
+		{"none", fieldFilter{}, []string{"Id", "Name", "Password", "InitialEstimate", "Project", "EntityType", "Tasks"}},
+		{"settable", fieldFilter{Settable: true}, []string{"Name", "Password", "InitialEstimate", "Project"}},
+		{"gettable", fieldFilter{Gettable: true}, []string{"Id", "Name", "InitialEstimate", "Project", "EntityType", "Tasks"}},
+		{"required", fieldFilter{Required: true}, []string{"Name", "Project"}},
+		{"references", fieldFilter{Kind: "references"}, []string{"Project", "EntityType"}},
+		{"settable references", fieldFilter{Kind: "references", Settable: true}, []string{"Project"}},
+		{"collections", fieldFilter{Kind: "collections"}, []string{"Tasks"}},
+		{"settable and gettable", fieldFilter{Settable: true, Gettable: true}, []string{"Name", "InitialEstimate", "Project"}},
+		{"match name", fieldFilter{Match: "ENTITY"}, []string{"EntityType"}},
+		{"match skips description", fieldFilter{Match: "effort"}, []string{}},
+		{"match description", fieldFilter{Match: "effort", InDescription: true}, []string{"InitialEstimate"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := props.fields(tt.filter)
+			names := make([]string, len(got))
+			for i, f := range got {
+				names[i] = f.Name
+			}
+			if len(names) != len(tt.want) {
+				t.Fatalf("fields(%+v) = %v, want %v", tt.filter, names, tt.want)
+			}
+			for i := range names {
+				if names[i] != tt.want[i] {
+					t.Fatalf("fields(%+v) = %v, want %v", tt.filter, names, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRawFieldElement(t *testing.T) {
+	data := []byte(`<ResourceMetadataDescription Name="Bug">
+  <ResourceMetadataPropertiesDescription>
+    <ResourceMetadataPropertiesResourceValuesDescription>
+      <ResourceFieldMetadataDescription Name="Name" Type="String" IsRequired="true" IsSynthetic="false" />
+    </ResourceMetadataPropertiesResourceValuesDescription>
+    <ResourceMetadataPropertiesResourceCollectionsDescription>
+      <ResourceCollecitonFieldMetadataDescription Name="Tasks" Type="Task"><Extra /></ResourceCollecitonFieldMetadataDescription>
+    </ResourceMetadataPropertiesResourceCollectionsDescription>
+  </ResourceMetadataPropertiesDescription>
+</ResourceMetadataDescription>`)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Name", `<ResourceFieldMetadataDescription Name="Name" Type="String" IsRequired="true" IsSynthetic="false" />`},
+		{"Tasks", `<ResourceCollecitonFieldMetadataDescription Name="Tasks" Type="Task"><Extra /></ResourceCollecitonFieldMetadataDescription>`},
+	}
+	for _, tt := range tests {
+		got, ok := rawFieldElement(data, tt.name)
+		if !ok || strings.TrimSpace(string(got)) != tt.want {
+			t.Errorf("rawFieldElement(%q) = %q, %v; want %q", tt.name, got, ok, tt.want)
+		}
+	}
+	if _, ok := rawFieldElement(data, "Bug"); ok {
+		t.Error("rawFieldElement matched the type element, want only fields")
+	}
+}
+
+func TestFindFieldAndSuggest(t *testing.T) {
+	fields := []fieldMeta{{Name: "EntityState"}, {Name: "Effort"}, {Name: "EffortCompleted"}, {Name: "Owner"}, {Name: "Project"}}
+
+	if f, ok := findField(fields, "entitystate"); !ok || f.Name != "EntityState" {
+		t.Errorf("findField(entitystate) = %q, %v; want EntityState", f.Name, ok)
+	}
+	if _, ok := findField(fields, "State"); ok {
+		t.Error("findField(State) matched, want no partial matches")
+	}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"EntitySate", []string{"EntityState"}},
+		{"effort", []string{"Effort", "EffortCompleted"}},
+		{"projetc", []string{"Project"}},
+		{"Zzzzzzzz", []string{}},
+	}
+	for _, tt := range tests {
+		got := suggestFields(fields, tt.name)
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("suggestFields(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
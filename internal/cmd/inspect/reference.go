@@ -0,0 +1,124 @@
+package inspect
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+	"github.com/lifedraft/targetprocess-cli/internal/output"
+	"github.com/lifedraft/targetprocess-cli/internal/resolve"
+)
+
+// referenceList describes a small lookup entity whose IDs other fields take,
+// such as Priority for Priority.Id.
+type referenceList struct {
+	Name       string
+	Usage      string
+	EntityType string
+	// ByType is set when each entry belongs to one entity type, so the list
+	// can be narrowed with --type.
+	ByType  bool
+	Example string
+}
+
+var (
+	priorityList = referenceList{
+		Name:       "priorities",
+		Usage:      "List priorities (id, name, importance) for setting Priority",
+		EntityType: "Priority",
+		ByType:     true,
+		Example: `tp inspect priorities
+tp inspect priorities --type Bug
+tp inspect priorities -o json`,
+	}
+	severityList = referenceList{
+		Name:       "severities",
+		Usage:      "List bug severities (id, name, importance) for setting Severity",
+		EntityType: "Severity",
+		Example: `tp inspect severities
+tp inspect severities -o json`,
+	}
+)
+
+func newReferenceListCmd(f *cmdutil.Factory, rl referenceList) *cli.Command {
+	flags := []cli.Flag{cmdutil.OutputFlag()}
+	if rl.ByType {
+		flags = append(flags, &cli.StringFlag{Name: "type", Usage: "Only entries for this entity type (e.g. Bug)"})
+	}
+	return &cli.Command{
+		Name:      rl.Name,
+		Usage:     rl.Usage,
+		UsageText: rl.Example,
+		Flags:     flags,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			var where string
+			include := []string{"Name", "Importance"}
+			if rl.ByType {
+				include = append(include, "EntityType")
+				if t := cmd.String("type"); t != "" {
+					entityType := resolve.EntityType(t)
+					if err := api.ValidateEntityType(entityType); err != nil {
+						return err
+					}
+					where = fmt.Sprintf("EntityType.Name eq '%s'", entityType)
+				}
+			}
+
+			client, err := f.Client()
+			if err != nil {
+				return err
+			}
+			items, err := client.SearchEntities(ctx, rl.EntityType, where, include, 1000, []string{"Importance"})
+			if err != nil {
+				return err
+			}
+
+			if cmdutil.IsStructured(cmd) {
+				return cmdutil.PrintStructured(cmd, map[string]any{
+					"items": items,
+					"count": len(items),
+				})
+			}
+			return printReferenceList(rl, items)
+		},
+	}
+}
+
+func printReferenceList(rl referenceList, items []api.Entity) error {
+	if len(items) == 0 {
+		fmt.Fprintf(os.Stdout, "No %s found.\n", rl.Name)
+		return nil
+	}
+	tw := output.NewTabWriter(os.Stdout)
+	if rl.ByType {
+		fmt.Fprintln(tw, "ID\tNAME\tIMPORTANCE\tTYPE")
+	} else {
+		fmt.Fprintln(tw, "ID\tNAME\tIMPORTANCE")
+	}
+	for _, item := range items {
+		name, _ := item["Name"].(string)
+		line := fmt.Sprintf("%s\t%s\t%s", formatNumber(item["Id"]), name, formatNumber(item["Importance"]))
+		if rl.ByType {
+			entityType := ""
+			if et, ok := item["EntityType"].(map[string]any); ok {
+				entityType, _ = et["Name"].(string)
+			}
+			line += "\t" + entityType
+		}
+		fmt.Fprintln(tw, line)
+	}
+	return tw.Flush()
+}
+
+// formatNumber renders a JSON number without a decimal point when whole.
+func formatNumber(v any) string {
+	if n, ok := v.(float64); ok {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package inspect
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/lifedraft/targetprocess-cli/internal/resolve"
+)
+
+// maxSuggestions caps the "did you mean" list for an unknown property.
+const maxSuggestions = 3
+
+// findField returns the field named name, ignoring case. An exact-case
+// match wins if the metadata has names differing only in case.
+func findField(fields []fieldMeta, name string) (fieldMeta, bool) {
+	var folded *fieldMeta
+	for i, f := range fields {
+		if f.Name == name {
+			return f, true
+		}
+		if folded == nil && strings.EqualFold(f.Name, name) {
+			folded = &fields[i]
+		}
+	}
+	if folded != nil {
+		return *folded, true
+	}
+	return fieldMeta{}, false
+}
+
+// suggestFields returns up to maxSuggestions field names close to name:
+// those containing it, then those within a small edit distance, nearest
+// first.
+func suggestFields(fields []fieldMeta, name string) []string {
+	needle := strings.ToLower(name)
+	// Allow roughly one typo per three characters, and at least two.
+	limit := max(2, len(needle)/3)
+
+	type candidate struct {
+		name string
+		dist int
+	}
+	var candidates []candidate
+	for _, f := range fields {
+		lower := strings.ToLower(f.Name)
+		switch {
+		case strings.Contains(lower, needle) || strings.Contains(needle, lower):
+			candidates = append(candidates, candidate{f.Name, 0})
+		default:
+			if d := resolve.EditDistance(lower, needle); d <= limit {
+				candidates = append(candidates, candidate{f.Name, d})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].dist < candidates[j].dist })
+
+	names := make([]string, 0, maxSuggestions)
+	for _, c := range candidates {
+		if len(names) == maxSuggestions {
+			break
+		}
+		names = append(names, c.name)
+	}
+	return names
+}
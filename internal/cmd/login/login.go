@@ -0,0 +1,133 @@
+package login
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+	"github.com/lifedraft/targetprocess-cli/internal/config"
+)
+
+// NewCmd creates the "login" command.
+func NewCmd(f *cmdutil.Factory) *cli.Command {
+	return &cli.Command{
+		Name:  "login",
+		Usage: "Interactively set and verify the domain and access token",
+		Description: `Prompts for your Targetprocess domain and access token (input hidden),
+checks them against the API, and saves them: the domain to the config
+file, the token to the system keychain when available.
+
+When stdin is not a terminal, the domain and token are read as two lines.`,
+		UsageText: `# Prompt for domain and token
+  tp login
+
+  # Prompt only for the token
+  tp login --domain https://your-instance.tpondemand.com`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "domain", Usage: "Targetprocess domain (prompted if omitted)"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			cfg, err := config.Load(f.ConfigPath)
+			if err != nil {
+				return err
+			}
+
+			in := bufio.NewReader(os.Stdin)
+			domain := strings.TrimSpace(cmd.String("domain"))
+			if domain == "" {
+				domain, err = promptLine(in, os.Stderr, "Domain", cfg.Domain)
+				if err != nil {
+					return err
+				}
+			}
+			if domain == "" {
+				return errors.New("domain is required")
+			}
+
+			token, err := readToken(in)
+			if err != nil {
+				return err
+			}
+			if token == "" {
+				return errors.New("token is required")
+			}
+
+			client := api.NewClient(domain, token, f.Debug)
+			user, err := client.CurrentUser(ctx)
+			if err != nil {
+				return fmt.Errorf("login failed for %s: %w", client.BaseURL, err)
+			}
+
+			if err := config.Set(f.ConfigPath, "domain", client.BaseURL); err != nil {
+				return err
+			}
+			source, err := config.SetToken(f.ConfigPath, token)
+			if err != nil {
+				return err
+			}
+			if source == config.TokenSourceFile {
+				fmt.Fprintf(f.Warnings(), "Warning: keychain unavailable, token stored in plain text at %s\n", config.DefaultPath())
+			}
+			if os.Getenv("TP_TOKEN") != "" || os.Getenv("TP_DOMAIN") != "" {
+				fmt.Fprintln(f.Warnings(), "Note: TP_DOMAIN/TP_TOKEN are set and take precedence over the saved login.")
+			}
+
+			fmt.Fprintf(os.Stdout, "Logged in to %s as %s\n", client.BaseURL, userLabel(user))
+			return nil
+		},
+	}
+}
+
+// promptLine writes prompt (with def shown as the default, if any) and reads
+// one line. An empty answer returns def.
+func promptLine(r *bufio.Reader, w io.Writer, prompt, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(w, "%s [%s]: ", prompt, def)
+	} else {
+		fmt.Fprintf(w, "%s: ", prompt)
+	}
+	line, err := r.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("reading %s: %w", strings.ToLower(prompt), err)
+	}
+	if line = strings.TrimSpace(line); line != "" {
+		return line, nil
+	}
+	return def, nil
+}
+
+// readToken reads the token without echo on a terminal, or as a plain line
+// from piped input.
+func readToken(r *bufio.Reader) (string, error) {
+	if !cmdutil.IsInteractive() {
+		return promptLine(r, io.Discard, "Token", "")
+	}
+	fmt.Fprint(os.Stderr, "Access token: ")
+	b, err := term.ReadPassword(int(os.Stdin.Fd())) //nolint:gosec // Fd fits in int on supported platforms
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("reading token: %w", err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// userLabel names a user as "First Last (login, ID n)", omitting missing parts.
+func userLabel(u api.User) string {
+	name := strings.TrimSpace(u.FirstName + " " + u.LastName)
+	if name == "" {
+		name = u.Login
+	}
+	if u.Login != "" && u.Login != name {
+		return fmt.Sprintf("%s (%s, ID %d)", name, u.Login, u.ID)
+	}
+	return fmt.Sprintf("%s (ID %d)", name, u.ID)
+}
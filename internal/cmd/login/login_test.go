@@ -0,0 +1,46 @@
+package login
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+func TestPromptLine(t *testing.T) {
+	tests := []struct {
+		input, def, want string
+	}{
+		{"example.tpondemand.com\n", "", "example.tpondemand.com"},
+		{"  spaced  \n", "", "spaced"},
+		{"\n", "old.tpondemand.com", "old.tpondemand.com"},
+		{"", "old.tpondemand.com", "old.tpondemand.com"},
+		{"no-newline", "", "no-newline"},
+	}
+	for _, tt := range tests {
+		got, err := promptLine(bufio.NewReader(strings.NewReader(tt.input)), io.Discard, "Domain", tt.def)
+		if err != nil {
+			t.Fatalf("promptLine(%q) error = %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("promptLine(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestUserLabel(t *testing.T) {
+	tests := []struct {
+		user api.User
+		want string
+	}{
+		{api.User{ID: 7, FirstName: "Ada", LastName: "Lovelace", Login: "ada"}, "Ada Lovelace (ada, ID 7)"},
+		{api.User{ID: 8, Login: "bot"}, "bot (ID 8)"},
+	}
+	for _, tt := range tests {
+		if got := userLabel(tt.user); got != tt.want {
+			t.Errorf("userLabel(%+v) = %q, want %q", tt.user, got, tt.want)
+		}
+	}
+}
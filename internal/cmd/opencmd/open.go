@@ -0,0 +1,71 @@
+package opencmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/browser"
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+	"github.com/lifedraft/targetprocess-cli/internal/config"
+)
+
+// NewCmd creates the "open" command.
+func NewCmd(f *cmdutil.Factory) *cli.Command {
+	return &cli.Command{
+		Name:      "open",
+		Usage:     "Open an entity in the Targetprocess web UI",
+		ArgsUsage: "<id>",
+		UsageText: `# Open an entity in your browser
+  tp open 341079
+
+  # Just print the URL (e.g. to paste into chat)
+  tp open 341079 --print`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "print", Usage: "Print the URL instead of opening it"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			arg := cmd.Args().First()
+			if arg == "" {
+				return errors.New("entity ID is required; usage: tp open <id>")
+			}
+			id, err := strconv.Atoi(arg)
+			if err != nil {
+				return fmt.Errorf("invalid entity ID %q: must be an integer", arg)
+			}
+			if id <= 0 {
+				return fmt.Errorf("entity ID must be positive, got %d", id)
+			}
+
+			cfg, err := f.Config()
+			if err != nil {
+				return err
+			}
+			if cfg.Domain == "" {
+				return fmt.Errorf("domain is required (set TP_DOMAIN env var or domain in %s)", config.DefaultPath())
+			}
+
+			entityURL := EntityURL(cfg.Domain, id)
+			if cmd.Bool("print") {
+				fmt.Fprintln(os.Stdout, entityURL)
+				return nil
+			}
+			fmt.Fprintf(f.Warnings(), "Opening %s\n", entityURL)
+			return browser.Open(ctx, entityURL)
+		},
+	}
+}
+
+// EntityURL returns the web UI URL for an entity. The UI resolves bare IDs,
+// so the entity type is not needed.
+func EntityURL(domain string, id int) string {
+	if !strings.HasPrefix(domain, "http") {
+		domain = "https://" + domain
+	}
+	return fmt.Sprintf("%s/entity/%d", strings.TrimRight(domain, "/"), id)
+}
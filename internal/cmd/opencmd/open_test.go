@@ -0,0 +1,19 @@
+package opencmd
+
+import "testing"
+
+func TestEntityURL(t *testing.T) {
+	tests := []struct {
+		domain string
+		want   string
+	}{
+		{"example.tpondemand.com", "https://example.tpondemand.com/entity/42"},
+		{"https://example.tpondemand.com/", "https://example.tpondemand.com/entity/42"},
+		{"http://localhost:8080", "http://localhost:8080/entity/42"},
+	}
+	for _, tt := range tests {
+		if got := EntityURL(tt.domain, 42); got != tt.want {
+			t.Errorf("EntityURL(%q) = %q, want %q", tt.domain, got, tt.want)
+		}
+	}
+}
@@ -10,30 +10,43 @@ import (
 
 	"github.com/lifedraft/targetprocess-cli/internal/cmd/search"
 	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
-	"github.com/lifedraft/targetprocess-cli/internal/output"
 )
 
 // NewCmd creates the "presets" command.
-func NewCmd() *cli.Command {
+func NewCmd(f *cmdutil.Factory) *cli.Command {
 	return &cli.Command{
 		Name:  "presets",
-		Usage: "List available search preset filters",
+		Usage: "List available search preset filters and select views",
 		UsageText: `# List all presets in text format
   tp presets
 
   # List presets as JSON
   tp presets --output json`,
+		Description: `Teams can add their own presets in the presets section of the config file;
+they are listed with source "config", and one with a built-in name replaces
+the built-in preset:
+
+  presets:
+    coreTriage:
+      description: Untriaged bugs for the core team
+      where: team.name=="Core" and severity==null
+      select: id,name,createDate
+      orderBy: createDate desc`,
 		Flags: []cli.Flag{
 			cmdutil.OutputFlag(),
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			if cmdutil.IsJSON(cmd) {
+			if err := search.LoadUserPresets(f, f.Warnings()); err != nil {
+				return err
+			}
+			if cmdutil.IsStructured(cmd) {
 				type jsonPreset struct {
 					Name        string `json:"name"`
 					Description string `json:"description"`
 					Where       string `json:"where"`
 					Select      string `json:"select,omitempty"`
 					OrderBy     string `json:"orderBy,omitempty"`
+					Source      string `json:"source"`
 				}
 				names := search.SortedPresetNames
 				presetList := make([]jsonPreset, len(names))
@@ -45,20 +58,52 @@ func NewCmd() *cli.Command {
 						Where:       p.Where,
 						Select:      p.Select,
 						OrderBy:     p.OrderBy,
+						Source:      presetSource(p),
 					}
 				}
-				return output.PrintJSON(os.Stdout, map[string]any{
+				type jsonView struct {
+					Name        string `json:"name"`
+					Description string `json:"description"`
+					Select      string `json:"select"`
+				}
+				viewList := make([]jsonView, len(search.SortedSelectPresetNames))
+				for i, name := range search.SortedSelectPresetNames {
+					v := search.SelectPresets[name]
+					viewList[i] = jsonView{Name: v.Name, Description: v.Description, Select: v.Select}
+				}
+				return cmdutil.PrintStructured(cmd, map[string]any{
 					"presets": presetList,
+					"views":   viewList,
 				})
 			}
 
 			tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			fmt.Fprintf(tw, "NAME\tDESCRIPTION\tWHERE\n")
+			fmt.Fprintf(tw, "NAME\tSOURCE\tDESCRIPTION\tWHERE\n")
 			for _, name := range search.SortedPresetNames {
 				p := search.SearchPresets[name]
-				fmt.Fprintf(tw, "%s\t%s\t%s\n", name, p.Description, p.Where)
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", name, presetSource(p), p.Description, p.Where)
+			}
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(os.Stdout)
+			fmt.Fprintln(os.Stdout, "Select views (--view):")
+			tw = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(tw, "NAME\tDESCRIPTION\tSELECT\n")
+			for _, name := range search.SortedSelectPresetNames {
+				v := search.SelectPresets[name]
+				fmt.Fprintf(tw, "%s\t%s\t%s\n", name, v.Description, v.Select)
 			}
 			return tw.Flush()
 		},
 	}
 }
+
+// presetSource says where a preset comes from: built-in or config.
+func presetSource(p search.Preset) string {
+	if p.User {
+		return "config"
+	}
+	return "built-in"
+}
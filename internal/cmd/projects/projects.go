@@ -0,0 +1,63 @@
+package projects
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+	"github.com/lifedraft/targetprocess-cli/internal/output"
+)
+
+// NewCmd creates the "projects" command.
+func NewCmd(f *cmdutil.Factory) *cli.Command {
+	return &cli.Command{
+		Name:  "projects",
+		Usage: "List the projects the current token can access",
+		UsageText: `# All accessible projects with their process
+  tp projects
+
+  # Only active projects, as JSON
+  tp projects --active -o json`,
+		Flags: []cli.Flag{
+			cmdutil.OutputFlag(),
+			&cli.BoolFlag{Name: "active", Usage: "Only active projects"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			client, err := f.Client()
+			if err != nil {
+				return err
+			}
+			list, err := client.ListProjects(ctx, cmd.Bool("active"))
+			if err != nil {
+				return err
+			}
+			return Print(cmd, list)
+		},
+	}
+}
+
+// Print writes projects as a table, or as JSON with --output json.
+func Print(cmd *cli.Command, projects []api.Project) error {
+	if cmdutil.IsStructured(cmd) {
+		return cmdutil.PrintStructured(cmd, map[string]any{
+			"projects": projects,
+			"count":    len(projects),
+		})
+	}
+
+	if len(projects) == 0 {
+		fmt.Fprintln(os.Stdout, "No accessible projects.")
+		return nil
+	}
+	tw := output.NewTabWriter(os.Stdout)
+	fmt.Fprintln(tw, "ID\tNAME\tPROCESS\tACTIVE")
+	for _, p := range projects {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", p.ID, p.Name, p.Process, strconv.FormatBool(p.IsActive))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,61 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// assertRe matches "count <op> <number>", e.g. "count>=1" or "count == 0".
+var assertRe = regexp.MustCompile(`^\s*count\s*(==|!=|>=|<=|>|<)\s*(\d+)\s*$`)
+
+// countAssertion is a parsed --assert post-condition on the result count.
+type countAssertion struct {
+	expr string
+	op   string
+	n    int
+}
+
+// parseAssertion parses an --assert expression of the form "count <op> <number>".
+func parseAssertion(expr string) (*countAssertion, error) {
+	m := assertRe.FindStringSubmatch(expr)
+	if m == nil {
+		return nil, fmt.Errorf("invalid --assert %q: expected 'count <op> <number>' with op one of ==, !=, >, >=, <, <=", expr)
+	}
+	n, err := strconv.Atoi(m[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid --assert %q: %w", expr, err)
+	}
+	return &countAssertion{expr: expr, op: m[1], n: n}, nil
+}
+
+// check returns an error naming the actual count if the assertion fails.
+func (a *countAssertion) check(count int) error {
+	var ok bool
+	switch a.op {
+	case "==":
+		ok = count == a.n
+	case "!=":
+		ok = count != a.n
+	case ">":
+		ok = count > a.n
+	case ">=":
+		ok = count >= a.n
+	case "<":
+		ok = count < a.n
+	case "<=":
+		ok = count <= a.n
+	}
+	if !ok {
+		return fmt.Errorf("assertion failed: %s (actual count: %d)", a.expr, count)
+	}
+	return nil
+}
+
+// checkAssertion runs a if set; a nil assertion always passes.
+func checkAssertion(a *countAssertion, count int) error {
+	if a == nil {
+		return nil
+	}
+	return a.check(count)
+}
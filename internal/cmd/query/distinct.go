@@ -0,0 +1,82 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+	"github.com/lifedraft/targetprocess-cli/internal/output"
+)
+
+// distinctAlias is the select alias the --distinct field is fetched under.
+const distinctAlias = "distinctValue"
+
+// distinctSelect projects only the --distinct field.
+func distinctSelect(field string) string {
+	return fmt.Sprintf("id,%s as %s", field, distinctAlias)
+}
+
+// distinctValue is one unique value of the --distinct field and how many
+// items have it.
+type distinctValue struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// buildDistinct counts the distinct values fetched under distinctAlias,
+// sorted by value. Values are rendered with formatValue, so a reference such
+// as entityState shows as its name, as in tables; nulls are labeled
+// noGroupName.
+func buildDistinct(items []map[string]any) []distinctValue {
+	counts := make(map[string]int)
+	for _, item := range items {
+		key := formatValue(item[distinctAlias])
+		if key == "" {
+			key = noGroupName
+		}
+		counts[key]++
+	}
+	values := make([]distinctValue, 0, len(counts))
+	for v, n := range counts {
+		values = append(values, distinctValue{Value: v, Count: n})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Value < values[j].Value
+	})
+	return values
+}
+
+func printDistinct(cmd *cli.Command, field string, items []map[string]any) error {
+	values := buildDistinct(items)
+
+	if cmdutil.IsStructured(cmd) {
+		return cmdutil.PrintStructured(cmd, values)
+	}
+
+	if cmdutil.IsJSONL(cmd) {
+		enc := json.NewEncoder(os.Stdout)
+		for _, v := range values {
+			if err := enc.Encode(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(values) == 0 {
+		fmt.Fprintln(os.Stdout, "No results found.")
+		return nil
+	}
+
+	tw := output.NewTabWriter(os.Stdout)
+	fmt.Fprintf(tw, "%s\tCOUNT\n", strings.ToUpper(field))
+	for _, v := range values {
+		fmt.Fprintf(tw, "%s\t%d\n", v.Value, v.Count)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,297 @@
+package query
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/search"
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+	"github.com/lifedraft/targetprocess-cli/internal/output"
+	"github.com/lifedraft/targetprocess-cli/internal/resolve"
+)
+
+// exportFormats are the --format values export writes.
+var exportFormats = []string{"csv", "json", "jsonl"}
+
+// NewExportCmd creates the "export" command.
+func NewExportCmd(f *cmdutil.Factory) *cli.Command {
+	return &cli.Command{
+		Name:      "export",
+		Usage:     "Write every entity a query matches to a CSV, JSON, or JSON Lines file",
+		ArgsUsage: "<EntityType>",
+		UsageText: `# All open bugs to CSV, one column per selected field
+  tp export Bug -w 'entityState.isFinal!=true' -s 'id,name,entityState.name as state' --file bugs.csv
+
+  # Format from the extension, or set it with --format
+  tp export UserStory --where-preset open --file stories.jsonl
+  tp export Feature -s 'id,name,userStories.count as stories' --file features.out --format json
+
+  # Nightly extract of what changed in the last day
+  tp export Assignable --changed-since 1d -s 'id,name,modifyDate' --file changes.csv`,
+		Description: `Pages through all results by following the API's next links and writes each
+page as it arrives, so large sets are never held in memory. Progress goes to
+stderr. Nested objects become dot-separated CSV columns (project.name); the
+CSV columns come from the first page, so use --select to fix them.`,
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{Name: "file", Required: true, Usage: "File to write"},
+			&cli.StringFlag{Name: "format", Usage: "csv, json, or jsonl (default from the --file extension, else csv)"},
+			&cli.BoolFlag{Name: "flatten", Usage: "With json or jsonl, flatten nested objects into dot-separated keys (CSV always is)"},
+			&cli.StringFlag{Name: "select", Aliases: []string{"s"}, Usage: "Select expression (e.g., 'id,name,entityState.name as state')"},
+			&cli.StringFlag{Name: "view", Usage: "Apply a named select preset (e.g. summary, detailed; see tp presets). --select wins if both are given"},
+			&cli.StringSliceFlag{Name: "include", Usage: "v1-style related fields (e.g. Owner,Tasks), added to the select as owner.name as owner / tasks.count as tasksCount"},
+			&cli.StringFlag{Name: "where", Aliases: []string{"w"}, Usage: "Where filter expression"},
+			cmdutil.WhereFileFlag(),
+			&cli.StringFlag{Name: "where-preset", Usage: "Apply only the where clause of a named preset (run 'tp presets' to list); combined with --where using 'and'"},
+			&cli.StringFlag{Name: "order", Usage: "OrderBy expression (e.g., 'createDate desc')"},
+			&cli.IntFlag{Name: "take", Aliases: []string{"t"}, Value: 1000, Usage: "Page size"},
+			&cli.IntFlag{Name: "since-id", Usage: "Only entities with id greater than N, ordered by id (for incremental sync)"},
+			&cli.StringFlag{Name: "changed-since", Usage: "Only entities modified at or after this time: relative (7d) or a timestamp"},
+			&cli.StringFlag{Name: "columns-order", Usage: "CSV column order, e.g. 'id,state,name' (unlisted columns follow)"},
+			&cli.BoolFlag{Name: "dry-run", Usage: "Show the URL of the first page without fetching or writing anything"},
+			cmdutil.AutoQuoteFlag(),
+		}, cmdutil.FilterFlags()...),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 1 {
+				return errors.New("entity type is required; usage: tp export <EntityType> --file <path>")
+			}
+			entityType := resolve.EntityType(cmd.Args().First())
+			if err := api.ValidateEntityType(entityType); err != nil {
+				return err
+			}
+			if err := resolve.CheckEntityType(entityType); err != nil {
+				return err
+			}
+
+			path := cmd.String("file")
+			format, err := exportFormat(cmd.String("format"), path)
+			if err != nil {
+				return err
+			}
+			if cmd.Bool("flatten") && format == "csv" {
+				return errors.New("--flatten applies to json and jsonl; CSV is always flattened")
+			}
+
+			client, err := f.Client()
+			if err != nil {
+				return err
+			}
+
+			selectExpr, err := search.ApplyView(cmd.String("view"), cmd.String("select"))
+			if err != nil {
+				return err
+			}
+			if include := cmd.StringSlice("include"); len(include) > 0 {
+				selectExpr, err = includeSelect(selectExpr, include, relationKinds(ctx, client, entityType))
+				if err != nil {
+					return err
+				}
+			}
+			warnExpressions(f.Warnings(), selectExpr)
+
+			params, err := collectionParams(f, cmd, selectExpr, cmd.Int("take"), 0)
+			if err != nil {
+				return err
+			}
+			if params.Take == 0 {
+				return errors.New("--take must be at least 1")
+			}
+
+			if cmd.Bool("dry-run") {
+				fmt.Fprintln(os.Stdout, client.BuildV2URL(entityType, params))
+				return nil
+			}
+
+			return exportToFile(ctx, f, cmd, client, entityType, params, path, format)
+		},
+	}
+}
+
+// exportFormat returns the --format value, or the one the file extension
+// implies, or csv.
+func exportFormat(format, path string) (string, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+		if format == "ndjson" {
+			format = "jsonl"
+		}
+		for _, known := range exportFormats {
+			if format == known {
+				return format, nil
+			}
+		}
+		return "csv", nil
+	}
+	format = strings.ToLower(format)
+	for _, known := range exportFormats {
+		if format == known {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown --format %q; use %s", format, strings.Join(exportFormats, ", "))
+}
+
+// exportToFile streams every page of the query into path. A failed export
+// removes the partial file.
+func exportToFile(ctx context.Context, f *cmdutil.Factory, cmd *cli.Command, client *api.Client, entityType string, params api.V2Params, path, format string) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating output file: %w", err)
+	}
+	defer func() {
+		if cErr := file.Close(); cErr != nil && err == nil {
+			err = fmt.Errorf("closing output file: %w", cErr)
+		}
+		if err != nil {
+			os.Remove(path) //nolint:errcheck,gosec // best-effort cleanup of a partial file
+		}
+	}()
+
+	w := newExportWriter(file, format, cmd.Bool("flatten"), splitColumns(cmd.String("columns-order")), f.Warnings())
+	count := 0
+	err = client.QueryV2Pages(ctx, entityType, params, func(items []api.Entity) error {
+		if err := w.write(items); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+		count += len(items)
+		fmt.Fprintf(f.Warnings(), "Exported %d rows...\n", count)
+		return nil
+	})
+	if err != nil {
+		return queryFailed(cmd, err, fmt.Sprintf("/api/v2/%s", entityType), map[string]string{
+			"where":   params.Where,
+			"select":  params.Select,
+			"orderBy": params.OrderBy,
+			"take":    strconv.Itoa(params.Take),
+		})
+	}
+	if err := w.close(); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	fmt.Fprintf(f.Warnings(), "Wrote %d rows to %s\n", count, path)
+	return nil
+}
+
+// exportWriter writes pages of items in one format.
+type exportWriter struct {
+	w       *bufio.Writer
+	format  string
+	flatten bool
+	order   []string
+	warn    io.Writer
+
+	csv     *csv.Writer
+	columns []string
+	known   map[string]bool
+	dropped map[string]bool
+	rows    int
+}
+
+func newExportWriter(w io.Writer, format string, flatten bool, order []string, warn io.Writer) *exportWriter {
+	bw := bufio.NewWriter(w)
+	ew := &exportWriter{w: bw, format: format, flatten: flatten || format == "csv", order: order, warn: warn}
+	if format == "csv" {
+		ew.csv = csv.NewWriter(bw)
+	}
+	return ew
+}
+
+// write appends one page.
+func (ew *exportWriter) write(items []api.Entity) error {
+	if ew.flatten {
+		for i, item := range items {
+			items[i] = output.Flatten(item)
+		}
+	}
+	switch ew.format {
+	case "jsonl":
+		return output.PrintJSONLines(ew.w, items)
+	case "json":
+		return ew.writeJSON(items)
+	default:
+		return ew.writeCSV(items)
+	}
+}
+
+// writeJSON writes items as elements of one JSON array, one per line.
+func (ew *exportWriter) writeJSON(items []api.Entity) error {
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		sep := ",\n"
+		if ew.rows == 0 {
+			sep = "[\n"
+		}
+		ew.rows++
+		if _, err := ew.w.WriteString(sep); err != nil {
+			return err
+		}
+		if _, err := ew.w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCSV writes items as CSV rows. The header comes from the first
+// non-empty page; keys that only appear later are dropped with a warning.
+func (ew *exportWriter) writeCSV(items []api.Entity) error {
+	if len(items) == 0 {
+		return nil
+	}
+	if ew.columns == nil {
+		ew.columns = tableColumns(items, ew.order, false, ew.warn)
+		ew.known = make(map[string]bool, len(ew.columns))
+		for _, c := range ew.columns {
+			ew.known[c] = true
+		}
+		ew.dropped = map[string]bool{}
+		if err := ew.csv.Write(ew.columns); err != nil {
+			return err
+		}
+	}
+	record := make([]string, len(ew.columns))
+	for _, item := range items {
+		for key := range item {
+			if !ew.known[key] && key != "resourceType" && !ew.dropped[key] {
+				ew.dropped[key] = true
+				fmt.Fprintf(ew.warn, "Warning: column %s first appeared after the header was written and is left out; pass --select to fix the columns\n", key)
+			}
+		}
+		for i, col := range ew.columns {
+			record[i] = formatValue(item[col])
+		}
+		if err := ew.csv.Write(record); err != nil {
+			return err
+		}
+	}
+	ew.csv.Flush()
+	return ew.csv.Error()
+}
+
+// close finishes the file: the closing bracket for JSON, then a flush.
+func (ew *exportWriter) close() error {
+	if ew.format == "json" {
+		closing := "\n]\n"
+		if ew.rows == 0 {
+			closing = "[]\n"
+		}
+		if _, err := ew.w.WriteString(closing); err != nil {
+			return err
+		}
+	}
+	return ew.w.Flush()
+}
@@ -0,0 +1,67 @@
+package query
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+func TestExportFormat(t *testing.T) {
+	tests := []struct {
+		format, path string
+		want         string
+		wantErr      bool
+	}{
+		{"", "out.csv", "csv", false},
+		{"", "out.JSON", "json", false},
+		{"", "out.ndjson", "jsonl", false},
+		{"", "out.txt", "csv", false},
+		{"JSONL", "out.csv", "jsonl", false},
+		{"xml", "out.xml", "", true},
+	}
+	for _, tt := range tests {
+		got, err := exportFormat(tt.format, tt.path)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("exportFormat(%q, %q) = %q, %v; want %q (error %v)", tt.format, tt.path, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestExportWriter(t *testing.T) {
+	pages := [][]api.Entity{
+		{{"id": 1.0, "name": "a"}},
+		{{"id": 2.0, "name": "b", "effort": 3.0}},
+	}
+	tests := []struct {
+		format string
+		pages  [][]api.Entity
+		want   string
+	}{
+		{"json", pages, "[\n{\"id\":1,\"name\":\"a\"},\n{\"effort\":3,\"id\":2,\"name\":\"b\"}\n]\n"},
+		{"json", nil, "[]\n"},
+		{"jsonl", pages, "{\"id\":1,\"name\":\"a\"}\n{\"effort\":3,\"id\":2,\"name\":\"b\"}\n"},
+		// effort only appears on the second page, after the header.
+		{"csv", pages, "id,name\n1,a\n2,b\n"},
+		{"csv", nil, ""},
+	}
+	for _, tt := range tests {
+		var buf, warn bytes.Buffer
+		w := newExportWriter(&buf, tt.format, false, nil, &warn)
+		for _, page := range tt.pages {
+			if err := w.write(page); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.close(); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("%s export = %q, want %q", tt.format, buf.String(), tt.want)
+		}
+		if tt.format == "csv" && tt.pages != nil && !strings.Contains(warn.String(), "column effort") {
+			t.Errorf("expected a warning about the late effort column, got %q", warn.String())
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package query
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+// includeDefaultSelect is the base projection when --include is given
+// without --select, since any select replaces v2's default fields.
+const includeDefaultSelect = "id,name"
+
+// typeRelations lists the reference and collection field names from a
+// type's metadata XML.
+type typeRelations struct {
+	References []struct {
+		Name string `xml:"Name,attr"`
+	} `xml:"ResourceMetadataPropertiesDescription>ResourceMetadataPropertiesResourceReferencesDescription>ResourceFieldMetadataDescription"`
+	Collections []struct {
+		Name string `xml:"Name,attr"`
+	} `xml:"ResourceMetadataPropertiesDescription>ResourceMetadataPropertiesResourceCollectionsDescription>ResourceCollecitonFieldMetadataDescription"`
+}
+
+// fieldKinds maps lowercased field names to "reference" or "collection".
+type fieldKinds map[string]string
+
+// relationKinds reads entityType's reference and collection fields from its
+// (cached) metadata, or returns nil if metadata is unavailable.
+func relationKinds(ctx context.Context, client *api.Client, entityType string) fieldKinds {
+	data, err := client.GetTypeMeta(ctx, entityType)
+	if err != nil {
+		return nil
+	}
+	var rel typeRelations
+	if err := xml.Unmarshal(data, &rel); err != nil {
+		return nil
+	}
+	kinds := fieldKinds{}
+	for _, r := range rel.References {
+		kinds[strings.ToLower(r.Name)] = "reference"
+	}
+	for _, c := range rel.Collections {
+		kinds[strings.ToLower(c.Name)] = "collection"
+	}
+	return kinds
+}
+
+// includeSelect appends a v1-style --include list to selectExpr the v2 way:
+// a reference becomes "owner.name as owner" and a collection
+// "tasks.count as tasksCount". With kinds nil (no metadata) every name is
+// treated as a reference; otherwise unknown names are an error.
+func includeSelect(selectExpr string, include []string, kinds fieldKinds) (string, error) {
+	if selectExpr == "" {
+		selectExpr = includeDefaultSelect
+	}
+	parts := []string{selectExpr}
+	for _, name := range include {
+		field := lowerFirst(strings.TrimSpace(name))
+		if field == "" {
+			continue
+		}
+		kind := "reference"
+		if kinds != nil {
+			var ok bool
+			kind, ok = kinds[strings.ToLower(field)]
+			if !ok {
+				return "", fmt.Errorf("--include %s: not a reference or collection of this type (see tp inspect properties --kind references)", name)
+			}
+		}
+		if kind == "collection" {
+			parts = append(parts, fmt.Sprintf("%s.count as %sCount", field, field))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s.name as %s", field, field))
+		}
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// lowerFirst turns a v1 field name (EntityState) into its v2 form (entityState).
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"sort"
@@ -15,6 +16,8 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/report"
+	"github.com/lifedraft/targetprocess-cli/internal/cmd/search"
 	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
 	"github.com/lifedraft/targetprocess-cli/internal/output"
 	"github.com/lifedraft/targetprocess-cli/internal/resolve"
@@ -41,9 +44,75 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
   # Find items by text search
   tp query Assignable -s 'id,name,entityType.name as type' -w 'name.toLower().contains("login")' --order 'modifyDate desc'
 
+  # Fetch with any select, but show state first in the table
+  tp query Bug -s 'id,name,entityState.name as state' --columns-order state,id,name
+
+  # v1-style include: adds owner.name as owner and tasks.count as tasksCount
+  tp query UserStory -s 'id,name' --include Owner,Tasks
+
+  # Named projection: id, name, state, type, effort, priority, assignees
+  tp query UserStory -w 'entityState.isFinal!=true' --view detailed
+
   # Dry run to inspect the URL
   tp query Bug -w 'entityState.name=="Open"' --dry-run
 
+  # Annotated breakdown without sending anything: resolved type, the key each select field comes back under, filter, paging
+  tp query bugs -s 'id,name,project.name' -w 'effort>3' --explain
+
+  # On failure, show every error pattern that matched (not just the first hint)
+  tp query Bug -w 'description is null and createDate > Now' --explain-errors
+
+  # Incremental sync: everything created after the last seen id, all pages
+  tp query Bug -s 'id,name' --since-id 341000 --all -o json
+
+  # Explore wide selects without columns that are blank for every row
+  tp query Feature -s 'id,name,description,effort,release.name as release,tags' --omit-empty-columns
+  tp query Bug -s 'id,name,entityState.name as state' -o tsv | cut -f1,3
+  tp query UserStory -w 'id==1234' -s 'id,name,effort,entityState.name as state,owner.fullName as owner' --transpose
+
+  # Exact matches without hand-escaping quotes (repeatable, ANDed together)
+  tp query UserStory --eq "name:O'Brien's login bug" --eq 'project.id:42'
+
+  # Lists, ranges, and substrings compile to v2 clauses the same way
+  tp query Bug --not-in 'entityState.name:Done,Closed' --between effort:1,5 --contains name:login
+
+  # Let bare words in --where be read as strings (prints what it quoted)
+  tp query Bug -w 'entityState.name==Open and priority.name!=Low' --auto-quote
+
+  # Change sync: everything modified in the last 7 days, or since a timestamp
+  tp query Bug -s 'id,name,modifyDate' --changed-since 7d --all -o json
+  tp query Bug -s 'id,name,modifyDate' --changed-since 2024-01-01T00:00:00Z --all -o json
+
+  # Team workload: open items per assignee, heaviest load first
+  tp query Assignable -w 'entityState.isFinal!=true' --as-assignee-report
+
+  # Which states are in use, and how many bugs are in each
+  tp query Bug --distinct 'entityState.name'
+
+  # Bugs per state, most common first (grouped client-side)
+  tp query Bug --group-by 'entityState.name'
+
+  # Status report: count and effort per state, with a totals row
+  tp query Assignable -w 'teamIteration!=null' --group-summary 'entityState.name'
+
+  # Stream every page as JSON Lines (one object per line) to a downstream tool
+  tp query Bug -s 'id,name,modifyDate' --all -o jsonl | jq -c 'select(.id > 100)'
+
+  # Include inferred field types alongside the data for downstream tooling
+  tp query Bug -s 'id,name,effort,createDate' -o json --with-schema
+
+  # Fail (non-zero exit) when a post-condition on the result count does not hold
+  tp query Bug -w "entityState.name=='Open' and severity.name=='Critical'" --assert 'count==0'
+
+  # Export to Parquet for analytics tools
+  tp query Bug -s 'id,name,effort,entityState.name as state' --all -o parquet --out bugs.parquet
+
+  # Reuse a preset's filter with your own projection and sort
+  tp query Bug --where-preset highPriority -s 'id,name,priority.name as priority' --order 'id desc'
+
+  # Team filter kept in version control (multi-line; # lines are comments), narrowed inline
+  tp query Bug --where-file filters/triage.v2 -w 'priority.importance<=2'
+
   # Items created in last 7 days
   tp query UserStory -s 'id,name,createDate' -w 'createDate>=Today.AddDays(-7)' --order 'createDate desc'
 
@@ -58,18 +127,39 @@ Where operators: ==, !=, >, <, >=, <=, and, or, in [...], .contains(), .startsWi
 Date functions: Today, Today.AddDays(-N), Today.AddMonths(-N)
 Null checks: field==null, field!=null
 State helpers: entityState.isFinal==true, entityState.isInitial==true`,
-		Flags: []cli.Flag{
-			cmdutil.OutputFlag(),
+		Flags: append([]cli.Flag{
+			cmdutil.OutputFlag("jsonl", "tsv", "parquet"),
+			&cli.StringFlag{
+				Name:  "out",
+				Usage: "File to write to (required for --output parquet)",
+			},
+			&cli.BoolFlag{
+				Name:  "flatten",
+				Usage: "Flatten nested objects into dot-separated columns (e.g. project.name)",
+			},
 			&cli.StringFlag{
 				Name:    "select",
 				Aliases: []string{"s"},
 				Usage:   "Select expression (e.g., 'id,name,entityState.name as state')",
 			},
+			&cli.StringFlag{
+				Name:  "view",
+				Usage: "Apply a named select preset (e.g. summary, detailed; see tp presets). --select wins if both are given",
+			},
+			&cli.StringSliceFlag{
+				Name:  "include",
+				Usage: "v1-style related fields (e.g. Owner,Tasks), added to the select as owner.name as owner / tasks.count as tasksCount",
+			},
 			&cli.StringFlag{
 				Name:    "where",
 				Aliases: []string{"w"},
 				Usage:   "Where filter expression",
 			},
+			cmdutil.WhereFileFlag(),
+			&cli.StringFlag{
+				Name:  "where-preset",
+				Usage: "Apply only the where clause of a named preset (run 'tp presets' to list); combined with --where using 'and'",
+			},
 			&cli.StringFlag{
 				Name:  "order",
 				Usage: "OrderBy expression (e.g., 'createDate desc')",
@@ -85,11 +175,69 @@ State helpers: entityState.isFinal==true, entityState.isInitial==true`,
 				Value: 0,
 				Usage: "Number of results to skip",
 			},
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "Fetch every page by following 'next' links (--take sets the page size)",
+			},
+			&cli.IntFlag{
+				Name:  "since-id",
+				Usage: "Only return entities with id greater than N, ordered by id (for incremental sync)",
+			},
+			&cli.StringFlag{
+				Name:    "changed-since",
+				Aliases: []string{"only-changed-since"},
+				Usage:   "Only entities modified at or after this time: relative (7d) or a timestamp (2024-01-01T00:00:00, zone-aware with Z/+02:00)",
+			},
+			&cli.BoolFlag{
+				Name:  "as-assignee-report",
+				Usage: "Group all matches by assignee with item counts and total effort (workload view); fetches every page",
+			},
+			&cli.StringFlag{
+				Name:  "group-summary",
+				Usage: "Group all matches by a field path (e.g. 'entityState.name') and print count and effort per group with a total row; fetches every page",
+			},
+			&cli.StringFlag{
+				Name:  "distinct",
+				Usage: "Print the sorted unique values of a field path (e.g. 'entityState.name') with how many matches have each; fetches every page",
+			},
+			&cli.StringFlag{
+				Name:  "group-by",
+				Usage: "Count matches per value of a field path (e.g. 'entityState.name'), computed client-side like tp report, most common first; fetches every page",
+			},
+			&cli.BoolFlag{
+				Name:  "omit-empty-columns",
+				Usage: "In table output, hide columns that are empty or null in every row (JSON is unchanged)",
+			},
+			&cli.BoolFlag{
+				Name:  "transpose",
+				Usage: "Print the first result as field/value lines instead of a table (automatic for a single result wider than the terminal)",
+			},
+			&cli.StringFlag{
+				Name:  "columns-order",
+				Usage: "Table column order for display, e.g. 'id,state,name' (unlisted columns follow; does not change what is fetched)",
+			},
+			&cli.BoolFlag{
+				Name:  "with-schema",
+				Usage: "With -o json, add a schema section giving each returned field's type, inferred from a sample of values",
+			},
+			&cli.StringFlag{
+				Name:  "assert",
+				Usage: "Fail with a non-zero exit if the result count does not satisfy 'count <op> <n>' (op: == != > >= < <=), e.g. 'count>=1'",
+			},
+			&cli.BoolFlag{
+				Name:  "explain",
+				Usage: "Print an annotated breakdown (type, select keys, where, order, paging, URL) instead of running the query; sends nothing",
+			},
+			&cli.BoolFlag{
+				Name:  "explain-errors",
+				Usage: "On failure, list every known error pattern that matches, not just the first hint",
+			},
 			&cli.BoolFlag{
 				Name:  "dry-run",
 				Usage: "Show the URL that would be called without executing",
 			},
-		},
+			cmdutil.AutoQuoteFlag(),
+		}, cmdutil.FilterFlags()...),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			args := cmd.Args().Slice()
 			if len(args) == 0 {
@@ -101,10 +249,41 @@ State helpers: entityState.isFinal==true, entityState.isInitial==true`,
 				return err
 			}
 
+			var assertion *countAssertion
+			if expr := cmd.String("assert"); expr != "" {
+				assertion, err = parseAssertion(expr)
+				if err != nil {
+					return err
+				}
+			}
+
+			typeArg := entityType
 			entityType = resolve.EntityType(entityType)
 			if vErr := api.ValidateEntityType(entityType); vErr != nil {
 				return vErr
 			}
+			if err := resolve.CheckEntityType(entityType); err != nil {
+				return err
+			}
+
+			if cmd.String("output") == "parquet" && cmd.String("out") == "" {
+				return errors.New("--output parquet requires --out <file>")
+			}
+
+			if cmd.Bool("with-schema") {
+				if !cmdutil.IsJSON(cmd) {
+					return errors.New("--with-schema requires --output json")
+				}
+				if entityID > 0 || cmd.Bool("as-assignee-report") || cmd.String("group-summary") != "" || cmd.String("distinct") != "" || cmd.String("group-by") != "" {
+					return errors.New("--with-schema applies to collection queries only")
+				}
+			}
+
+			// --explain is a dry run that shows the breakdown instead of the
+			// URL; nothing below may reach the network for it.
+			explain := cmd.Bool("explain")
+			dryRun := explain || cmd.Bool("dry-run")
+			f.Offline = dryRun
 
 			client, err := f.Client()
 			if err != nil {
@@ -113,15 +292,78 @@ State helpers: entityState.isFinal==true, entityState.isInitial==true`,
 
 			selectExpr := cmd.String("select")
 
-			// Warn about dot-paths missing 'as' aliases (silently dropped by API)
-			if warn := api.WarnSelectDotPaths(selectExpr); warn != "" {
-				fmt.Fprint(os.Stderr, warn)
+			groupField := strings.TrimSpace(cmd.String("group-summary"))
+			distinctField := strings.TrimSpace(cmd.String("distinct"))
+			groupByField := strings.TrimSpace(cmd.String("group-by"))
+			assigneeReport := cmd.Bool("as-assignee-report")
+			var reportFlags []string
+			if assigneeReport {
+				reportFlags = append(reportFlags, "--as-assignee-report")
+			}
+			if groupField != "" {
+				reportFlags = append(reportFlags, "--group-summary")
+			}
+			if distinctField != "" {
+				reportFlags = append(reportFlags, "--distinct")
+			}
+			if groupByField != "" {
+				reportFlags = append(reportFlags, "--group-by")
+			}
+			if len(reportFlags) > 1 {
+				return fmt.Errorf("%s cannot be combined", strings.Join(reportFlags, " and "))
+			}
+			if len(reportFlags) == 1 {
+				if selectExpr != "" || cmd.String("view") != "" || entityID > 0 {
+					return fmt.Errorf("%s builds its own select and cannot be combined with --select, --view, or an entity ID", reportFlags[0])
+				}
+				switch {
+				case groupField != "":
+					selectExpr = groupSummarySelect(groupField)
+				case distinctField != "":
+					selectExpr = distinctSelect(distinctField)
+				case groupByField != "":
+					selectExpr = report.CountSelect(groupByField)
+				default:
+					selectExpr = assigneeReportSelect
+				}
+			}
+
+			selectExpr, err = search.ApplyView(cmd.String("view"), selectExpr)
+			if err != nil {
+				return err
+			}
+
+			if include := cmd.StringSlice("include"); len(include) > 0 {
+				if len(reportFlags) > 0 {
+					return fmt.Errorf("--include cannot be combined with %s", reportFlags[0])
+				}
+				var kinds fieldKinds
+				if !explain {
+					kinds = relationKinds(ctx, client, entityType)
+				}
+				selectExpr, err = includeSelect(selectExpr, include, kinds)
+				if err != nil {
+					return err
+				}
+			}
+
+			// --explain shows these warnings inline instead
+			if !explain {
+				warnExpressions(f.Warnings(), selectExpr)
 			}
 
 			// Single entity by ID
 			if entityID > 0 {
-				if cmd.Bool("dry-run") {
-					fmt.Fprintln(os.Stdout, client.BuildV2EntityURL(entityType, entityID, selectExpr))
+				if dryRun {
+					entityURL := client.BuildV2EntityURL(entityType, entityID, selectExpr)
+					if explain {
+						cmdutil.PrintQueryPlan(os.Stdout, cmdutil.QueryPlan{
+							TypeArg: typeArg, EntityType: entityType, ID: entityID,
+							Params: api.V2Params{Select: selectExpr}, URL: entityURL,
+						})
+						return nil
+					}
+					fmt.Fprintln(os.Stdout, entityURL)
 					return nil
 				}
 
@@ -129,52 +371,214 @@ State helpers: entityState.isFinal==true, entityState.isInitial==true`,
 				data, err = client.QueryV2Entity(ctx, entityType, entityID, selectExpr)
 				if err != nil {
 					path := fmt.Sprintf("/api/v2/%s/%d", entityType, entityID)
-					err = api.EnhanceError(err, path, map[string]string{"select": selectExpr})
-					return fmt.Errorf("query failed: %w", err)
+					return queryFailed(cmd, err, path, map[string]string{"select": selectExpr})
 				}
 
-				return printResponse(cmd, data)
+				if err := printResponse(cmd, data, f.OutputWidth(), f.Warnings()); err != nil {
+					return err
+				}
+				return checkAssertion(assertion, 1)
 			}
 
 			// Collection query
+			sinceID := cmd.IsSet("since-id")
+			// The report modes aggregate over every match, so they always
+			// fetch all pages, in large pages unless --take says otherwise.
 			take := cmd.Int("take")
-			if take < 0 || take > 1000 {
-				return fmt.Errorf("take must be between 0 and 1000, got %d", take)
-			}
-			skip := cmd.Int("skip")
-			if skip < 0 {
-				return fmt.Errorf("skip must be non-negative, got %d", skip)
+			if len(reportFlags) > 0 && !cmd.IsSet("take") {
+				take = reportPageSize
 			}
-
-			params := api.V2Params{
-				Where:   cmd.String("where"),
-				Select:  selectExpr,
-				OrderBy: cmd.String("order"),
-				Take:    take,
-				Skip:    skip,
+			params, err := collectionParams(f, cmd, selectExpr, take, cmd.Int("skip"))
+			if err != nil {
+				return err
 			}
 
-			if cmd.Bool("dry-run") {
+			if dryRun {
+				if explain {
+					cmdutil.PrintQueryPlan(os.Stdout, cmdutil.QueryPlan{
+						TypeArg: typeArg, EntityType: entityType, Params: params,
+						URL: client.BuildV2URL(entityType, params),
+					})
+					return nil
+				}
 				fmt.Fprintln(os.Stdout, client.BuildV2URL(entityType, params))
 				return nil
 			}
 
-			data, err := client.QueryV2(ctx, entityType, params)
-			if err != nil {
-				path := fmt.Sprintf("/api/v2/%s", entityType)
-				err = api.EnhanceError(err, path, map[string]string{
+			enhance := func(err error) error {
+				return queryFailed(cmd, err, fmt.Sprintf("/api/v2/%s", entityType), map[string]string{
 					"where":   params.Where,
 					"select":  params.Select,
 					"orderBy": params.OrderBy,
+					"take":    strconv.Itoa(params.Take),
+				})
+			}
+
+			// JSON Lines with --all streams each page as it arrives.
+			if cmdutil.IsJSONL(cmd) && cmd.Bool("all") && len(reportFlags) == 0 {
+				var lastPage []map[string]any
+				count := 0
+				err = client.QueryV2Pages(ctx, entityType, params, func(items []api.Entity) error {
+					count += len(items)
+					if len(items) > 0 {
+						lastPage = items
+					}
+					return printJSONLines(cmd, items)
 				})
-				return fmt.Errorf("query failed: %w", err)
+				if err != nil {
+					return enhance(err)
+				}
+				if sinceID {
+					// --since-id orders by id, so the last page holds the max id.
+					fmt.Fprint(f.Warnings(), cmdutil.SinceIDHint(cmd.Int("since-id"), lastPage))
+				}
+				return checkAssertion(assertion, count)
 			}
 
-			return printResponse(cmd, data)
+			var parsed map[string]any
+			if cmd.Bool("all") || len(reportFlags) > 0 {
+				var items []api.Entity
+				items, err = f.FetchAll(ctx, client, entityType, params)
+				if err != nil {
+					return enhance(err)
+				}
+				anyItems := make([]any, len(items))
+				for i, item := range items {
+					anyItems[i] = item
+				}
+				parsed = map[string]any{"items": anyItems}
+			} else {
+				var data []byte
+				data, err = client.QueryV2(ctx, entityType, params)
+				if err != nil {
+					return enhance(err)
+				}
+				if err := json.Unmarshal(data, &parsed); err != nil {
+					return fmt.Errorf("parsing response: %w", err)
+				}
+			}
+
+			items := collectionItems(parsed)
+			if groupField != "" {
+				if err := printGroupSummary(cmd, groupField, items); err != nil {
+					return err
+				}
+				return checkAssertion(assertion, len(items))
+			}
+			if distinctField != "" {
+				if err := printDistinct(cmd, distinctField, items); err != nil {
+					return err
+				}
+				return checkAssertion(assertion, len(items))
+			}
+			if groupByField != "" {
+				if err := report.PrintCounts(cmd, groupByField, items); err != nil {
+					return err
+				}
+				return checkAssertion(assertion, len(items))
+			}
+			if assigneeReport {
+				if err := printAssigneeReport(cmd, items); err != nil {
+					return err
+				}
+				return checkAssertion(assertion, len(items))
+			}
+
+			if err := printParsed(cmd, parsed, f.OutputWidth(), f.Warnings()); err != nil {
+				return err
+			}
+			if sinceID {
+				fmt.Fprint(f.Warnings(), cmdutil.SinceIDHint(cmd.Int("since-id"), items))
+			}
+			return checkAssertion(assertion, len(items))
 		},
 	}
 }
 
+// warnExpressions reports select mistakes the API would reject or silently
+// mishandle. collectionParams checks the where expression.
+func warnExpressions(w io.Writer, selectExpr string) {
+	// Warn about dot-paths missing 'as' aliases (silently dropped by API)
+	fmt.Fprint(w, api.WarnSelectDotPaths(selectExpr))
+
+	// Warn about malformed select syntax (colon subfields, unbalanced braces, empty aliases)
+	fmt.Fprint(w, api.ValidateSelect(selectExpr))
+}
+
+// collectionParams builds the v2 parameters for a collection query from the
+// filter flags query and export share: --where and --where-file (with
+// --auto-quote), --where-preset, the structured where flags, --changed-since, --order, and
+// --since-id.
+func collectionParams(f *cmdutil.Factory, cmd *cli.Command, selectExpr string, take, skip int) (api.V2Params, error) {
+	where, err := cmdutil.WhereFlag(cmd, f.Warnings())
+	if err != nil {
+		return api.V2Params{}, err
+	}
+	// Warn about v1 word operators (eq, ne, gt, ...) that v2 rejects
+	fmt.Fprint(f.Warnings(), api.WarnWhereV1Operators(where))
+
+	if presetName := cmd.String("where-preset"); presetName != "" {
+		// tp presets and tp config doctor report replaced built-ins.
+		if err := search.LoadUserPresets(f, io.Discard); err != nil {
+			return api.V2Params{}, err
+		}
+		p, err := search.ApplyPreset(presetName, where)
+		if err != nil {
+			return api.V2Params{}, err
+		}
+		// Only the filter is taken; select and orderBy stay under user control.
+		where = p.Where
+	}
+
+	where, err = cmdutil.AppendFilterClauses(cmd, where)
+	if err != nil {
+		return api.V2Params{}, err
+	}
+
+	if changedSince := cmd.String("changed-since"); changedSince != "" {
+		clause, err := changedSinceClause(f, changedSince)
+		if err != nil {
+			return api.V2Params{}, err
+		}
+		where = cmdutil.AndWhere(where, clause)
+	}
+
+	// Tidy the order expression and warn about aggregates or bad directions
+	orderBy, orderWarn := api.NormalizeOrderBy(cmd.String("order"))
+	fmt.Fprint(f.Warnings(), orderWarn)
+	if cmd.IsSet("since-id") {
+		where, selectExpr, orderBy, err = cmdutil.ApplySinceID(cmd.Int("since-id"), where, selectExpr, orderBy)
+		if err != nil {
+			return api.V2Params{}, err
+		}
+	}
+
+	return cmdutil.NewV2Params(where, selectExpr, orderBy, take, skip)
+}
+
+// changedSinceClause builds the --changed-since filter in the account timezone.
+func changedSinceClause(f *cmdutil.Factory, value string) (string, error) {
+	cfg, err := f.Config()
+	if err != nil {
+		return "", err
+	}
+	loc, err := cfg.Location()
+	if err != nil {
+		return "", err
+	}
+	return cmdutil.ChangedSinceClause(value, loc)
+}
+
+// queryFailed enhances a query error with a hint and, with --explain-errors,
+// appends every matching error pattern after the error message.
+func queryFailed(cmd *cli.Command, err error, path string, params map[string]string) error {
+	enhanced := api.EnhanceError(err, path, params)
+	if cmd.Bool("explain-errors") {
+		return fmt.Errorf("query failed: %w\n\n%s", enhanced, api.FormatExplain(api.MatchPatterns(err, path, params)))
+	}
+	return fmt.Errorf("query failed: %w", enhanced)
+}
+
 // parseEntityArg splits "EntityType" or "EntityType/123" into parts.
 func parseEntityArg(arg string) (entityType string, id int, err error) {
 	parts := strings.SplitN(arg, "/", 2)
@@ -200,15 +604,54 @@ func parseEntityArg(arg string) (entityType string, id int, err error) {
 }
 
 // printResponse handles output for any v2 response (single entity or collection).
-func printResponse(cmd *cli.Command, data []byte) error {
+func printResponse(cmd *cli.Command, data []byte, width int, warn io.Writer) error {
 	// Parse once into a generic structure.
 	var parsed map[string]any
 	if err := json.Unmarshal(data, &parsed); err != nil {
 		return fmt.Errorf("parsing response: %w", err)
 	}
+	return printParsed(cmd, parsed, width, warn)
+}
 
-	if cmdutil.IsJSON(cmd) {
-		return output.PrintJSON(os.Stdout, parsed)
+// collectionItems returns the object items of a parsed v2 collection response.
+func collectionItems(parsed map[string]any) []map[string]any {
+	items, _ := parsed["items"].([]any)
+	itemMaps := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]any); ok {
+			itemMaps = append(itemMaps, m)
+		}
+	}
+	return itemMaps
+}
+
+// printParsed prints an already-decoded v2 response. width is the column
+// budget for tables, or 0 if unknown; notes and warnings go to warn.
+func printParsed(cmd *cli.Command, parsed map[string]any, width int, warn io.Writer) error {
+	if cmd.Bool("flatten") {
+		parsed = flattenResponse(parsed)
+	}
+
+	if cmd.String("output") == "parquet" {
+		return writeParquetFile(cmd.String("out"), parsed, warn)
+	}
+
+	if cmdutil.IsStructured(cmd) {
+		if cmd.Bool("with-schema") {
+			parsed["schema"] = output.InferSchema(collectionItems(parsed), output.SchemaSampleSize)
+		}
+		return cmdutil.PrintStructured(cmd, parsed)
+	}
+
+	if cmdutil.IsJSONL(cmd) {
+		if _, ok := parsed["items"]; ok {
+			return output.PrintJSONLines(os.Stdout, collectionItems(parsed))
+		}
+		return output.PrintJSONLines(os.Stdout, []map[string]any{parsed})
+	}
+
+	if cmd.String("output") == "tsv" {
+		return printTSV(cmd, parsed, warn)
 	}
 
 	// Check if it looks like a collection response (has "items" key).
@@ -218,13 +661,16 @@ func printResponse(cmd *cli.Command, data []byte) error {
 				fmt.Fprintln(os.Stdout, "No results found.")
 				return nil
 			}
-			itemMaps := make([]map[string]any, 0, len(items))
-			for _, item := range items {
-				if m, ok := item.(map[string]any); ok {
-					itemMaps = append(itemMaps, m)
+			itemMaps := collectionItems(parsed)
+			cols := tableColumns(itemMaps, splitColumns(cmd.String("columns-order")), cmd.Bool("omit-empty-columns"), warn)
+			if transpose(cmd.Bool("transpose"), itemMaps, cols, width) {
+				if len(itemMaps) > 1 {
+					fmt.Fprintf(warn, "Showing the first of %d results (--transpose)\n", len(itemMaps))
 				}
+				printTransposed(os.Stdout, itemMaps[0], cols)
+				return nil
 			}
-			printDynamicTable(itemMaps)
+			printDynamicTable(itemMaps, cols)
 			return nil
 		}
 	}
@@ -234,23 +680,93 @@ func printResponse(cmd *cli.Command, data []byte) error {
 	return nil
 }
 
-// printDynamicTable prints items as a table, deriving columns from the data.
-func printDynamicTable(items []map[string]any) {
-	colSet := make(map[string]bool)
-	var cols []string
+// printJSONLines writes one page of streamed items as JSON Lines, applying
+// --flatten per item.
+func printJSONLines(cmd *cli.Command, items []map[string]any) error {
+	if cmd.Bool("flatten") {
+		flat := make([]map[string]any, len(items))
+		for i, item := range items {
+			flat[i] = output.Flatten(item)
+		}
+		items = flat
+	}
+	return output.PrintJSONLines(os.Stdout, items)
+}
+
+// flattenResponse flattens each item of a collection response, or the
+// entity itself for a single-entity response.
+func flattenResponse(parsed map[string]any) map[string]any {
+	rawItems, ok := parsed["items"].([]any)
+	if !ok {
+		return output.Flatten(parsed)
+	}
+	flat := make([]any, len(rawItems))
+	for i, item := range rawItems {
+		if m, ok := item.(map[string]any); ok {
+			flat[i] = output.Flatten(m)
+		} else {
+			flat[i] = item
+		}
+	}
+	result := make(map[string]any, len(parsed))
+	for k, v := range parsed {
+		result[k] = v
+	}
+	result["items"] = flat
+	return result
+}
+
+// writeParquetFile writes the response items (or the single entity) to path as Parquet.
+func writeParquetFile(path string, parsed map[string]any, warn io.Writer) (err error) {
+	items := []map[string]any{parsed}
+	if _, ok := parsed["items"]; ok {
+		items = collectionItems(parsed)
+	}
 	for _, item := range items {
-		for key := range item {
-			if key == "resourceType" {
-				continue
-			}
-			if !colSet[key] {
-				colSet[key] = true
-				cols = append(cols, key)
-			}
+		delete(item, "resourceType")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating output file: %w", err)
+	}
+	defer func() {
+		if cErr := file.Close(); cErr != nil && err == nil {
+			err = fmt.Errorf("closing output file: %w", cErr)
 		}
+	}()
+
+	if err := output.WriteParquet(file, items); err != nil {
+		os.Remove(path) //nolint:errcheck,gosec // best-effort cleanup of a partial file
+		return err
 	}
-	sort.Strings(cols)
+	fmt.Fprintf(warn, "Wrote %d rows to %s\n", len(items), path)
+	return nil
+}
 
+// printTSV writes the response as tab-separated values, with the same
+// columns the text table would show. No results print nothing.
+func printTSV(cmd *cli.Command, parsed map[string]any, warn io.Writer) error {
+	items := []map[string]any{parsed}
+	if _, ok := parsed["items"]; ok {
+		items = collectionItems(parsed)
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	cols := tableColumns(items, splitColumns(cmd.String("columns-order")), cmd.Bool("omit-empty-columns"), warn)
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		rows[i] = make([]string, len(cols))
+		for j, col := range cols {
+			rows[i][j] = formatValue(item[col])
+		}
+	}
+	return output.WriteTSV(os.Stdout, cols, rows)
+}
+
+// printDynamicTable prints items as a table with the given columns.
+func printDynamicTable(items []map[string]any, cols []string) {
 	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
 	headers := make([]string, len(cols))
@@ -269,6 +785,107 @@ func printDynamicTable(items []map[string]any) {
 	tw.Flush()
 }
 
+// tableColumns derives table columns from the data. With omitEmpty, columns
+// that are blank in every row are left out. Columns named in order come
+// first, in that order; the rest follow sorted. Unknown names are reported
+// on warn.
+func tableColumns(items []map[string]any, order []string, omitEmpty bool, warn io.Writer) []string {
+	colSet := make(map[string]bool)
+	var cols []string
+	for _, item := range items {
+		for key := range item {
+			if key == "resourceType" {
+				continue
+			}
+			if !colSet[key] {
+				colSet[key] = true
+				cols = append(cols, key)
+			}
+		}
+	}
+	sort.Strings(cols)
+	if omitEmpty {
+		cols = nonEmptyColumns(cols, items)
+	}
+	return orderColumns(cols, order, warn)
+}
+
+// nonEmptyColumns keeps the columns that have a non-empty value in at least
+// one item.
+func nonEmptyColumns(cols []string, items []map[string]any) []string {
+	kept := make([]string, 0, len(cols))
+	for _, col := range cols {
+		for _, item := range items {
+			if !isEmptyValue(item[col]) {
+				kept = append(kept, col)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// isEmptyValue reports whether v is null, an empty string, or an empty
+// collection or object.
+func isEmptyValue(v any) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(val) == ""
+	case []any:
+		return len(val) == 0
+	case map[string]any:
+		return len(val) == 0
+	default:
+		return false
+	}
+}
+
+// orderColumns moves the columns named in order (case-insensitive) to the
+// front, keeping the remaining columns in their existing order. Names that
+// match no column are reported on warn and skipped.
+func orderColumns(cols, order []string, warn io.Writer) []string {
+	if len(order) == 0 {
+		return cols
+	}
+	byLower := make(map[string]string, len(cols))
+	for _, c := range cols {
+		byLower[strings.ToLower(c)] = c
+	}
+
+	placed := make(map[string]bool, len(order))
+	result := make([]string, 0, len(cols))
+	for _, name := range order {
+		col, ok := byLower[strings.ToLower(name)]
+		if !ok {
+			fmt.Fprintf(warn, "Warning: --columns-order: no column %q in the results\n", name)
+			continue
+		}
+		if !placed[col] {
+			placed[col] = true
+			result = append(result, col)
+		}
+	}
+	for _, c := range cols {
+		if !placed[c] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// splitColumns parses a comma-separated column list, dropping empty entries.
+func splitColumns(s string) []string {
+	var cols []string
+	for _, c := range strings.Split(s, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			cols = append(cols, c)
+		}
+	}
+	return cols
+}
+
 // formatValue converts a value to a display string.
 func formatValue(v any) string {
 	if v == nil {
@@ -0,0 +1,167 @@
+package query
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestOrderColumns(t *testing.T) {
+	cols := []string{"effort", "id", "name", "state"}
+	tests := []struct {
+		order string
+		want  string
+	}{
+		{"", "effort,id,name,state"},
+		{"state,id", "state,id,effort,name"},
+		{"NAME, missing ,id,name", "name,id,effort,state"},
+	}
+	for _, tt := range tests {
+		got := orderColumns(cols, splitColumns(tt.order), io.Discard)
+		if strings.Join(got, ",") != tt.want {
+			t.Errorf("orderColumns(%q) = %v, want %s", tt.order, got, tt.want)
+		}
+	}
+}
+
+func TestCountAssertion(t *testing.T) {
+	tests := []struct {
+		expr  string
+		count int
+		pass  bool
+	}{
+		{"count>=1", 1, true},
+		{"count>=1", 0, false},
+		{"count == 0", 0, true},
+		{"count==5", 4, false},
+		{"count!=0", 3, true},
+		{"count<10", 10, false},
+		{"count<=10", 10, true},
+		{"count>2", 3, true},
+	}
+	for _, tt := range tests {
+		a, err := parseAssertion(tt.expr)
+		if err != nil {
+			t.Fatalf("parseAssertion(%q) error = %v", tt.expr, err)
+		}
+		err = a.check(tt.count)
+		if (err == nil) != tt.pass {
+			t.Errorf("%q with count %d: err = %v, want pass=%v", tt.expr, tt.count, err, tt.pass)
+		}
+		if err != nil && !strings.Contains(err.Error(), "actual count") {
+			t.Errorf("failure should report the actual count, got %q", err)
+		}
+	}
+
+	for _, bad := range []string{"", "count", "count=1", "size>1", "count>=-1"} {
+		if _, err := parseAssertion(bad); err == nil {
+			t.Errorf("parseAssertion(%q) should fail", bad)
+		}
+	}
+}
+
+func TestBuildGroupSummary(t *testing.T) {
+	items := []map[string]any{
+		{"id": float64(1), "effort": float64(3), "groupKey": "Open"},
+		{"id": float64(2), "effort": float64(2), "groupKey": "Done"},
+		{"id": float64(3), "effort": 1.5, "groupKey": "Open"},
+		{"id": float64(4), "effort": nil, "groupKey": nil},
+	}
+
+	groups, total := buildGroupSummary(items)
+	if len(groups) != 3 {
+		t.Fatalf("got %d groups, want 3", len(groups))
+	}
+	if groups[0].Value != "Open" || groups[0].Count != 2 || groups[0].Effort != 4.5 {
+		t.Errorf("first group = %+v, want Open with 2 items and 4.5 effort", *groups[0])
+	}
+	if groups[2].Value != noGroupName {
+		t.Errorf("null group should sort last here and be labeled %q, got %q", noGroupName, groups[2].Value)
+	}
+	if total.Count != 4 || total.Effort != 6.5 {
+		t.Errorf("total = %+v, want 4 items and 6.5 effort", total)
+	}
+}
+
+func TestBuildDistinct(t *testing.T) {
+	items := []map[string]any{
+		{"id": float64(1), "distinctValue": "Open"},
+		{"id": float64(2), "distinctValue": map[string]any{"id": float64(5), "name": "Done"}},
+		{"id": float64(3), "distinctValue": "Open"},
+		{"id": float64(4), "distinctValue": nil},
+		{"id": float64(5), "distinctValue": float64(3)},
+	}
+
+	want := []distinctValue{{noGroupName, 1}, {"3", 1}, {"Done", 1}, {"Open", 2}}
+	got := buildDistinct(items)
+	if len(got) != len(want) {
+		t.Fatalf("buildDistinct() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("buildDistinct()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNonEmptyColumns(t *testing.T) {
+	items := []map[string]any{
+		{"id": float64(1), "name": "a", "tags": "", "owner": nil, "effort": float64(0), "list": []any{}, "obj": map[string]any{}},
+		{"id": float64(2), "name": "b", "tags": " ", "owner": nil, "effort": nil, "list": []any{}, "obj": map[string]any{"name": "x"}},
+	}
+	cols := []string{"effort", "id", "list", "name", "obj", "owner", "tags"}
+	got := nonEmptyColumns(cols, items)
+	want := []string{"effort", "id", "name", "obj"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("nonEmptyColumns() = %v, want %v", got, want)
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	item := map[string]any{"id": float64(7), "name": "Login page", "owner": map[string]any{"name": "Ann"}, "effort": nil}
+	cols := []string{"id", "name", "owner"}
+
+	if got := rowWidth(item, cols); got != len("id")+2+len("Login page")+2+len("owner")+2 {
+		t.Errorf("rowWidth() = %d", got)
+	}
+	if !transpose(true, []map[string]any{item, item}, cols, 0) {
+		t.Error("transpose(force) = false, want true")
+	}
+	if transpose(true, nil, cols, 0) {
+		t.Error("transpose(force) with no items = true, want false")
+	}
+	if !transpose(false, []map[string]any{item}, cols, 20) {
+		t.Error("transpose() of a wide single row = false, want true")
+	}
+	if transpose(false, []map[string]any{item}, cols, 0) || transpose(false, []map[string]any{item}, cols, 200) {
+		t.Error("transpose() with unknown or ample width = true, want false")
+	}
+
+	var buf bytes.Buffer
+	printTransposed(&buf, item, cols)
+	want := "id:     7\nname:   Login page\nowner:  Ann\n"
+	if buf.String() != want {
+		t.Errorf("printTransposed() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestIncludeSelect(t *testing.T) {
+	kinds := fieldKinds{"owner": "reference", "entitystate": "reference", "tasks": "collection"}
+
+	got, err := includeSelect("id,name", []string{"Owner", "Tasks", "entityState"}, kinds)
+	if err != nil {
+		t.Fatalf("includeSelect() error = %v", err)
+	}
+	want := "id,name,owner.name as owner,tasks.count as tasksCount,entityState.name as entityState"
+	if got != want {
+		t.Errorf("includeSelect() = %q, want %q", got, want)
+	}
+
+	if got, _ := includeSelect("", []string{"Project"}, nil); got != "id,name,project.name as project" {
+		t.Errorf("includeSelect() without metadata = %q", got)
+	}
+	if _, err := includeSelect("id", []string{"Bogus"}, kinds); err == nil {
+		t.Error("expected error for a field that is not a reference or collection")
+	}
+}
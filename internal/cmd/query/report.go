@@ -0,0 +1,160 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+	"github.com/lifedraft/targetprocess-cli/internal/output"
+)
+
+// assigneeReportSelect projects each item's effort and the users assigned to it.
+const assigneeReportSelect = "id,name,effort," +
+	"assignments.select({generalUser.id as id,generalUser.firstName as firstName,generalUser.lastName as lastName}) as assignees"
+
+// unassignedName labels the bucket for items with no assignments.
+const unassignedName = "(unassigned)"
+
+// assigneeLoad is one person's share of the workload.
+type assigneeLoad struct {
+	ID     int          `json:"id,omitempty"`
+	Name   string       `json:"name"`
+	Count  int          `json:"count"`
+	Effort float64      `json:"effort"`
+	Items  []reportItem `json:"items"`
+}
+
+type reportItem struct {
+	ID     int     `json:"id"`
+	Name   string  `json:"name"`
+	Effort float64 `json:"effort"`
+}
+
+// buildAssigneeReport groups items by assignee. An item with several
+// assignees counts toward each of them with its full effort. The result is
+// sorted by effort, then item count, descending.
+func buildAssigneeReport(items []map[string]any) []*assigneeLoad {
+	byKey := make(map[string]*assigneeLoad)
+	var loads []*assigneeLoad
+	bucket := func(key string, id int, name string) *assigneeLoad {
+		l, ok := byKey[key]
+		if !ok {
+			l = &assigneeLoad{ID: id, Name: name, Items: []reportItem{}}
+			byKey[key] = l
+			loads = append(loads, l)
+		}
+		return l
+	}
+
+	for _, item := range items {
+		ri := reportItem{
+			ID:     toInt(item["id"]),
+			Name:   fmt.Sprintf("%v", item["name"]),
+			Effort: toFloat(item["effort"]),
+		}
+
+		assignees := assigneeList(item["assignees"])
+		if len(assignees) == 0 {
+			l := bucket(unassignedName, 0, unassignedName)
+			l.Count++
+			l.Effort += ri.Effort
+			l.Items = append(l.Items, ri)
+			continue
+		}
+		for _, a := range assignees {
+			id := toInt(a["id"])
+			name := strings.TrimSpace(fmt.Sprintf("%v %v", valueOrEmpty(a["firstName"]), valueOrEmpty(a["lastName"])))
+			if name == "" {
+				name = fmt.Sprintf("user %d", id)
+			}
+			l := bucket(fmt.Sprintf("user:%d", id), id, name)
+			l.Count++
+			l.Effort += ri.Effort
+			l.Items = append(l.Items, ri)
+		}
+	}
+
+	sort.SliceStable(loads, func(i, j int) bool {
+		if loads[i].Effort != loads[j].Effort {
+			return loads[i].Effort > loads[j].Effort
+		}
+		return loads[i].Count > loads[j].Count
+	})
+	return loads
+}
+
+// assigneeList extracts assignee objects from a v2 nested collection, which
+// may arrive either as a bare array or wrapped in {"items": [...]}.
+func assigneeList(v any) []map[string]any {
+	if m, ok := v.(map[string]any); ok {
+		v = m["items"]
+	}
+	raw, _ := v.([]any)
+	list := make([]map[string]any, 0, len(raw))
+	for _, r := range raw {
+		if m, ok := r.(map[string]any); ok {
+			list = append(list, m)
+		}
+	}
+	return list
+}
+
+func printAssigneeReport(cmd *cli.Command, items []map[string]any) error {
+	loads := buildAssigneeReport(items)
+
+	if cmdutil.IsStructured(cmd) {
+		return cmdutil.PrintStructured(cmd, map[string]any{
+			"assignees":  loads,
+			"totalItems": len(items),
+		})
+	}
+
+	if cmdutil.IsJSONL(cmd) {
+		enc := json.NewEncoder(os.Stdout)
+		for _, l := range loads {
+			if err := enc.Encode(l); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(loads) == 0 {
+		fmt.Fprintln(os.Stdout, "No results found.")
+		return nil
+	}
+
+	tw := output.NewTabWriter(os.Stdout)
+	fmt.Fprintln(tw, "ASSIGNEE\tITEMS\tEFFORT")
+	for _, l := range loads {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", l.Name, l.Count, formatValue(l.Effort))
+	}
+	fmt.Fprintf(tw, "TOTAL\t%d\t\n", len(items))
+	return tw.Flush()
+}
+
+func toInt(v any) int {
+	if f, ok := v.(float64); ok {
+		return int(f)
+	}
+	return 0
+}
+
+func toFloat(v any) float64 {
+	if f, ok := v.(float64); ok {
+		return f
+	}
+	return 0
+}
+
+func valueOrEmpty(v any) any {
+	if v == nil {
+		return ""
+	}
+	return v
+}
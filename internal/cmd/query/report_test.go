@@ -0,0 +1,72 @@
+package query
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestBuildAssigneeReport(t *testing.T) {
+	type load struct {
+		Name   string
+		Count  int
+		Effort float64
+		Items  []int
+	}
+	tests := []struct {
+		name  string
+		items string
+		want  []load
+	}{
+		{
+			name:  "unassigned items share one bucket",
+			items: `[{"id":1,"name":"a","effort":2},{"id":2,"name":"b","effort":null,"assignees":{"items":[]}}]`,
+			want:  []load{{Name: unassignedName, Count: 2, Effort: 2, Items: []int{1, 2}}},
+		},
+		{
+			name: "several assignees each get the full effort",
+			items: `[{"id":1,"name":"a","effort":5,"assignees":[
+				{"id":7,"firstName":"Anna","lastName":"Schmidt"},{"id":8,"firstName":"Tom","lastName":"Baker"}]}]`,
+			want: []load{
+				{Name: "Anna Schmidt", Count: 1, Effort: 5, Items: []int{1}},
+				{Name: "Tom Baker", Count: 1, Effort: 5, Items: []int{1}},
+			},
+		},
+		{
+			name: "efforts sum per person, sorted by effort then count",
+			items: `[
+				{"id":1,"name":"a","effort":3,"assignees":{"items":[{"id":7,"firstName":"Anna","lastName":"Schmidt"}]}},
+				{"id":2,"name":"b","effort":4.5,"assignees":{"items":[{"id":7,"firstName":"Anna","lastName":"Schmidt"}]}},
+				{"id":3,"name":"c","effort":1,"assignees":{"items":[{"id":9}]}},
+				{"id":4,"name":"d","effort":0,"assignees":{"items":[{"id":9}]}},
+				{"id":5,"name":"e","effort":1}]`,
+			want: []load{
+				{Name: "Anna Schmidt", Count: 2, Effort: 7.5, Items: []int{1, 2}},
+				{Name: "user 9", Count: 2, Effort: 1, Items: []int{3, 4}},
+				{Name: unassignedName, Count: 1, Effort: 1, Items: []int{5}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var items []map[string]any
+			if err := json.Unmarshal([]byte(tt.items), &items); err != nil {
+				t.Fatal(err)
+			}
+			got := buildAssigneeReport(items)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d assignees, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, w := range tt.want {
+				g := got[i]
+				var ids []int
+				for _, it := range g.Items {
+					ids = append(ids, it.ID)
+				}
+				if g.Name != w.Name || g.Count != w.Count || g.Effort != w.Effort || !slices.Equal(ids, w.Items) {
+					t.Errorf("assignee %d = {%s %d %v %v}, want %+v", i, g.Name, g.Count, g.Effort, ids, w)
+				}
+			}
+		})
+	}
+}
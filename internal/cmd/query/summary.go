@@ -0,0 +1,110 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+	"github.com/lifedraft/targetprocess-cli/internal/output"
+)
+
+// groupKeyAlias is the select alias the --group-summary field is fetched under.
+const groupKeyAlias = "groupKey"
+
+// reportPageSize is the page size the report modes (--group-summary,
+// --distinct, --group-by, --as-assignee-report) fetch with when --take is not
+// set; they always read every page.
+const reportPageSize = 1000
+
+// noGroupName labels the bucket for items whose group field is null.
+const noGroupName = "(none)"
+
+// groupSummarySelect projects the group field and effort for --group-summary.
+func groupSummarySelect(field string) string {
+	return fmt.Sprintf("id,effort,%s as %s", field, groupKeyAlias)
+}
+
+// groupTotal is the item count and effort sum for one group value.
+type groupTotal struct {
+	Value  string  `json:"value"`
+	Count  int     `json:"count"`
+	Effort float64 `json:"effort"`
+}
+
+// buildGroupSummary groups items by their groupKey value, sorted by count
+// then effort, descending, with ties broken by value. It also returns the
+// grand total across all groups.
+func buildGroupSummary(items []map[string]any) ([]*groupTotal, groupTotal) {
+	byValue := make(map[string]*groupTotal)
+	var groups []*groupTotal
+	total := groupTotal{Value: "TOTAL"}
+
+	for _, item := range items {
+		value := noGroupName
+		if v := item[groupKeyAlias]; v != nil {
+			value = formatValue(v)
+		}
+		g, ok := byValue[value]
+		if !ok {
+			g = &groupTotal{Value: value}
+			byValue[value] = g
+			groups = append(groups, g)
+		}
+		effort := toFloat(item["effort"])
+		g.Count++
+		g.Effort += effort
+		total.Count++
+		total.Effort += effort
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		if groups[i].Effort != groups[j].Effort {
+			return groups[i].Effort > groups[j].Effort
+		}
+		return groups[i].Value < groups[j].Value
+	})
+	return groups, total
+}
+
+func printGroupSummary(cmd *cli.Command, field string, items []map[string]any) error {
+	groups, total := buildGroupSummary(items)
+
+	if cmdutil.IsStructured(cmd) {
+		return cmdutil.PrintStructured(cmd, map[string]any{
+			"groupBy": field,
+			"groups":  groups,
+			"total":   map[string]any{"count": total.Count, "effort": total.Effort},
+		})
+	}
+
+	if cmdutil.IsJSONL(cmd) {
+		enc := json.NewEncoder(os.Stdout)
+		for _, g := range groups {
+			if err := enc.Encode(g); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(groups) == 0 {
+		fmt.Fprintln(os.Stdout, "No results found.")
+		return nil
+	}
+
+	tw := output.NewTabWriter(os.Stdout)
+	fmt.Fprintf(tw, "%s\tCOUNT\tEFFORT\n", strings.ToUpper(field))
+	for _, g := range groups {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", g.Value, g.Count, formatValue(g.Effort))
+	}
+	fmt.Fprintf(tw, "TOTAL\t%d\t%s\n", total.Count, formatValue(total.Effort))
+	return tw.Flush()
+}
@@ -0,0 +1,45 @@
+package query
+
+import (
+	"io"
+	"strings"
+
+	"github.com/lifedraft/targetprocess-cli/internal/output"
+)
+
+// transpose reports whether a collection should be shown vertically: always
+// when forced, otherwise only for a single result whose one-row table would
+// be wider than width. With an unknown width (0) the table is kept, so piped
+// output stays stable.
+func transpose(force bool, items []map[string]any, cols []string, width int) bool {
+	if force {
+		return len(items) > 0
+	}
+	if len(items) != 1 {
+		return false
+	}
+	if width <= 0 {
+		return false
+	}
+	return rowWidth(items[0], cols) > width
+}
+
+// rowWidth is the width of a one-row table for item as printDynamicTable
+// lays it out: each column as wide as its header or value, plus padding.
+func rowWidth(item map[string]any, cols []string) int {
+	width := 0
+	for _, col := range cols {
+		width += max(len(col), len(formatValue(item[col]))) + 2
+	}
+	return width
+}
+
+// printTransposed prints item's columns as field/value lines using the same
+// layout as a single entity.
+func printTransposed(w io.Writer, item map[string]any, cols []string) {
+	fields := make(map[string]any, len(cols))
+	for _, col := range cols {
+		fields[col] = strings.ReplaceAll(formatValue(item[col]), "\n", " ")
+	}
+	output.PrintEntity(w, fields)
+}
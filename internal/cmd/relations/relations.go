@@ -0,0 +1,157 @@
+package relations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+	"github.com/lifedraft/targetprocess-cli/internal/output"
+)
+
+// NewRelateCmd creates the "relate" command.
+func NewRelateCmd(f *cmdutil.Factory) *cli.Command {
+	return &cli.Command{
+		Name:      "relate",
+		Usage:     "Link two entities with a relation (blocks, relates to, ...)",
+		ArgsUsage: "<from-id> <to-id>",
+		UsageText: `# 123 blocks 456
+  tp relate 123 456 --type Blocker
+
+  # Verb forms work too
+  tp relate 123 456 --type blocks
+
+  # A plain "relates to" link
+  tp relate 123 456
+
+  # Show the request without creating anything
+  tp relate 123 456 --type Dependency --dry-run`,
+		Description: `The first entity is the relation's master and the second its slave: with
+--type Blocker, <from-id> blocks <to-id>. --type takes a relation type name
+(case-insensitive), a verb form such as "blocks" or "depends on", or an ID.`,
+		Flags: []cli.Flag{
+			cmdutil.OutputFlag(),
+			&cli.StringFlag{Name: "type", Value: "Relation", Usage: "Relation type name or ID (e.g. Blocker, Dependency, Relation)"},
+			&cli.BoolFlag{Name: "dry-run", Usage: "Print the request that would be sent (method, path, JSON body) without sending it"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			args := cmd.Args().Slice()
+			if len(args) != 2 {
+				return errors.New("two entity IDs are required; usage: tp relate <from-id> <to-id>")
+			}
+			fromID, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			toID, err := parseID(args[1])
+			if err != nil {
+				return err
+			}
+			if fromID == toID {
+				return errors.New("an entity cannot be related to itself")
+			}
+
+			client, err := f.Client()
+			if err != nil {
+				return err
+			}
+
+			typeID, err := client.ResolveRelationType(ctx, cmd.String("type"))
+			if err != nil {
+				return err
+			}
+
+			if cmd.Bool("dry-run") {
+				return cmdutil.PrintDryRun(os.Stdout, cmd, http.MethodPost, api.EntityPath("Relation", 0), api.RelationFields(fromID, toID, typeID))
+			}
+
+			entity, err := client.CreateRelation(ctx, fromID, toID, typeID)
+			if err != nil {
+				return err
+			}
+
+			if cmdutil.IsStructured(cmd) {
+				return cmdutil.PrintStructured(cmd, entity)
+			}
+
+			output.PrintEntity(os.Stdout, entity)
+			return nil
+		},
+	}
+}
+
+// NewListCmd creates the "relations" command.
+func NewListCmd(f *cmdutil.Factory) *cli.Command {
+	return &cli.Command{
+		Name:      "relations",
+		Usage:     "List the relations of an entity",
+		ArgsUsage: "<id>",
+		UsageText: `# What 123 blocks, depends on, or relates to, and what points at it
+  tp relations 123
+
+  # As JSON, one object per relation with its direction
+  tp relations 123 -o json`,
+		Description: `Outbound relations are those where the entity is the master (it blocks or is
+depended on by the other entity); inbound ones point at it.`,
+		Flags: []cli.Flag{
+			cmdutil.OutputFlag(),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 1 {
+				return errors.New("entity ID is required; usage: tp relations <id>")
+			}
+			id, err := parseID(cmd.Args().First())
+			if err != nil {
+				return err
+			}
+
+			client, err := f.Client()
+			if err != nil {
+				return err
+			}
+			list, err := client.ListRelations(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			if cmdutil.IsStructured(cmd) {
+				return cmdutil.PrintStructured(cmd, map[string]any{
+					"relations": list,
+					"count":     len(list),
+				})
+			}
+
+			if len(list) == 0 {
+				fmt.Fprintf(os.Stdout, "#%d has no relations.\n", id)
+				return nil
+			}
+			tw := output.NewTabWriter(os.Stdout)
+			fmt.Fprintln(tw, "ID\tDIRECTION\tTYPE\tENTITY\tNAME")
+			for _, r := range list {
+				arrow := "→"
+				if r.Direction == api.RelationInbound {
+					arrow = "←"
+				}
+				fmt.Fprintf(tw, "%d\t%s %s\t%s\t%s #%d\t%s\n", r.ID, arrow, r.Direction, r.Type, r.Entity.Type, r.Entity.ID, r.Entity.Name)
+			}
+			return tw.Flush()
+		},
+	}
+}
+
+func parseID(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid entity ID %q: must be an integer", s)
+	}
+	if id <= 0 {
+		return 0, fmt.Errorf("entity ID must be positive, got %d", id)
+	}
+	return id, nil
+}
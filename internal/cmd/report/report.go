@@ -0,0 +1,245 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+	"github.com/lifedraft/targetprocess-cli/internal/output"
+	"github.com/lifedraft/targetprocess-cli/internal/resolve"
+)
+
+// Select aliases the group and metric fields are fetched under.
+const (
+	groupAlias  = "groupKey"
+	metricAlias = "metricValue"
+)
+
+// noGroupName labels the bucket for items whose group field is null.
+const noGroupName = "(none)"
+
+// metricRe matches sum(field) and avg(field).
+var metricRe = regexp.MustCompile(`^(sum|avg)\(\s*([A-Za-z][A-Za-z0-9.]*)\s*\)$`)
+
+// metric is a parsed --metric: count, or sum/avg over a field path.
+type metric struct {
+	Fn    string // "count", "sum", or "avg"
+	Field string // empty for count
+}
+
+func (m metric) String() string {
+	if m.Fn == "count" {
+		return "count"
+	}
+	return fmt.Sprintf("%s(%s)", m.Fn, m.Field)
+}
+
+func parseMetric(s string) (metric, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "count" {
+		return metric{Fn: "count"}, nil
+	}
+	m := metricRe.FindStringSubmatch(s)
+	if m == nil {
+		return metric{}, fmt.Errorf("invalid metric %q: use count, sum(field), or avg(field)", s)
+	}
+	return metric{Fn: m[1], Field: m[2]}, nil
+}
+
+// group is one row of the report.
+type group struct {
+	Group string  `json:"group"`
+	Value float64 `json:"value"`
+	Count int     `json:"count"`
+
+	sum float64
+}
+
+// NewCmd creates the "report" command.
+func NewCmd(f *cmdutil.Factory) *cli.Command {
+	return &cli.Command{
+		Name:      "report",
+		Usage:     "Group entities client-side and aggregate a metric per group",
+		ArgsUsage: "<Type>",
+		UsageText: `# Open bugs by state
+  tp report Bug --group-by entityState.name --metric count -w 'entityState.isFinal!=true'
+
+  # Total effort per assignee team
+  tp report UserStory --group-by team.name --metric 'sum(effort)'
+
+  # Average effort per feature
+  tp report UserStory --group-by feature.name --metric 'avg(effort)'`,
+		Description: `Fetches every matching entity and groups it locally, avoiding the v2
+API's unreliable top-level groupBy. Items whose group field is null are
+reported as "(none)". sum and avg skip items where the field is null.`,
+		Flags: []cli.Flag{
+			cmdutil.OutputFlag(),
+			&cli.StringFlag{Name: "group-by", Required: true, Usage: "Field path to group on (e.g. entityState.name)"},
+			&cli.StringFlag{Name: "metric", Value: "count", Usage: "count, sum(field), or avg(field)"},
+			&cli.StringFlag{Name: "where", Aliases: []string{"w"}, Usage: "v2 filter selecting the entities"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() == 0 {
+				return errors.New("entity type is required; usage: tp report <Type> --group-by <field>")
+			}
+			entityType := resolve.EntityType(cmd.Args().First())
+			if err := api.ValidateEntityType(entityType); err != nil {
+				return err
+			}
+			m, err := parseMetric(cmd.String("metric"))
+			if err != nil {
+				return err
+			}
+			groupBy := strings.TrimSpace(cmd.String("group-by"))
+
+			client, err := f.Client()
+			if err != nil {
+				return err
+			}
+
+			params := api.V2Params{
+				Where:  cmd.String("where"),
+				Select: reportSelect(groupBy, m),
+				Take:   1000,
+			}
+			items, err := f.FetchAll(ctx, client, entityType, params)
+			if err != nil {
+				return api.EnhanceError(err, "/api/v2/"+entityType, map[string]string{
+					"where":  params.Where,
+					"select": params.Select,
+				})
+			}
+
+			return printReport(cmd, groupBy, m, aggregate(items, m))
+		},
+	}
+}
+
+// CountSelect is the select PrintCounts expects: the ID and the groupBy field.
+func CountSelect(groupBy string) string {
+	return reportSelect(groupBy, metric{Fn: "count"})
+}
+
+// PrintCounts groups items fetched with CountSelect and prints how many fall
+// in each group, as tp report --metric count does. tp query --group-by uses
+// it so both commands bucket, label, and sort the same way.
+func PrintCounts(cmd *cli.Command, groupBy string, items []api.Entity) error {
+	m := metric{Fn: "count"}
+	return printReport(cmd, groupBy, m, aggregate(items, m))
+}
+
+// reportSelect fetches only the group field and, for sum/avg, the metric field.
+func reportSelect(groupBy string, m metric) string {
+	sel := fmt.Sprintf("id,%s as %s", groupBy, groupAlias)
+	if m.Field != "" {
+		sel += fmt.Sprintf(",%s as %s", m.Field, metricAlias)
+	}
+	return sel
+}
+
+// aggregate groups items by their group value and computes the metric,
+// sorted by value descending, then group name.
+func aggregate(items []api.Entity, m metric) []*group {
+	byName := make(map[string]*group)
+	var groups []*group
+	for _, item := range items {
+		name := groupName(item[groupAlias])
+		g, ok := byName[name]
+		if !ok {
+			g = &group{Group: name}
+			byName[name] = g
+			groups = append(groups, g)
+		}
+		if m.Fn == "count" {
+			g.Count++
+			continue
+		}
+		if v, ok := item[metricAlias].(float64); ok {
+			g.Count++
+			g.sum += v
+		}
+	}
+
+	for _, g := range groups {
+		switch m.Fn {
+		case "count":
+			g.Value = float64(g.Count)
+		case "sum":
+			g.Value = g.sum
+		case "avg":
+			if g.Count > 0 {
+				g.Value = g.sum / float64(g.Count)
+			}
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Value != groups[j].Value {
+			return groups[i].Value > groups[j].Value
+		}
+		return groups[i].Group < groups[j].Group
+	})
+	return groups
+}
+
+// groupName renders a group value; nested objects use their name.
+func groupName(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return noGroupName
+	case string:
+		if val == "" {
+			return noGroupName
+		}
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case map[string]any:
+		if name, ok := val["name"]; ok {
+			return groupName(name)
+		}
+	}
+	return fmt.Sprint(v)
+}
+
+func printReport(cmd *cli.Command, groupBy string, m metric, groups []*group) error {
+	if cmdutil.IsStructured(cmd) {
+		return cmdutil.PrintStructured(cmd, map[string]any{
+			"groupBy": groupBy,
+			"metric":  m.String(),
+			"groups":  groups,
+		})
+	}
+
+	if cmdutil.IsJSONL(cmd) {
+		enc := json.NewEncoder(os.Stdout)
+		for _, g := range groups {
+			if err := enc.Encode(g); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(groups) == 0 {
+		fmt.Fprintln(os.Stdout, "No results found.")
+		return nil
+	}
+	tw := output.NewTabWriter(os.Stdout)
+	fmt.Fprintf(tw, "%s\t%s\n", strings.ToUpper(groupBy), strings.ToUpper(m.String()))
+	for _, g := range groups {
+		fmt.Fprintf(tw, "%s\t%s\n", g.Group, strconv.FormatFloat(math.Round(g.Value*100)/100, 'f', -1, 64))
+	}
+	return tw.Flush()
+}
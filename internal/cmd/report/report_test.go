@@ -0,0 +1,83 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/testutil"
+)
+
+func TestParseMetric(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    metric
+		wantErr bool
+	}{
+		{"count", metric{Fn: "count"}, false},
+		{"", metric{Fn: "count"}, false},
+		{"sum(effort)", metric{Fn: "sum", Field: "effort"}, false},
+		{"avg( timeSpent )", metric{Fn: "avg", Field: "timeSpent"}, false},
+		{"max(effort)", metric{}, true},
+		{"sum()", metric{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseMetric(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseMetric(%q) = %+v, %v; want %+v, err=%v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestAggregate_FromSimulation(t *testing.T) {
+	body, err := json.Marshal(map[string]any{"items": []map[string]any{
+		{"id": 1, "groupKey": "Open", "metricValue": 3},
+		{"id": 2, "groupKey": "Open", "metricValue": 5},
+		{"id": 3, "groupKey": "Done", "metricValue": 1},
+		{"id": 4, "groupKey": "Done", "metricValue": nil},
+		{"id": 5, "groupKey": nil, "metricValue": 2},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss := testutil.NewSimulationServer(&testutil.Simulation{Pairs: []testutil.Pair{{
+		Request:  testutil.Request{Method: "GET", Path: "/api/v2/Bug", Query: map[string]string{"select": "{" + reportSelect("entityState.name", metric{Fn: "sum", Field: "effort"}) + "}"}},
+		Response: testutil.Response{Status: 200, Body: body},
+	}}})
+	defer ss.Close()
+
+	client := api.NewClient(ss.URL(), "tok", false)
+	items, err := client.QueryV2All(context.Background(), "Bug", api.V2Params{
+		Select: reportSelect("entityState.name", metric{Fn: "sum", Field: "effort"}),
+	})
+	if err != nil {
+		t.Fatalf("QueryV2All() error = %v", err)
+	}
+
+	tests := []struct {
+		m    metric
+		want map[string]float64
+	}{
+		{metric{Fn: "count"}, map[string]float64{"Open": 2, "Done": 2, noGroupName: 1}},
+		{metric{Fn: "sum", Field: "effort"}, map[string]float64{"Open": 8, "Done": 1, noGroupName: 2}},
+		{metric{Fn: "avg", Field: "effort"}, map[string]float64{"Open": 4, "Done": 1, noGroupName: 2}},
+	}
+	for _, tt := range tests {
+		groups := aggregate(items, tt.m)
+		if len(groups) != len(tt.want) {
+			t.Fatalf("%s: got %d groups, want %d", tt.m, len(groups), len(tt.want))
+		}
+		for _, g := range groups {
+			if g.Value != tt.want[g.Group] {
+				t.Errorf("%s: %s = %v, want %v", tt.m, g.Group, g.Value, tt.want[g.Group])
+			}
+		}
+		for i := 1; i < len(groups); i++ {
+			prev, cur := groups[i-1], groups[i]
+			if prev.Value < cur.Value || (prev.Value == cur.Value && prev.Group > cur.Group) {
+				t.Errorf("%s: groups not sorted by value then name: %s before %s", tt.m, prev.Group, cur.Group)
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package rollup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+)
+
+// storySelect fetches each story's effort and whether its state is final.
+const storySelect = "id,effort,entityState.isFinal as done"
+
+// Rollup is the effort summary for one feature.
+type Rollup struct {
+	Total     float64 `json:"total"`
+	Done      float64 `json:"done"`
+	Remaining float64 `json:"remaining"`
+	Percent   float64 `json:"percent"`
+
+	stories int
+}
+
+// NewCmd creates the "rollup" command.
+func NewCmd(f *cmdutil.Factory) *cli.Command {
+	return &cli.Command{
+		Name:      "rollup",
+		Usage:     "Show total vs done effort across a feature's user stories",
+		ArgsUsage: "<feature-id>",
+		UsageText: `tp rollup 1234
+  tp rollup 1234 -o json`,
+		Description: `Sums the effort of every user story in the feature. A story counts
+as done when its state is final. Stories without an estimate add nothing.`,
+		Flags: []cli.Flag{cmdutil.OutputFlag()},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() == 0 {
+				return errors.New("feature ID is required; usage: tp rollup <feature-id>")
+			}
+			id, err := strconv.Atoi(cmd.Args().First())
+			if err != nil {
+				return fmt.Errorf("invalid feature ID %q: must be an integer", cmd.Args().First())
+			}
+			if id <= 0 {
+				return fmt.Errorf("feature ID must be positive, got %d", id)
+			}
+
+			client, err := f.Client()
+			if err != nil {
+				return err
+			}
+
+			params := api.V2Params{
+				Where:  fmt.Sprintf("feature.id==%d", id),
+				Select: storySelect,
+				Take:   1000,
+			}
+			stories, err := client.QueryV2All(ctx, "UserStory", params)
+			if err != nil {
+				return api.EnhanceError(err, "/api/v2/UserStory", map[string]string{
+					"where":  params.Where,
+					"select": params.Select,
+				})
+			}
+
+			r := compute(stories)
+			if cmdutil.IsStructured(cmd) {
+				return cmdutil.PrintStructured(cmd, r)
+			}
+			printRollup(id, r)
+			return nil
+		},
+	}
+}
+
+// compute totals story effort and splits it into done and remaining.
+func compute(stories []api.Entity) Rollup {
+	var r Rollup
+	for _, s := range stories {
+		r.stories++
+		effort, _ := s["effort"].(float64)
+		r.Total += effort
+		if done, _ := s["done"].(bool); done {
+			r.Done += effort
+		}
+	}
+	r.Remaining = r.Total - r.Done
+	if r.Total > 0 {
+		r.Percent = math.Round(r.Done/r.Total*1000) / 10
+	}
+	return r
+}
+
+func printRollup(id int, r Rollup) {
+	fmt.Printf("Feature #%d: %d user stories\n", id, r.stories)
+	fmt.Printf("total:      %s\n", formatEffort(r.Total))
+	fmt.Printf("completed:  %s\n", formatEffort(r.Done))
+	fmt.Printf("remaining:  %s\n", formatEffort(r.Remaining))
+	fmt.Printf("percent:    %s%%\n", formatEffort(r.Percent))
+}
+
+func formatEffort(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
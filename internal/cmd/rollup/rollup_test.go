@@ -0,0 +1,46 @@
+package rollup
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/testutil"
+)
+
+func TestCompute_FromSimulation(t *testing.T) {
+	body, err := json.Marshal(map[string]any{"items": []map[string]any{
+		{"id": 1, "effort": 5, "done": true},
+		{"id": 2, "effort": 3, "done": false},
+		{"id": 3, "effort": nil, "done": true},
+		{"id": 4, "effort": 2, "done": nil},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss := testutil.NewSimulationServer(&testutil.Simulation{Pairs: []testutil.Pair{{
+		Request:  testutil.Request{Method: "GET", Path: "/api/v2/UserStory", Query: map[string]string{"where": "feature.id==42", "select": "{" + storySelect + "}"}},
+		Response: testutil.Response{Status: 200, Body: body},
+	}}})
+	defer ss.Close()
+
+	client := api.NewClient(ss.URL(), "tok", false)
+	stories, err := client.QueryV2All(context.Background(), "UserStory", api.V2Params{Where: "feature.id==42", Select: storySelect})
+	if err != nil {
+		t.Fatalf("QueryV2All() error = %v", err)
+	}
+
+	got := compute(stories)
+	want := Rollup{Total: 10, Done: 5, Remaining: 5, Percent: 50, stories: 4}
+	if got != want {
+		t.Errorf("compute() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCompute_NoEffort(t *testing.T) {
+	got := compute([]api.Entity{{"id": 1, "done": true}})
+	if got.Total != 0 || got.Percent != 0 {
+		t.Errorf("compute() = %+v, want zero totals", got)
+	}
+}
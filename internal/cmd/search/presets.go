@@ -2,7 +2,12 @@ package search
 
 import (
 	"fmt"
+	"io"
 	"sort"
+	"strings"
+
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+	"github.com/lifedraft/targetprocess-cli/internal/config"
 )
 
 // Preset defines a reusable search filter with optional field projection and sorting.
@@ -12,6 +17,8 @@ type Preset struct {
 	Where       string
 	Select      string
 	OrderBy     string
+	// User marks a preset defined in the config file rather than built in.
+	User bool
 }
 
 // SearchPresets is the map of all available search presets.
@@ -110,24 +117,192 @@ var SearchPresets = map[string]Preset{
 }
 
 // SortedPresetNames is the sorted list of preset names.
-var SortedPresetNames = func() []string {
+var SortedPresetNames = sortedPresetNames()
+
+func sortedPresetNames() []string {
 	names := make([]string, 0, len(SearchPresets))
 	for name := range SearchPresets {
 		names = append(names, name)
 	}
 	sort.Strings(names)
 	return names
-}()
+}
+
+// LoadUserPresets adds the presets section of the config file to
+// SearchPresets. Only the commands that use presets call it, so a broken
+// presets section cannot stop tp config or tp login from fixing it. Config
+// errors are left to the command's own f.Config call; warnings about
+// replaced built-in presets go to warn.
+func LoadUserPresets(f *cmdutil.Factory, warn io.Writer) error {
+	cfg, err := f.Config()
+	if err != nil {
+		return nil //nolint:nilerr // reported by the command that needs the config
+	}
+	return AddUserPresets(cfg.Presets, warn)
+}
+
+// CheckUserPresets validates the presets section of the config file and
+// returns the names of the built-in presets it replaces, sorted. Every user
+// preset needs a where clause, and since --preset stacks comma-separated
+// names, a name cannot contain a comma or a space.
+func CheckUserPresets(presets map[string]config.Preset) ([]string, error) {
+	var replaced []string
+	for _, name := range sortedKeys(presets) {
+		p := presets[name]
+		if strings.TrimSpace(name) == "" || strings.ContainsAny(name, ", ") {
+			return nil, fmt.Errorf("config: invalid preset name %q: names cannot be empty or contain commas or spaces", name)
+		}
+		if strings.TrimSpace(p.Where) == "" {
+			return nil, fmt.Errorf("config: preset %q has no where clause", name)
+		}
+		if existing, ok := SearchPresets[name]; ok && !existing.User {
+			replaced = append(replaced, name)
+		}
+	}
+	return replaced, nil
+}
+
+// AddUserPresets merges the presets section of the config file into
+// SearchPresets after checking it with CheckUserPresets. A user preset
+// replaces a built-in one of the same name, with a warning on warn.
+func AddUserPresets(presets map[string]config.Preset, warn io.Writer) error {
+	replaced, err := CheckUserPresets(presets)
+	if err != nil {
+		return err
+	}
+	for _, name := range replaced {
+		fmt.Fprintf(warn, "Warning: preset %q from the config file replaces the built-in preset\n", name)
+	}
+
+	for _, name := range sortedKeys(presets) {
+		p := presets[name]
+		description := p.Description
+		if description == "" {
+			description = "User preset"
+		}
+		SearchPresets[name] = Preset{
+			Name:        name,
+			Description: description,
+			Where:       strings.TrimSpace(p.Where),
+			Select:      strings.TrimSpace(p.Select),
+			OrderBy:     strings.TrimSpace(p.OrderBy),
+			User:        true,
+		}
+	}
+	SortedPresetNames = sortedPresetNames()
+	return nil
+}
+
+func sortedKeys(presets map[string]config.Preset) []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
 
 // ApplyPreset resolves a preset name into a full Preset struct.
-// If where is also provided, the preset where and the extra where are combined with " and ".
+// presetName may be a comma-separated list (e.g. "open,highPriority"), in which
+// case the presets' where clauses are ANDed. Stacked presets must agree on
+// Select and OrderBy if more than one of them sets it. If where is also
+// provided, it is ANDed on last. Each clause is parenthesized with
+// cmdutil.AndWhere, so an "or" in one cannot widen the others.
 func ApplyPreset(presetName, where string) (Preset, error) {
-	p, ok := SearchPresets[presetName]
-	if !ok {
-		return Preset{}, fmt.Errorf("unknown preset %q, valid presets: %v", presetName, SortedPresetNames)
+	var combined Preset
+	var names, descriptions, clauses []string
+	selectFrom, orderFrom := "", ""
+	for _, name := range strings.Split(presetName, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		p, ok := SearchPresets[name]
+		if !ok {
+			return Preset{}, fmt.Errorf("unknown preset %q, valid presets: %v", name, SortedPresetNames)
+		}
+		names = append(names, p.Name)
+		descriptions = append(descriptions, p.Description)
+		if p.Where != "" {
+			clauses = append(clauses, p.Where)
+		}
+		if p.Select != "" {
+			if combined.Select != "" && combined.Select != p.Select {
+				return Preset{}, fmt.Errorf("presets %q and %q set conflicting select expressions", selectFrom, name)
+			}
+			combined.Select, selectFrom = p.Select, name
+		}
+		if p.OrderBy != "" {
+			if combined.OrderBy != "" && combined.OrderBy != p.OrderBy {
+				return Preset{}, fmt.Errorf("presets %q and %q set conflicting orderBy expressions", orderFrom, name)
+			}
+			combined.OrderBy, orderFrom = p.OrderBy, name
+		}
+	}
+	if len(names) == 0 {
+		return Preset{}, fmt.Errorf("no preset given, valid presets: %v", SortedPresetNames)
 	}
-	if where != "" {
-		p.Where = p.Where + " and " + where
+
+	combined.Name = strings.Join(names, ",")
+	combined.Description = strings.Join(descriptions, "; ")
+	for _, clause := range append(clauses, where) {
+		combined.Where = cmdutil.AndWhere(combined.Where, clause)
+	}
+	return combined, nil
+}
+
+// SelectPreset is a named projection applied with --view.
+type SelectPreset struct {
+	Name        string
+	Description string
+	Select      string
+}
+
+// SelectPresets is the map of all available select presets (views).
+var SelectPresets = map[string]SelectPreset{
+	"summary": {
+		Name:        "summary",
+		Description: "Id, name, and state",
+		Select:      "id,name,entityState.name as state",
+	},
+	"detailed": {
+		Name:        "detailed",
+		Description: "Summary plus type, effort, priority, and assignees",
+		Select: "id,name,entityType.name as type,entityState.name as state,effort,priority.name as priority," +
+			"assignments.select({generalUser.firstName as firstName,generalUser.lastName as lastName}) as assignees",
+	},
+	"planning": {
+		Name:        "planning",
+		Description: "State, effort, and the sprint and release an item is planned for",
+		Select:      "id,name,entityState.name as state,effort,teamIteration.name as sprint,release.name as release",
+	},
+	"timeline": {
+		Name:        "timeline",
+		Description: "State with create, modify, and end dates",
+		Select:      "id,name,entityState.name as state,createDate,modifyDate,endDate",
+	},
+}
+
+// SortedSelectPresetNames is the sorted list of select preset names.
+var SortedSelectPresetNames = func() []string {
+	names := make([]string, 0, len(SelectPresets))
+	for name := range SelectPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}()
+
+// ApplyView returns the select expression to use given an explicit --select
+// and a --view name. An explicit select always wins; an empty view leaves the
+// select unchanged.
+func ApplyView(view, selectExpr string) (string, error) {
+	if view == "" || selectExpr != "" {
+		return selectExpr, nil
+	}
+	p, ok := SelectPresets[view]
+	if !ok {
+		return "", fmt.Errorf("unknown view %q, valid views: %v", view, SortedSelectPresetNames)
 	}
-	return p, nil
+	return p.Select, nil
 }
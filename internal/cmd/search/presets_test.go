@@ -0,0 +1,160 @@
+package search
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/config"
+)
+
+func TestApplyPreset(t *testing.T) {
+	tests := []struct {
+		name        string
+		preset      string
+		where       string
+		wantWhere   string
+		wantSelect  string
+		wantOrderBy string
+		wantErr     bool
+	}{
+		{
+			name:      "single preset",
+			preset:    "open",
+			wantWhere: "entityState.isInitial==true",
+		},
+		{
+			name:      "single preset with extra where",
+			preset:    "open",
+			where:     "effort>0",
+			wantWhere: "(entityState.isInitial==true) and (effort>0)",
+		},
+		{
+			name:      "extra where with or stays inside its parentheses",
+			preset:    "open",
+			where:     "effort>5 or priority.importance>=90",
+			wantWhere: "(entityState.isInitial==true) and (effort>5 or priority.importance>=90)",
+		},
+		{
+			name:      "stacked presets",
+			preset:    "open,highPriority",
+			wantWhere: "(entityState.isInitial==true) and (priority.importance>=90)",
+		},
+		{
+			name:      "stacked presets with spaces and extra where",
+			preset:    "open, unassigned",
+			where:     "effort>0",
+			wantWhere: "((entityState.isInitial==true) and (assignments.count==0)) and (effort>0)",
+		},
+		{
+			name:        "stacked presets keep select and orderBy",
+			preset:      "highPriority,recentActivity",
+			wantWhere:   "(priority.importance>=90) and (modifyDate>=Today.AddDays(-7))",
+			wantSelect:  "id,name,entityType.name as type,entityState.name as state,modifyDate",
+			wantOrderBy: "modifyDate desc",
+		},
+		{
+			name:    "conflicting select",
+			preset:  "recentActivity,unestimated",
+			wantErr: true,
+		},
+		{
+			name:    "unknown preset in list",
+			preset:  "open,nope",
+			wantErr: true,
+		},
+		{
+			name:    "empty list",
+			preset:  " , ",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyPreset(tt.preset, tt.where)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ApplyPreset(%q) expected error, got %+v", tt.preset, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ApplyPreset(%q) unexpected error: %v", tt.preset, err)
+			}
+			if got.Where != tt.wantWhere {
+				t.Errorf("Where = %q, want %q", got.Where, tt.wantWhere)
+			}
+			if got.Select != tt.wantSelect {
+				t.Errorf("Select = %q, want %q", got.Select, tt.wantSelect)
+			}
+			if got.OrderBy != tt.wantOrderBy {
+				t.Errorf("OrderBy = %q, want %q", got.OrderBy, tt.wantOrderBy)
+			}
+		})
+	}
+}
+
+func TestApplyView(t *testing.T) {
+	got, err := ApplyView("summary", "")
+	if err != nil || got != SelectPresets["summary"].Select {
+		t.Errorf("ApplyView(summary) = %q, %v", got, err)
+	}
+	if got, _ := ApplyView("summary", "id"); got != "id" {
+		t.Errorf("explicit select should win, got %q", got)
+	}
+	if got, _ := ApplyView("", "id,name"); got != "id,name" {
+		t.Errorf("empty view should keep select, got %q", got)
+	}
+	if _, err := ApplyView("nope", ""); err == nil {
+		t.Error("expected error for unknown view")
+	}
+}
+
+func TestAddUserPresets(t *testing.T) {
+	saved := make(map[string]Preset, len(SearchPresets))
+	for k, v := range SearchPresets {
+		saved[k] = v
+	}
+	t.Cleanup(func() {
+		SearchPresets = saved
+		SortedPresetNames = sortedPresetNames()
+	})
+
+	var warn bytes.Buffer
+	err := AddUserPresets(map[string]config.Preset{
+		"coreTriage": {Where: `team.name=="Core" or severity==null`, Select: "id,name"},
+		"open":       {Description: "Our open", Where: "entityState.name==\"Open\""},
+	}, &warn)
+	if err != nil {
+		t.Fatalf("AddUserPresets() error = %v", err)
+	}
+	if !strings.Contains(warn.String(), `preset "open" from the config file replaces the built-in preset`) {
+		t.Errorf("expected an override warning, got %q", warn.String())
+	}
+	if !slices.Contains(SortedPresetNames, "coreTriage") {
+		t.Errorf("SortedPresetNames = %v, missing coreTriage", SortedPresetNames)
+	}
+
+	p, err := ApplyPreset("coreTriage,open", "effort>0")
+	if err != nil {
+		t.Fatalf("ApplyPreset() error = %v", err)
+	}
+	if want := `((team.name=="Core" or severity==null) and (entityState.name=="Open")) and (effort>0)`; p.Where != want {
+		t.Errorf("Where = %q, want %q", p.Where, want)
+	}
+	if p.Select != "id,name" {
+		t.Errorf("Select = %q, want id,name", p.Select)
+	}
+
+	for name, bad := range map[string]config.Preset{
+		"noWhere":   {Select: "id"},
+		"a,b":       {Where: "id>0"},
+		"has space": {Where: "id>0"},
+	} {
+		if err := AddUserPresets(map[string]config.Preset{name: bad}, &warn); err == nil {
+			t.Errorf("AddUserPresets(%q) succeeded, want error", name)
+		}
+	}
+}
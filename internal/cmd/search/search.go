@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/urfave/cli/v3"
@@ -27,27 +28,61 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
 		UsageText: `# Search open user stories
   tp search UserStory -w 'entityState.isFinal!=true' -s 'id,name,entityState.name as state'
 
+  # Build the filter from flags instead of writing v2 syntax
+  tp search Bug --in 'priority.name:High,Urgent' --between effort:1,5 --eq assignedUser.id:null
+
+  # Quote bare words compared against in --where
+  tp search Bug -w 'entityState.name==Open' --auto-quote
+
   # Cross-type search
   tp search Assignable -w 'name.toLower().contains("login")' -s 'id,name,entityType.name as type'
 
   # Use a preset
   tp search UserStory --preset open
 
+  # Stack presets (where clauses are combined with 'and')
+  tp search Bug --preset open,highPriority
+
   # With sorting
   tp search Bug -w 'priority.name=="High"' --order-by 'createDate desc' --take 50
 
+  # Second page of 25
+  tp search UserStory --skip 25 --take 25
+  tp search UserStory --page 2 --page-size 25
+
+  # Use a named projection instead of typing --select
+  tp search Bug --preset open --view detailed
+
+  # Stream all open bugs as JSON Lines
+  tp search Bug --preset open --all -o jsonl
+
   # Recently modified items
-  tp search Assignable --preset recentActivity`,
-		Flags: []cli.Flag{
-			cmdutil.OutputFlag(),
+  tp search Assignable --preset recentActivity
+
+  # See what a preset search would send, annotated, without sending it
+  tp search bugs --preset open --view summary --explain
+
+  # Audit: open bugs created by one person, last touched by another
+  tp search Bug --preset open --created-by timo --modified-by 'Anna Schmidt'`,
+		Flags: append([]cli.Flag{
+			cmdutil.OutputFlag("jsonl"),
 			&cli.StringFlag{
 				Name:    "where",
 				Aliases: []string{"w"},
 				Usage:   `Filter expression using v2 syntax (e.g. 'entityState.isFinal!=true', 'name.contains("login")')`,
 			},
+			cmdutil.WhereFileFlag(),
 			&cli.StringFlag{
 				Name:  "preset",
-				Usage: "Use a preset filter (run 'tp presets' to list available presets)",
+				Usage: "Use a preset filter; comma-separate to stack presets (run 'tp presets' to list available presets)",
+			},
+			&cli.StringFlag{
+				Name:  "where-preset",
+				Usage: "Like --preset, but apply only the preset's where clause (ignore its select/orderBy)",
+			},
+			&cli.StringFlag{
+				Name:  "view",
+				Usage: "Apply a named select preset (e.g. summary, detailed; see tp presets). --select wins if both are given",
 			},
 			&cli.StringFlag{
 				Name:    "select",
@@ -60,11 +95,52 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
 				Value:   25,
 				Usage:   "Max number of results to return (max 1000)",
 			},
+			&cli.IntFlag{
+				Name:  "skip",
+				Usage: "Number of results to skip (for paging with --take)",
+			},
+			&cli.IntFlag{
+				Name:  "page",
+				Usage: "Page number, starting at 1 (sets --skip from --page-size)",
+			},
+			&cli.IntFlag{
+				Name:  "page-size",
+				Usage: "Results per page for --page (default: --take)",
+			},
 			&cli.StringFlag{
 				Name:  "order-by",
 				Usage: "Sort expression (e.g. 'createDate desc')",
 			},
-		},
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "Fetch every page by following 'next' links (--take sets the page size)",
+			},
+			&cli.IntFlag{
+				Name:  "since-id",
+				Usage: "Only return entities with id greater than N, ordered by id (for incremental sync)",
+			},
+			&cli.StringFlag{
+				Name:  "created-by",
+				Usage: "Only entities created by this user (login, first or full name, or user ID); filters on owner.id",
+			},
+			&cli.StringFlag{
+				Name:  "modified-by",
+				Usage: "Only entities last modified by this user (login, first or full name, or user ID); filters on lastEditor.id",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show the URL that would be called without executing",
+			},
+			&cli.BoolFlag{
+				Name:  "explain",
+				Usage: "Print an annotated breakdown (type, select keys, where, order, paging, URL) instead of searching; sends nothing",
+			},
+			&cli.BoolFlag{
+				Name:  "explain-errors",
+				Usage: "On failure, list every known error pattern that matches, not just the first hint",
+			},
+			cmdutil.AutoQuoteFlag(),
+		}, cmdutil.FilterFlags()...),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			args := cmd.Args().Slice()
 			if len(args) == 0 {
@@ -75,18 +151,50 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
 			if vErr := api.ValidateEntityType(entityType); vErr != nil {
 				return vErr
 			}
+			if err := resolve.CheckEntityType(entityType); err != nil {
+				return err
+			}
+
+			// --explain is a dry run that shows the breakdown instead of the
+			// URL; nothing below may reach the network for it.
+			explain := cmd.Bool("explain")
+			dryRun := explain || cmd.Bool("dry-run")
+			f.Offline = dryRun
 
 			client, err := f.Client()
 			if err != nil {
 				return err
 			}
 
-			where := cmd.String("where")
-			selectExpr := cmd.String("select")
-			take := cmd.Int("take")
+			where, err := cmdutil.WhereFlag(cmd, f.Warnings())
+			if err != nil {
+				return err
+			}
+			selectExpr, err := ApplyView(cmd.String("view"), cmd.String("select"))
+			if err != nil {
+				return err
+			}
 			orderBy := cmd.String("order-by")
 
+			if cmd.String("preset") != "" && cmd.String("where-preset") != "" {
+				return errors.New("--preset and --where-preset cannot be used together")
+			}
+			if cmd.String("preset") != "" || cmd.String("where-preset") != "" {
+				// tp presets and tp config doctor report replaced built-ins.
+				if err := LoadUserPresets(f, io.Discard); err != nil {
+					return err
+				}
+			}
+
 			// Apply preset if specified
+			if presetName := cmd.String("where-preset"); presetName != "" {
+				var p Preset
+				p, err = ApplyPreset(presetName, where)
+				if err != nil {
+					return err
+				}
+				where = p.Where
+			}
 			if presetName := cmd.String("preset"); presetName != "" {
 				var p Preset
 				p, err = ApplyPreset(presetName, where)
@@ -102,54 +210,171 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
 				}
 			}
 
-			if take < 0 || take > 1000 {
-				return fmt.Errorf("take must be between 0 and 1000, got %d", take)
+			where, err = cmdutil.AppendFilterClauses(cmd, where)
+			if err != nil {
+				return err
 			}
 
+			users := map[string]string{
+				"created-by":  cmd.String("created-by"),
+				"modified-by": cmd.String("modified-by"),
+			}
+			if explain {
+				where = explainUserFilters(where, users)
+			} else {
+				where, err = applyUserFilters(ctx, client, entityType, where, users)
+				if err != nil {
+					return err
+				}
+			}
+
+			// --since-id replaces any preset ordering but conflicts with an explicit --order-by.
+			sinceID := cmd.IsSet("since-id")
+			if sinceID {
+				where, selectExpr, orderBy, err = cmdutil.ApplySinceID(cmd.Int("since-id"), where, selectExpr, cmd.String("order-by"))
+				if err != nil {
+					return err
+				}
+			}
+
+			// --explain shows the select warnings inline instead
 			// Warn about dot-paths missing 'as' aliases (silently dropped by API)
-			if warn := api.WarnSelectDotPaths(selectExpr); warn != "" {
-				fmt.Fprint(os.Stderr, warn)
+			if warn := api.WarnSelectDotPaths(selectExpr); warn != "" && !explain {
+				fmt.Fprint(f.Warnings(), warn)
+			}
+
+			// Warn about malformed select syntax (colon subfields, unbalanced braces, empty aliases)
+			if warn := api.ValidateSelect(selectExpr); warn != "" && !explain {
+				fmt.Fprint(f.Warnings(), warn)
 			}
 
-			params := api.V2Params{
-				Where:   where,
-				Select:  selectExpr,
-				OrderBy: orderBy,
-				Take:    take,
+			// Warn about v1 word operators (eq, ne, gt, ...) that v2 rejects
+			if warn := api.WarnWhereV1Operators(where); warn != "" {
+				fmt.Fprint(f.Warnings(), warn)
 			}
 
-			data, err := client.QueryV2(ctx, entityType, params)
+			// Tidy the order expression and warn about aggregates or bad directions
+			orderBy, orderWarn := api.NormalizeOrderBy(orderBy)
+			fmt.Fprint(f.Warnings(), orderWarn)
+
+			take, skip := cmd.Int("take"), cmd.Int("skip")
+			if cmd.IsSet("page") || cmd.IsSet("page-size") {
+				if cmd.IsSet("skip") || cmd.Bool("all") {
+					return errors.New("--page and --page-size cannot be combined with --skip or --all")
+				}
+				page, pageSize := 1, take
+				if cmd.IsSet("page") {
+					page = cmd.Int("page")
+				}
+				if cmd.IsSet("page-size") {
+					pageSize = cmd.Int("page-size")
+				}
+				take, skip, err = cmdutil.PageWindow(page, pageSize)
+				if err != nil {
+					return err
+				}
+			}
+
+			params, err := cmdutil.NewV2Params(where, selectExpr, orderBy, take, skip)
 			if err != nil {
+				return err
+			}
+
+			if dryRun {
+				if explain {
+					cmdutil.PrintQueryPlan(os.Stdout, cmdutil.QueryPlan{
+						TypeArg: args[0], EntityType: entityType, Params: params,
+						URL: client.BuildV2URL(entityType, params),
+					})
+					return nil
+				}
+				fmt.Fprintln(os.Stdout, client.BuildV2URL(entityType, params))
+				return nil
+			}
+
+			enhance := func(err error) error {
 				path := fmt.Sprintf("/api/v2/%s", entityType)
-				err = api.EnhanceError(err, path, map[string]string{
+				queryParams := map[string]string{
 					"where":   params.Where,
 					"select":  params.Select,
 					"orderBy": params.OrderBy,
-				})
-				return fmt.Errorf("search failed: %w", err)
+					"take":    strconv.Itoa(params.Take),
+				}
+				enhanced := api.EnhanceError(err, path, queryParams)
+				if cmd.Bool("explain-errors") {
+					return fmt.Errorf("search failed: %w\n\n%s", enhanced, api.FormatExplain(api.MatchPatterns(err, path, queryParams)))
+				}
+				return fmt.Errorf("search failed: %w", enhanced)
 			}
 
-			// Parse v2 response: {"items": [...], "next": "..."}
-			var resp struct {
-				Items []api.Entity `json:"items"`
+			// JSON Lines with --all streams each page as it arrives.
+			if cmdutil.IsJSONL(cmd) && cmd.Bool("all") {
+				var lastPage []api.Entity
+				err = client.QueryV2Pages(ctx, entityType, params, func(items []api.Entity) error {
+					if len(items) > 0 {
+						lastPage = items
+					}
+					return output.PrintJSONLines(os.Stdout, items)
+				})
+				if err != nil {
+					return enhance(err)
+				}
+				if sinceID {
+					// --since-id orders by id, so the last page holds the max id.
+					fmt.Fprint(f.Warnings(), cmdutil.SinceIDHint(cmd.Int("since-id"), lastPage))
+				}
+				return nil
 			}
-			if err := json.Unmarshal(data, &resp); err != nil {
-				return fmt.Errorf("parsing v2 response: %w", err)
+
+			items, err := fetch(ctx, f, client, entityType, params, cmd.Bool("all"))
+			if err != nil {
+				return enhance(err)
 			}
 
-			if cmdutil.IsJSON(cmd) {
-				return output.PrintJSON(os.Stdout, map[string]any{
-					"items": resp.Items,
-					"count": len(resp.Items),
-				})
+			if cmdutil.IsJSONL(cmd) {
+				if err := output.PrintJSONLines(os.Stdout, items); err != nil {
+					return err
+				}
+			} else if cmdutil.IsStructured(cmd) {
+				if err := cmdutil.PrintStructured(cmd, map[string]any{
+					"items": items,
+					"count": len(items),
+				}); err != nil {
+					return err
+				}
+			} else {
+				printV2EntityTable(os.Stdout, items)
 			}
 
-			printV2EntityTable(os.Stdout, resp.Items)
+			if sinceID {
+				fmt.Fprint(f.Warnings(), cmdutil.SinceIDHint(cmd.Int("since-id"), items))
+			}
 			return nil
 		},
 	}
 }
 
+// fetch runs the v2 query, following every page when all is set.
+func fetch(ctx context.Context, f *cmdutil.Factory, client *api.Client, entityType string, params api.V2Params, all bool) ([]api.Entity, error) {
+	if all {
+		return f.FetchAll(ctx, client, entityType, params)
+	}
+
+	data, err := client.QueryV2(ctx, entityType, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse v2 response: {"items": [...], "next": "..."}
+	var resp struct {
+		Items []api.Entity `json:"items"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parsing v2 response: %w", err)
+	}
+	return resp.Items, nil
+}
+
 // printV2EntityTable prints entities from the v2 API as a table.
 func printV2EntityTable(w io.Writer, entities []api.Entity) {
 	if len(entities) == 0 {
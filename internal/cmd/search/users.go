@@ -0,0 +1,101 @@
+package search
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+	"github.com/lifedraft/targetprocess-cli/internal/text"
+)
+
+// userFilter maps an audit flag to the user reference it filters on.
+type userFilter struct {
+	Flag string
+	// Field is the v2 reference used in the where clause.
+	Field string
+	// MetaName is the same reference as named in the v1 type metadata.
+	MetaName string
+}
+
+var userFilters = []userFilter{
+	{Flag: "created-by", Field: "owner", MetaName: "Owner"},
+	{Flag: "modified-by", Field: "lastEditor", MetaName: "LastEditor"},
+}
+
+// typeReferences lists the reference fields from a type's metadata XML.
+type typeReferences struct {
+	References []struct {
+		Name string `xml:"Name,attr"`
+	} `xml:"ResourceMetadataPropertiesDescription>ResourceMetadataPropertiesResourceReferencesDescription>ResourceFieldMetadataDescription"`
+}
+
+// applyUserFilters resolves each set user flag to a user ID and ANDs an
+// <field>.id==<id> clause onto where. users maps flag name to the value given.
+// When type metadata can be fetched, it first checks that the entity type has
+// the reference; if metadata is unavailable the check is skipped.
+func applyUserFilters(ctx context.Context, client *api.Client, entityType, where string, users map[string]string) (string, error) {
+	var refs map[string]bool
+	resolver := &text.UserResolver{Client: client}
+
+	for _, uf := range userFilters {
+		name := users[uf.Flag]
+		if name == "" {
+			continue
+		}
+
+		if refs == nil {
+			refs = referenceNames(ctx, client, entityType)
+		}
+		if len(refs) > 0 && !refs[strings.ToLower(uf.MetaName)] {
+			return "", fmt.Errorf("--%s: %s has no %s field", uf.Flag, entityType, uf.MetaName)
+		}
+
+		id, err := resolver.LookupUserID(ctx, name)
+		if err != nil {
+			return "", fmt.Errorf("--%s: %w", uf.Flag, err)
+		}
+
+		where = cmdutil.AndWhere(where, fmt.Sprintf("%s.id==%d", uf.Field, id))
+	}
+	return where, nil
+}
+
+// explainUserFilters ANDs the same clauses as applyUserFilters onto where
+// without any lookups, for --explain: a user ID is used as given and a name
+// shows as a placeholder.
+func explainUserFilters(where string, users map[string]string) string {
+	for _, uf := range userFilters {
+		name := users[uf.Flag]
+		if name == "" {
+			continue
+		}
+		id := fmt.Sprintf("<id of %s>", name)
+		if _, err := strconv.Atoi(name); err == nil {
+			id = name
+		}
+		where = cmdutil.AndWhere(where, fmt.Sprintf("%s.id==%s", uf.Field, id))
+	}
+	return where
+}
+
+// referenceNames returns the lowercased reference field names of an entity
+// type, or an empty map if metadata is unavailable.
+func referenceNames(ctx context.Context, client *api.Client, entityType string) map[string]bool {
+	names := map[string]bool{}
+	data, err := client.GetTypeMeta(ctx, entityType)
+	if err != nil {
+		return names
+	}
+	var meta typeReferences
+	if err := xml.Unmarshal(data, &meta); err != nil {
+		return names
+	}
+	for _, r := range meta.References {
+		names[strings.ToLower(r.Name)] = true
+	}
+	return names
+}
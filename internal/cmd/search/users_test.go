@@ -0,0 +1,103 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+const bugMeta = `<ResourceMetadataDescription Name="Bug">
+  <ResourceMetadataPropertiesDescription>
+    <ResourceMetadataPropertiesResourceReferencesDescription>
+      <ResourceFieldMetadataDescription Name="Owner" Type="GeneralUser" />
+      <ResourceFieldMetadataDescription Name="Project" Type="Project" />
+    </ResourceMetadataPropertiesResourceReferencesDescription>
+  </ResourceMetadataPropertiesDescription>
+</ResourceMetadataDescription>`
+
+func TestApplyUserFilters(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/Bugs/meta":
+			fmt.Fprint(w, bugMeta)
+		case "/api/v2/GeneralUser":
+			switch r.URL.Query().Get("where") {
+			case `login=="timo"`:
+				fmt.Fprint(w, `{"items":[{"id":7,"login":"timo","firstName":"Timo","lastName":"L"}]}`)
+			case `login=="o'brien"`:
+				fmt.Fprint(w, `{"items":[{"id":8,"login":"o'brien","firstName":"Pat","lastName":"O'Brien"}]}`)
+			case `firstName.toLower()=="anna" and lastName.toLower()=="schmidt"`:
+				fmt.Fprint(w, `{"items":[{"id":9,"login":"aschmidt","firstName":"Anna","lastName":"Schmidt"}]}`)
+			default:
+				fmt.Fprint(w, `{"items":[]}`)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client := api.NewClient(srv.URL, "tok", false)
+	ctx := context.Background()
+
+	t.Run("resolves user and ANDs with where", func(t *testing.T) {
+		got, err := applyUserFilters(ctx, client, "Bug", "effort>0", map[string]string{"created-by": "@timo"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "(effort>0) and (owner.id==7)"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("field missing from metadata", func(t *testing.T) {
+		_, err := applyUserFilters(ctx, client, "Bug", "", map[string]string{"modified-by": "42"})
+		if err == nil || !strings.Contains(err.Error(), "has no LastEditor field") {
+			t.Errorf("expected missing-field error, got %v", err)
+		}
+	})
+
+	t.Run("no metadata skips the check", func(t *testing.T) {
+		got, err := applyUserFilters(ctx, client, "Task", "", map[string]string{"modified-by": "42"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "lastEditor.id==42"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("keeps an or filter together", func(t *testing.T) {
+		got, err := applyUserFilters(ctx, client, "Bug", "effort>0 or effort==null", map[string]string{"created-by": "timo"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "(effort>0 or effort==null) and (owner.id==7)"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("quotes logins and matches full names", func(t *testing.T) {
+		for name, want := range map[string]string{"o'brien": "owner.id==8", "Anna Schmidt": "owner.id==9"} {
+			got, err := applyUserFilters(ctx, client, "Bug", "", map[string]string{"created-by": name})
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", name, err)
+			}
+			if got != want {
+				t.Errorf("%s: got %q, want %q", name, got, want)
+			}
+		}
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := applyUserFilters(ctx, client, "Bug", "", map[string]string{"created-by": "nobody"})
+		if err == nil || !strings.Contains(err.Error(), `no user matches "nobody"`) {
+			t.Errorf("expected no-match error, got %v", err)
+		}
+	})
+}
@@ -27,6 +27,9 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
   # Show with explicit type (skips auto-detection)
   tp show 341079 --type UserStory
 
+  # Fail rather than auto-detect when --type is missing
+  tp show 341079 --type UserStory --no-detect
+
   # Include related data
   tp show 341079 --include Project,Team
 
@@ -35,6 +38,7 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
 		Flags: []cli.Flag{
 			cmdutil.OutputFlag(),
 			&cli.StringFlag{Name: "type", Usage: "Entity type (auto-detected if omitted)"},
+			cmdutil.NoDetectFlag(),
 			&cli.StringFlag{Name: "include", Usage: "Related data to include, comma-separated (e.g. Project,Team)"},
 			&cli.IntFlag{Name: "id", Usage: "Entity ID (alternative to positional argument)"},
 		},
@@ -44,13 +48,18 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
 				return err
 			}
 
-			return RunShow(ctx, f, id, resolve.EntityType(cmd.String("type")), cmd.String("include"), cmdutil.IsJSON(cmd))
+			entityType := resolve.EntityType(cmd.String("type"))
+			if err := cmdutil.CheckNoDetect(cmd, entityType); err != nil {
+				return err
+			}
+			return RunShow(ctx, f, id, entityType, cmd.String("include"), cmd.String("output"))
 		},
 	}
 }
 
 // RunShow executes the show logic. Exported so the root command can delegate to it.
-func RunShow(ctx context.Context, f *cmdutil.Factory, id int, entityType, include string, jsonOutput bool) error {
+// format is an --output value; json and yaml print the entity as data.
+func RunShow(ctx context.Context, f *cmdutil.Factory, id int, entityType, include, format string) error {
 	client, err := f.Client()
 	if err != nil {
 		return err
@@ -73,8 +82,8 @@ func RunShow(ctx context.Context, f *cmdutil.Factory, id int, entityType, includ
 		return err
 	}
 
-	if jsonOutput {
-		return output.PrintJSON(os.Stdout, entity)
+	if format == "json" || format == "yaml" {
+		return output.Print(os.Stdout, format, entity)
 	}
 
 	output.PrintEntity(os.Stdout, entity)
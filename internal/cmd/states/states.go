@@ -0,0 +1,96 @@
+package states
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+	"github.com/lifedraft/targetprocess-cli/internal/output"
+	"github.com/lifedraft/targetprocess-cli/internal/resolve"
+)
+
+// NewCmd creates the top-level "states" command.
+func NewCmd(f *cmdutil.Factory) *cli.Command {
+	return newCmd(f, "tp states")
+}
+
+// NewInspectCmd creates "inspect states", the same listing under inspect
+// next to the other metadata commands.
+func NewInspectCmd(f *cmdutil.Factory) *cli.Command {
+	return newCmd(f, "tp inspect states")
+}
+
+func newCmd(f *cmdutil.Factory, invocation string) *cli.Command {
+	return &cli.Command{
+		Name:  "states",
+		Usage: "List the workflow states of an entity type, with the IDs --state-id expects",
+		UsageText: `# User story states in every process
+  ` + invocation + ` --type UserStory
+
+  # Bug states of the process project 42 uses
+  ` + invocation + ` --type Bug --project 42
+
+  # Bug states of a process by name (or ID)
+  ` + invocation + ` --type Bug --process Kanban
+
+  # Then move an entity into one of them
+  tp update 12345 --state-id 100`,
+		Flags: []cli.Flag{
+			cmdutil.OutputFlag(),
+			&cli.StringFlag{Name: "type", Required: true, Usage: "Entity type (e.g. UserStory, Bug)"},
+			&cli.IntFlag{Name: "project", Usage: "Only states of this project's process"},
+			&cli.StringFlag{Name: "process", Usage: "Only states of this process (ID or name)"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			entityType := resolve.EntityType(cmd.String("type"))
+			if err := api.ValidateEntityType(entityType); err != nil {
+				return err
+			}
+			filter := api.StateFilter{ProjectID: cmd.Int("project"), Process: cmd.String("process")}
+			if filter.ProjectID < 0 {
+				return fmt.Errorf("project ID must be positive, got %d", filter.ProjectID)
+			}
+			if filter.ProjectID > 0 && filter.Process != "" {
+				return errors.New("--project and --process both pick a process; use one")
+			}
+
+			client, err := f.Client()
+			if err != nil {
+				return err
+			}
+			list, err := client.ListEntityStates(ctx, entityType, filter)
+			if err != nil {
+				return err
+			}
+			return Print(cmd, list)
+		},
+	}
+}
+
+// Print writes states as a table, or as JSON or YAML with --output.
+func Print(cmd *cli.Command, states []api.EntityState) error {
+	if cmdutil.IsStructured(cmd) {
+		return cmdutil.PrintStructured(cmd, map[string]any{
+			"states": states,
+			"count":  len(states),
+		})
+	}
+
+	if len(states) == 0 {
+		fmt.Fprintln(os.Stdout, "No states found.")
+		return nil
+	}
+	tw := output.NewTabWriter(os.Stdout)
+	fmt.Fprintln(tw, "ID\tNAME\tPROCESS\tINITIAL\tPLANNED\tFINAL")
+	for _, s := range states {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n", s.ID, s.Name, s.Process,
+			strconv.FormatBool(s.IsInitial), strconv.FormatBool(s.IsPlanned), strconv.FormatBool(s.IsFinal))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,269 @@
+package timecmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+	"github.com/lifedraft/targetprocess-cli/internal/output"
+	"github.com/lifedraft/targetprocess-cli/internal/text"
+)
+
+// dateLayout is the format --date takes and the list prints.
+const dateLayout = "2006-01-02"
+
+// NewCmd creates the "time" command.
+func NewCmd(f *cmdutil.Factory) *cli.Command {
+	return &cli.Command{
+		Name:  "time",
+		Usage: "Log and list time spent on entities",
+		UsageText: `# Log two hours on a task, with four hours left
+  tp time log 342236 --spent 2h --remaining 4h --description "Wired up the API"
+
+  # Log 90 minutes against a past day
+  tp time log 342236 --spent 90m --date 2024-06-01
+
+  # List time logged on an entity
+  tp time list 342236`,
+		Commands: []*cli.Command{
+			newLogCmd(f),
+			newListCmd(f),
+		},
+	}
+}
+
+func newLogCmd(f *cmdutil.Factory) *cli.Command {
+	return &cli.Command{
+		Name:      "log",
+		Usage:     "Log time against an entity",
+		ArgsUsage: "<entity-id>",
+		Description: `--spent and --remaining take durations such as 2h, 90m, or 1h30m; a bare
+number is hours. They are sent as decimal hours, rounded to two places.`,
+		Flags: []cli.Flag{
+			cmdutil.OutputFlag(),
+			&cli.IntFlag{Name: "entity-id", Usage: "Entity ID (alternative to positional argument)"},
+			&cli.StringFlag{Name: "spent", Required: true, Usage: "Time spent (e.g. 2h, 90m, 1.5)"},
+			&cli.StringFlag{Name: "remaining", Usage: "Time remaining on the entity after this entry"},
+			&cli.StringFlag{Name: "date", Usage: "Day the work was done, YYYY-MM-DD (default today)"},
+			&cli.StringFlag{Name: "description", Usage: "What the time was spent on"},
+			&cli.BoolFlag{Name: "no-mention-resolve", Usage: "Send @mentions as typed without looking up users"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			entityID, err := resolveEntityID(cmd, "log")
+			if err != nil {
+				return err
+			}
+
+			spent, err := parseHours(cmd.String("spent"))
+			if err != nil {
+				return fmt.Errorf("invalid --spent: %w", err)
+			}
+			if spent == 0 {
+				return errors.New("invalid --spent: must be more than zero")
+			}
+
+			fields := map[string]any{
+				"General": map[string]any{"Id": entityID},
+				"Spent":   spent,
+			}
+			if cmd.IsSet("remaining") {
+				remain, err := parseHours(cmd.String("remaining"))
+				if err != nil {
+					return fmt.Errorf("invalid --remaining: %w", err)
+				}
+				fields["Remain"] = remain
+			}
+			if date := cmd.String("date"); date != "" {
+				if _, err := time.Parse(dateLayout, date); err != nil {
+					return fmt.Errorf("invalid --date %q: use YYYY-MM-DD", date)
+				}
+				fields["Date"] = date
+			}
+			if desc := cmd.String("description"); desc != "" {
+				fields["Description"] = desc
+			}
+
+			client, err := f.Client()
+			if err != nil {
+				return err
+			}
+
+			if prepErr := text.PrepareFields(ctx, client, fields, text.PrepareOptions{
+				SkipMentions: cmd.Bool("no-mention-resolve"),
+				Warn:         f.Warnings(),
+			}); prepErr != nil {
+				return fmt.Errorf("preparing time fields: %w", prepErr)
+			}
+
+			entity, err := client.CreateEntity(ctx, "Time", fields)
+			if err != nil {
+				return fmt.Errorf("logging time: %w", err)
+			}
+
+			if cmdutil.IsStructured(cmd) {
+				return cmdutil.PrintStructured(cmd, entity)
+			}
+
+			output.PrintEntity(os.Stdout, entity)
+			return nil
+		},
+	}
+}
+
+func newListCmd(f *cmdutil.Factory) *cli.Command {
+	return &cli.Command{
+		Name:      "list",
+		Usage:     "List time logged on an entity",
+		ArgsUsage: "<entity-id>",
+		Flags: []cli.Flag{
+			cmdutil.OutputFlag(),
+			&cli.IntFlag{Name: "entity-id", Usage: "Entity ID (alternative to positional argument)"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			entityID, err := resolveEntityID(cmd, "list")
+			if err != nil {
+				return err
+			}
+
+			client, err := f.Client()
+			if err != nil {
+				return err
+			}
+
+			where := fmt.Sprintf("General.Id eq %d", entityID)
+			include := []string{"Spent", "Remain", "Date", "Description", "User"}
+
+			entries, err := client.SearchEntities(ctx, "Time", where, include, 0, []string{"Date"})
+			if err != nil {
+				return fmt.Errorf("listing time: %w", err)
+			}
+
+			if cmdutil.IsStructured(cmd) {
+				return cmdutil.PrintStructured(cmd, map[string]any{
+					"items": entries,
+					"count": len(entries),
+					"spent": totalSpent(entries),
+				})
+			}
+
+			printTimeTable(entries)
+			return nil
+		},
+	}
+}
+
+// parseHours converts a duration such as 2h, 90m, or 1h30m into decimal
+// hours rounded to two places. A bare number is taken as hours.
+func parseHours(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("duration is empty")
+	}
+	hours, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		d, durErr := time.ParseDuration(s)
+		if durErr != nil {
+			return 0, fmt.Errorf("%q is not a duration like 2h, 90m, or 1h30m", s)
+		}
+		hours = d.Hours()
+	}
+	if hours < 0 || math.IsNaN(hours) || math.IsInf(hours, 0) {
+		return 0, fmt.Errorf("%q must be a non-negative duration", s)
+	}
+	return math.Round(hours*100) / 100, nil
+}
+
+func resolveEntityID(cmd *cli.Command, sub string) (int, error) {
+	args := cmd.Args().Slice()
+	if len(args) > 0 {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return 0, fmt.Errorf("invalid entity ID %q: must be an integer", args[0])
+		}
+		if id <= 0 {
+			return 0, fmt.Errorf("entity ID must be positive, got %d", id)
+		}
+		return id, nil
+	}
+
+	if id := cmd.Int("entity-id"); id > 0 {
+		return id, nil
+	}
+
+	return 0, fmt.Errorf("entity ID is required; usage: tp time %s <entity-id> or tp time %s --entity-id <id>", sub, sub)
+}
+
+func printTimeTable(entries []api.Entity) {
+	if len(entries) == 0 {
+		fmt.Fprintln(os.Stdout, "No time logged.")
+		return
+	}
+
+	tw := output.NewTabWriter(os.Stdout)
+	fmt.Fprintln(tw, "ID\tDATE\tUSER\tSPENT\tREMAIN\tDESCRIPTION")
+	for _, e := range entries {
+		date := ""
+		if t, ok := api.ParseDate(e["Date"]); ok {
+			date = t.Format(dateLayout)
+		}
+		desc := ""
+		if d, ok := e["Description"].(string); ok {
+			desc = strings.Join(strings.Fields(text.MarkdownToPlain(d)), " ")
+		}
+		if r := []rune(desc); len(r) > 60 {
+			desc = string(r[:57]) + "..."
+		}
+		fmt.Fprintf(tw, "%v\t%s\t%s\t%s\t%s\t%s\n", e["Id"], date, userName(e), formatHours(e["Spent"]), formatHours(e["Remain"]), desc)
+	}
+	fmt.Fprintf(tw, "\t\tTotal\t%s\t\t\n", formatHours(totalSpent(entries)))
+	tw.Flush()
+}
+
+// totalSpent sums the Spent hours of entries.
+func totalSpent(entries []api.Entity) float64 {
+	var total float64
+	for _, e := range entries {
+		if h, ok := e["Spent"].(float64); ok {
+			total += h
+		}
+	}
+	return math.Round(total*100) / 100
+}
+
+// formatHours renders a JSON hours value as "1.5h", or "" if absent.
+func formatHours(v any) string {
+	h, ok := v.(float64)
+	if !ok {
+		return ""
+	}
+	return strconv.FormatFloat(h, 'f', -1, 64) + "h"
+}
+
+// userName returns a display name for the user who logged an entry.
+func userName(e api.Entity) string {
+	u, ok := e["User"].(map[string]any)
+	if !ok {
+		return ""
+	}
+	var parts []string
+	for _, key := range []string{"FirstName", "LastName"} {
+		if v, ok := u[key].(string); ok && v != "" {
+			parts = append(parts, v)
+		}
+	}
+	if len(parts) == 0 {
+		if login, ok := u["Login"].(string); ok {
+			return login
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,52 @@
+package timecmd
+
+import (
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+func TestParseHours(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"2h", 2},
+		{"90m", 1.5},
+		{"1h30m", 1.5},
+		{"20m", 0.33},
+		{"1.5", 1.5},
+		{" 4 ", 4},
+		{"0", 0},
+	}
+	for _, tt := range tests {
+		got, err := parseHours(tt.in)
+		if err != nil {
+			t.Errorf("parseHours(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseHours(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "two hours", "-1h", "-3"} {
+		if _, err := parseHours(in); err == nil {
+			t.Errorf("parseHours(%q) should fail", in)
+		}
+	}
+}
+
+func TestTotalSpent(t *testing.T) {
+	entries := []api.Entity{
+		{"Id": float64(1), "Spent": 1.25},
+		{"Id": float64(2), "Spent": float64(2)},
+		{"Id": float64(3)},
+	}
+	if got := totalSpent(entries); got != 3.25 {
+		t.Errorf("totalSpent() = %v, want 3.25", got)
+	}
+	if got := formatHours(1.5); got != "1.5h" {
+		t.Errorf("formatHours(1.5) = %q", got)
+	}
+}
@@ -0,0 +1,118 @@
+package update
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+// maxDiffValue is how many characters of a value the diff shows.
+const maxDiffValue = 80
+
+// fieldChange is one field whose value an update would change.
+type fieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// diffFields compares the fields about to be sent with the entity's current
+// values. Reference fields ({"Id": n}) are compared by ID. Fields that would
+// not change are left out. Custom fields are compared one by one and shown
+// as CustomFields.<name>.
+func diffFields(current api.Entity, fields map[string]any) []fieldChange {
+	var changes []fieldChange
+	for _, key := range sortedKeys(fields) {
+		newVal := fields[key]
+		oldVal := current[key]
+
+		if custom, ok := newVal.([]map[string]any); ok && key == "CustomFields" {
+			for _, cf := range custom {
+				name, _ := cf["Name"].(string)
+				old, _ := api.CustomFieldValue(current, name)
+				before, after := fmt.Sprintf("%v", valueOrEmpty(old)), fmt.Sprintf("%v", valueOrEmpty(cf["Value"]))
+				if before == after {
+					continue
+				}
+				changes = append(changes, fieldChange{Field: key + "." + name, Before: before, After: after})
+			}
+			continue
+		}
+
+		if ref, ok := newVal.(map[string]any); ok {
+			newID := fmt.Sprintf("%v", ref["Id"])
+			oldRef, _ := oldVal.(map[string]any)
+			if oldRef != nil && formatID(oldRef["Id"]) == newID {
+				continue
+			}
+			changes = append(changes, fieldChange{Field: key, Before: describeRef(oldRef), After: "#" + newID})
+			continue
+		}
+
+		before, after := fmt.Sprintf("%v", valueOrEmpty(oldVal)), fmt.Sprintf("%v", newVal)
+		if before == after {
+			continue
+		}
+		changes = append(changes, fieldChange{Field: key, Before: before, After: after})
+	}
+	return changes
+}
+
+// printDiff writes changes as a before/after list.
+func printDiff(w io.Writer, entityType string, id int, changes []fieldChange) {
+	fmt.Fprintf(w, "Changes to %s #%d:\n", entityType, id)
+	for _, c := range changes {
+		fmt.Fprintf(w, "  %s:\n    - %s\n    + %s\n", c.Field, shorten(c.Before), shorten(c.After))
+	}
+}
+
+// describeRef renders a current reference value as "Name (#Id)".
+func describeRef(ref map[string]any) string {
+	if ref == nil {
+		return "(none)"
+	}
+	id := formatID(ref["Id"])
+	if name, ok := ref["Name"].(string); ok && name != "" {
+		return fmt.Sprintf("%s (#%s)", name, id)
+	}
+	return "#" + id
+}
+
+// formatID renders a JSON number ID without a decimal point.
+func formatID(v any) string {
+	if f, ok := v.(float64); ok {
+		return fmt.Sprintf("%d", int64(f))
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// shorten flattens a value onto one line and truncates it for display.
+func shorten(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if s == "" {
+		return "(empty)"
+	}
+	if r := []rune(s); len(r) > maxDiffValue {
+		return string(r[:maxDiffValue]) + "..."
+	}
+	return s
+}
+
+func valueOrEmpty(v any) any {
+	if v == nil {
+		return ""
+	}
+	return v
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
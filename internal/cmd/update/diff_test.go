@@ -0,0 +1,75 @@
+package update
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+func TestDiffFields(t *testing.T) {
+	current := api.Entity{
+		"Name":        "Old title",
+		"Description": "Same text",
+		"EntityState": map[string]any{"Id": float64(100), "Name": "Open"},
+	}
+	fields := map[string]any{
+		"Name":         "New title",
+		"Description":  "Same text",
+		"EntityState":  map[string]any{"Id": 101},
+		"AssignedUser": map[string]any{"Id": 15},
+	}
+
+	changes := diffFields(current, fields)
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.Field+": "+c.Before+" -> "+c.After)
+	}
+	want := []string{
+		"AssignedUser: (none) -> #15",
+		"EntityState: Open (#100) -> #101",
+		"Name: Old title -> New title",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("diffFields() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if changes := diffFields(current, map[string]any{"EntityState": map[string]any{"Id": 100}}); len(changes) != 0 {
+		t.Errorf("same reference ID should not be a change, got %v", changes)
+	}
+}
+
+func TestPrintDiff_Shortens(t *testing.T) {
+	var buf bytes.Buffer
+	printDiff(&buf, "Bug", 7, []fieldChange{{Field: "Description", Before: "", After: strings.Repeat("x", 200)}})
+	out := buf.String()
+	if !strings.Contains(out, "- (empty)") || !strings.Contains(out, strings.Repeat("x", maxDiffValue)+"...") {
+		t.Errorf("unexpected diff output:\n%s", out)
+	}
+}
+
+func TestDiffFields_CustomFields(t *testing.T) {
+	current := api.Entity{"CustomFields": []any{
+		map[string]any{"Name": "Risk", "Value": "High"},
+		map[string]any{"Name": "Points", "Value": float64(3)},
+	}}
+	fields := map[string]any{"CustomFields": api.BuildCustomFields(map[string]string{
+		"Risk":   "Low",
+		"Points": "3",
+		"Notes":  "",
+		"Team":   "Core",
+	})}
+
+	var got []string
+	for _, c := range diffFields(current, fields) {
+		got = append(got, c.Field+": "+c.Before+" -> "+c.After)
+	}
+	want := []string{
+		"CustomFields.Risk: High -> Low",
+		"CustomFields.Team:  -> Core",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("diffFields() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,47 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+// checkTransition returns an error listing the allowed transitions if the
+// workflow does not let current move to targetID. Staying in the current
+// state is always allowed.
+func checkTransition(ctx context.Context, client *api.Client, current api.Entity, targetID int) error {
+	state, _ := current["EntityState"].(map[string]any)
+	if state == nil {
+		return fmt.Errorf("--validate-transition: current state of #%s is unknown", formatID(current["Id"]))
+	}
+	currentID, _ := state["Id"].(float64)
+	if int(currentID) == targetID {
+		return nil
+	}
+
+	next, err := client.NextStates(ctx, int(currentID))
+	if err != nil {
+		return fmt.Errorf("--validate-transition: %w", err)
+	}
+	for _, s := range next {
+		if s.ID == targetID {
+			return nil
+		}
+	}
+	return transitionError(describeRef(state), targetID, next)
+}
+
+// transitionError explains that targetID cannot follow from and lists the
+// states that can.
+func transitionError(from string, targetID int, next []api.EntityState) error {
+	if len(next) == 0 {
+		return fmt.Errorf("cannot move from %s to state %d: the workflow allows no transitions from this state", from, targetID)
+	}
+	valid := make([]string, len(next))
+	for i, s := range next {
+		valid[i] = fmt.Sprintf("  %d  %s", s.ID, s.Name)
+	}
+	return fmt.Errorf("cannot move from %s to state %d; valid transitions:\n%s", from, targetID, strings.Join(valid, "\n"))
+}
@@ -0,0 +1,39 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/testutil"
+)
+
+func TestCheckTransition(t *testing.T) {
+	ss := testutil.NewSimulationServer(&testutil.Simulation{Pairs: []testutil.Pair{{
+		Request:  testutil.Request{Method: "GET", Path: "/api/v2/EntityState/100"},
+		Response: testutil.Response{Status: 200, Body: json.RawMessage(`{"items":[{"nextStates":[{"id":101,"name":"In Progress"}]}]}`)},
+	}}})
+	defer ss.Close()
+
+	client := api.NewClient(ss.URL(), "tok", false)
+	current := api.Entity{"Id": float64(7), "EntityState": map[string]any{"Id": float64(100), "Name": "Open"}}
+	ctx := context.Background()
+
+	if err := checkTransition(ctx, client, current, 100); err != nil {
+		t.Errorf("staying in the current state: %v", err)
+	}
+	if err := checkTransition(ctx, client, current, 101); err != nil {
+		t.Errorf("allowed transition: %v", err)
+	}
+	err := checkTransition(ctx, client, current, 102)
+	if err == nil {
+		t.Fatal("expected an error for a disallowed transition")
+	}
+	for _, want := range []string{"Open (#100)", "state 102", "101  In Progress"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"strconv"
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/lifedraft/targetprocess-cli/internal/api"
 	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
 	"github.com/lifedraft/targetprocess-cli/internal/output"
 	"github.com/lifedraft/targetprocess-cli/internal/resolve"
@@ -27,16 +29,54 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
   # Change entity state
   tp update 67890 --state-id 100
 
+  # Check the workflow allows the move before sending it
+  tp update 67890 --state-id 100 --validate-transition
+
   # Update with explicit type (skips auto-detection)
-  tp update 111 --type Task --assigned-user-id 15 --description "Updated requirements"`,
+  tp update 111 --type Task --assigned-user-id 15 --description "Updated requirements"
+
+  # In scripts, fail instead of looking up the type when --type is missing
+  tp update 111 --type Task --name "Renamed" --no-detect
+
+  # Set custom fields (an empty value clears one)
+  tp update 12345 --custom "Severity Score=8" --custom "Release Notes="
+
+  # Skip the confirmation prompt (scripts never prompt)
+  tp update 12345 --name "New title" --yes
+
+  # Show the request without changing anything
+  tp update 12345 --description "See @timo's notes" --dry-run`,
+		Description: `Before sending the update, the current entity is fetched and the fields that
+would change are printed to stderr as a before/after diff. On an interactive
+terminal you are asked to confirm unless --yes is given; when stdin is not a
+terminal the update proceeds without prompting. If the diff shows no
+differences there is nothing to ask about, but the update is still sent: the
+server may normalize or coerce values in ways the diff cannot see.
+
+With --validate-transition, a --state-id change is checked against the
+workflow first: if the entity's current state cannot move to the target, the
+update fails locally and lists the states it can move to.
+
+With --confirm (or confirm_destructive set in config) the current entity is
+summarised too, and without a terminal the update fails unless --yes is given.
+
+--dry-run prints the request instead of the diff and sends nothing. Lookups
+needed to build it (type detection, @mentions) still run; they only read.`,
 		Flags: []cli.Flag{
 			cmdutil.OutputFlag(),
 			&cli.StringFlag{Name: "type", Usage: "Entity type (auto-detected if omitted)"},
+			cmdutil.NoDetectFlag(),
 			&cli.IntFlag{Name: "id", Usage: "Entity ID (alternative to positional argument)"},
 			&cli.StringFlag{Name: "name", Usage: "New name"},
 			&cli.StringFlag{Name: "description", Usage: "New description"},
 			&cli.IntFlag{Name: "state-id", Usage: "New entity state ID"},
+			&cli.BoolFlag{Name: "validate-transition", Usage: "Check the workflow allows moving to --state-id before updating"},
 			&cli.IntFlag{Name: "assigned-user-id", Usage: "New assigned user ID"},
+			cmdutil.CustomFlag(),
+			&cli.BoolFlag{Name: "no-mention-resolve", Usage: "Send @mentions as typed without looking up users"},
+			&cli.BoolFlag{Name: "yes", Aliases: []string{"y"}, Usage: "Apply the update without asking for confirmation"},
+			cmdutil.ConfirmFlag(),
+			&cli.BoolFlag{Name: "dry-run", Usage: "Print the request that would be sent (method, path, JSON body) without sending it"},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			id, err := resolveID(cmd)
@@ -44,12 +84,23 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
 				return err
 			}
 
+			entityType := resolve.EntityType(cmd.String("type"))
+			if err := resolve.CheckEntityType(entityType); err != nil {
+				return err
+			}
+			custom, err := cmdutil.CustomFields(cmd)
+			if err != nil {
+				return err
+			}
+			if err := cmdutil.CheckNoDetect(cmd, entityType); err != nil {
+				return err
+			}
+
 			client, err := f.Client()
 			if err != nil {
 				return err
 			}
 
-			entityType := resolve.EntityType(cmd.String("type"))
 			if entityType == "" {
 				entityType, err = client.ResolveEntityType(ctx, id)
 				if err != nil {
@@ -71,22 +122,82 @@ func NewCmd(f *cmdutil.Factory) *cli.Command {
 			if userID := cmd.Int("assigned-user-id"); userID > 0 {
 				fields["AssignedUser"] = map[string]any{"Id": userID}
 			}
+			if custom != nil {
+				fields["CustomFields"] = custom
+			}
 
 			if len(fields) == 0 {
-				return errors.New("no fields to update; specify at least one of --name, --description, --state-id, or --assigned-user-id")
+				return errors.New("no fields to update; specify at least one of --name, --description, --state-id, --assigned-user-id, or --custom")
 			}
 
-			if prepErr := text.PrepareFields(ctx, client, fields); prepErr != nil {
+			if prepErr := text.PrepareFields(ctx, client, fields, text.PrepareOptions{
+				SkipMentions: cmd.Bool("no-mention-resolve"),
+				Warn:         f.Warnings(),
+			}); prepErr != nil {
 				return prepErr
 			}
 
+			var current api.Entity
+			if stateID := cmd.Int("state-id"); stateID > 0 && cmd.Bool("validate-transition") {
+				current, err = client.GetEntity(ctx, entityType, id, nil)
+				if err != nil {
+					return fmt.Errorf("fetching current values: %w", err)
+				}
+				if err := checkTransition(ctx, client, current, stateID); err != nil {
+					return err
+				}
+			}
+
+			if cmd.Bool("dry-run") {
+				if err := api.ValidateEntityType(entityType); err != nil {
+					return err
+				}
+				return cmdutil.PrintDryRun(os.Stdout, cmd, http.MethodPost, api.EntityPath(entityType, id), fields)
+			}
+
+			if current == nil {
+				current, err = client.GetEntity(ctx, entityType, id, nil)
+				if err != nil {
+					return fmt.Errorf("fetching current values: %w", err)
+				}
+			}
+			// The diff only drives the prompt: it compares display values and
+			// can miss changes the server applies, so the update is sent even
+			// when it finds none.
+			changes := diffFields(current, fields)
+			confirm := f.ConfirmRequired(cmd)
+			prompt := !cmd.Bool("yes") && cmdutil.IsInteractive() && len(changes) > 0
+			if confirm {
+				fmt.Fprintln(os.Stderr, cmdutil.EntitySummary(entityType, id, current))
+			}
+			// The diff is what a prompt asks about; otherwise it is a note.
+			diffOut := f.Warnings()
+			if confirm || prompt {
+				diffOut = os.Stderr
+			}
+			if len(changes) == 0 {
+				fmt.Fprintf(diffOut, "No differences from the current values of %s #%d; sending the update anyway.\n", entityType, id)
+			} else {
+				printDiff(diffOut, entityType, id, changes)
+			}
+
+			if confirm {
+				if err := cmdutil.ConfirmDestructive(cmd.Bool("yes"), "Apply these changes?"); err != nil {
+					return fmt.Errorf("update: %w", err)
+				}
+			} else if prompt {
+				if !cmdutil.Confirm(os.Stdin, os.Stderr, "Apply these changes?") {
+					return errors.New("update cancelled")
+				}
+			}
+
 			entity, err := client.UpdateEntity(ctx, entityType, id, fields)
 			if err != nil {
 				return err
 			}
 
-			if cmdutil.IsJSON(cmd) {
-				return output.PrintJSON(os.Stdout, entity)
+			if cmdutil.IsStructured(cmd) {
+				return cmdutil.PrintStructured(cmd, entity)
 			}
 
 			output.PrintEntity(os.Stdout, entity)
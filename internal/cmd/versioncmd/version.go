@@ -0,0 +1,206 @@
+package versioncmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+)
+
+// latestReleaseURL is the GitHub API endpoint for the newest release.
+var latestReleaseURL = "https://api.github.com/repos/lifedraft/targetprocess-cli/releases/latest"
+
+// releaseCheckTTL is how long a cached release lookup is trusted.
+const releaseCheckTTL = 24 * time.Hour
+
+// release is the cached answer of a release lookup.
+type release struct {
+	Tag       string    `json:"tag"`
+	URL       string    `json:"url"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// NewCmd creates the "version" command.
+func NewCmd(f *cmdutil.Factory, version string) *cli.Command {
+	return &cli.Command{
+		Name:  "version",
+		Usage: "Print the CLI version, and with --check whether a newer release exists",
+		UsageText: `tp version
+
+  # Ask GitHub for the latest release (cached for a day)
+  tp version --check
+
+  # Offline: only report what an earlier check found
+  tp version --check --no-check`,
+		Description: `--check looks up the latest release on GitHub and caches the answer for 24
+hours next to the config file, so repeated checks don't hit GitHub. With
+--no-check (or TP_NO_UPDATE_CHECK set) the network is never used; a cached
+answer is still shown if there is one.`,
+		Flags: []cli.Flag{
+			cmdutil.OutputFlag(),
+			&cli.BoolFlag{Name: "check", Usage: "Check GitHub for a newer release"},
+			&cli.BoolFlag{
+				Name:    "no-check",
+				Usage:   "Never contact GitHub; --check only reports a cached result",
+				Sources: cli.EnvVars("TP_NO_UPDATE_CHECK"),
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if !cmd.Bool("check") {
+				if cmdutil.IsStructured(cmd) {
+					return cmdutil.PrintStructured(cmd, map[string]any{"version": version})
+				}
+				fmt.Printf("tp version %s\n", version)
+				return nil
+			}
+
+			latest, ok := cachedRelease(f.ReleaseCheckPath())
+			if !ok && !cmd.Bool("no-check") {
+				var err error
+				latest, err = fetchLatestRelease(ctx, version)
+				if err != nil {
+					return fmt.Errorf("checking for updates: %w", err)
+				}
+				saveRelease(f.ReleaseCheckPath(), latest)
+				ok = true
+			}
+
+			newer := ok && isNewer(latest.Tag, version)
+			if cmdutil.IsStructured(cmd) {
+				result := map[string]any{"version": version, "update_available": newer}
+				if ok {
+					result["latest"] = latest.Tag
+					result["url"] = latest.URL
+				}
+				return cmdutil.PrintStructured(cmd, result)
+			}
+
+			fmt.Printf("tp version %s\n", version)
+			switch {
+			case !ok:
+				fmt.Println("Update check skipped (--no-check) and no earlier result is cached.")
+			case newer:
+				fmt.Printf("A newer version is available: %s\n  %s\n", latest.Tag, latest.URL)
+			case version == "dev":
+				fmt.Printf("This is a development build; the latest release is %s.\n", latest.Tag)
+			default:
+				fmt.Println("You are on the latest release.")
+			}
+			return nil
+		},
+	}
+}
+
+// fetchLatestRelease asks GitHub for the newest release.
+func fetchLatestRelease(ctx context.Context, version string) (release, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, latestReleaseURL, http.NoBody)
+	if err != nil {
+		return release{}, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "tp-cli/"+version)
+
+	resp, err := http.DefaultClient.Do(req) //nolint:gosec // fixed GitHub API URL
+	if err != nil {
+		return release{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return release{}, fmt.Errorf("GitHub returned HTTP %d", resp.StatusCode)
+	}
+
+	var body struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return release{}, fmt.Errorf("reading GitHub response: %w", err)
+	}
+	if body.TagName == "" {
+		return release{}, errors.New("GitHub response has no release tag")
+	}
+	return release{Tag: body.TagName, URL: body.HTMLURL, CheckedAt: time.Now()}, nil
+}
+
+// isNewer reports whether tag is a later release than current. Versions
+// compare numerically part by part, ignoring a leading v and any
+// pre-release suffix; a current version that doesn't parse (dev) is never
+// out of date.
+func isNewer(tag, current string) bool {
+	latest, ok := parseVersion(tag)
+	if !ok {
+		return false
+	}
+	have, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	for i := range latest {
+		if latest[i] != have[i] {
+			return latest[i] > have[i]
+		}
+	}
+	return false
+}
+
+func parseVersion(v string) ([3]int, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return [3]int{}, false
+	}
+	var out [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return [3]int{}, false
+		}
+		out[i] = n
+	}
+	return out, true
+}
+
+// cachedRelease returns the cached lookup if it is still fresh.
+func cachedRelease(path string) (release, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return release{}, false
+	}
+	var r release
+	if err := json.Unmarshal(data, &r); err != nil || r.Tag == "" {
+		return release{}, false
+	}
+	if time.Since(r.CheckedAt) > releaseCheckTTL {
+		return release{}, false
+	}
+	return r, true
+}
+
+// saveRelease writes the cache best-effort; a failure only means the next
+// check asks GitHub again.
+func saveRelease(path string, r release) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
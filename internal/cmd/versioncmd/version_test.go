@@ -0,0 +1,69 @@
+package versioncmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+)
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		tag, current string
+		want         bool
+	}{
+		{"v1.3.0", "1.2.9", true},
+		{"v1.10.0", "1.9.0", true},
+		{"v1.2.0", "1.2.0", false},
+		{"v1.2.0", "v1.3.0", false},
+		{"v2.0.0-rc.1", "1.9.9", true},
+		{"v1.2.1", "1.2", true},
+		{"v1.2.0", "dev", false},
+		{"nightly", "1.0.0", false},
+	}
+	for _, tt := range tests {
+		if got := isNewer(tt.tag, tt.current); got != tt.want {
+			t.Errorf("isNewer(%q, %q) = %v, want %v", tt.tag, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestCheck_CachesRelease(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"tag_name":"v1.3.0","html_url":"https://github.com/lifedraft/targetprocess-cli/releases/tag/v1.3.0"}`)
+	}))
+	defer srv.Close()
+	orig := latestReleaseURL
+	latestReleaseURL = srv.URL
+	defer func() { latestReleaseURL = orig }()
+
+	f := &cmdutil.Factory{ConfigPath: filepath.Join(t.TempDir(), "config.yaml")}
+	for i := 0; i < 2; i++ {
+		if err := NewCmd(f, "1.2.0").Run(context.Background(), []string{"version", "--check", "-o", "json"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("GitHub was asked %d times, want 1 (second check should use the cache)", calls)
+	}
+	r, ok := cachedRelease(f.ReleaseCheckPath())
+	if !ok || r.Tag != "v1.3.0" {
+		t.Errorf("cachedRelease() = %+v, %v; want v1.3.0", r, ok)
+	}
+
+	// A stale cache is ignored, and --no-check never asks.
+	saveRelease(f.ReleaseCheckPath(), release{Tag: "v1.3.0", CheckedAt: time.Now().Add(-2 * releaseCheckTTL)})
+	if err := NewCmd(f, "1.2.0").Run(context.Background(), []string{"version", "--check", "--no-check", "-o", "json"}); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("--no-check contacted GitHub (%d calls)", calls)
+	}
+}
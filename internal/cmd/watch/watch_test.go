@@ -0,0 +1,109 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/testutil"
+)
+
+func newTestWatcher(t *testing.T, ss *testutil.SimulationServer, where string) (*watcher, *bytes.Buffer) {
+	t.Helper()
+	var out bytes.Buffer
+	return &watcher{
+		client:     api.NewClient(ss.URL(), "tok", false),
+		entityType: "Bug",
+		where:      where,
+		selectExpr: "id,name",
+		statePath:  filepath.Join(t.TempDir(), "watch-state.json"),
+		loc:        time.FixedZone("UTC+9", 9*3600),
+		out:        &out,
+	}, &out
+}
+
+func TestPollSeedsCursorOnFirstRun(t *testing.T) {
+	ss := testutil.NewSimulationServer(&testutil.Simulation{})
+	t.Cleanup(ss.Close)
+	w, out := newTestWatcher(t, ss, "")
+
+	state := map[string]string{}
+	if err := w.poll(context.Background(), state); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(ss.Requests()); n != 0 {
+		t.Errorf("first poll sent %d requests, want none", n)
+	}
+	if out.Len() != 0 {
+		t.Errorf("first poll printed %q, want nothing", out.String())
+	}
+
+	cursor, err := time.ParseInLocation(timeLayout, state["Bug||id,name"], w.loc)
+	if err != nil {
+		t.Fatalf("cursor %q: %v (state %v)", state["Bug||id,name"], err, state)
+	}
+	// The cursor is written in the account timezone, not the machine's.
+	if d := time.Since(cursor); d < 0 || d > time.Minute {
+		t.Errorf("cursor %s is %s from now, want the poll time", cursor, d)
+	}
+
+	saved, err := loadState(w.statePath)
+	if err != nil || saved["Bug||id,name"] != state["Bug||id,name"] {
+		t.Errorf("saved state = %v, %v; want %v", saved, err, state)
+	}
+}
+
+func TestPollEmitsChangedItems(t *testing.T) {
+	where := "project.id==42 or project.id==43"
+	ss := testutil.NewSimulationServer(&testutil.Simulation{Pairs: []testutil.Pair{{
+		Request: testutil.Request{
+			Method: "GET",
+			Path:   "/api/v2/Bug",
+			Query: map[string]string{
+				"where": `(project.id==42 or project.id==43) and (modifyDate>=DateTime.Parse("2024-05-01T10:00:00"))`,
+			},
+		},
+		Response: testutil.Response{
+			Status: 200,
+			Body:   []byte(`{"items":[{"id":1,"name":"First"},{"id":2,"name":"Second"}]}`),
+		},
+	}}})
+	t.Cleanup(ss.Close)
+	w, out := newTestWatcher(t, ss, where)
+
+	key := "Bug|" + where + "|id,name"
+	state := map[string]string{
+		key:            "2024-05-01T10:00:00",
+		"Bug||id,name": "2024-01-01T00:00:00",
+	}
+	if err := w.poll(context.Background(), state); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"First"`) || !strings.Contains(lines[1], `"Second"`) {
+		t.Errorf("output = %q, want one JSON line per changed item", out.String())
+	}
+	if state[key] == "2024-05-01T10:00:00" {
+		t.Error("cursor was not advanced after the poll")
+	}
+	if state["Bug||id,name"] != "2024-01-01T00:00:00" {
+		t.Errorf("a watch with another filter had its cursor moved to %q", state["Bug||id,name"])
+	}
+}
+
+func TestRunStopsCleanlyWhenCanceled(t *testing.T) {
+	ss := testutil.NewSimulationServer(&testutil.Simulation{})
+	t.Cleanup(ss.Close)
+	w, _ := newTestWatcher(t, ss, "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := w.run(ctx, time.Hour, false); err != nil {
+		t.Errorf("run after cancel = %v, want nil", err)
+	}
+}
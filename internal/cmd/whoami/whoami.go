@@ -0,0 +1,68 @@
+package whoami
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/cmdutil"
+)
+
+// NewCmd creates the "whoami" command.
+func NewCmd(f *cmdutil.Factory) *cli.Command {
+	return &cli.Command{
+		Name:  "whoami",
+		Usage: "Show the user the configured credentials authenticate as",
+		Description: `Also a quick auth check: it fails with a clear message if the
+domain or token is rejected.`,
+		UsageText: `tp whoami
+  tp whoami -o json`,
+		Flags: []cli.Flag{cmdutil.OutputFlag()},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			client, err := f.Client()
+			if err != nil {
+				return err
+			}
+
+			user, err := client.CurrentUser(ctx)
+			if err != nil {
+				return authError(client.BaseURL, err)
+			}
+
+			name := strings.TrimSpace(user.FirstName + " " + user.LastName)
+			if cmdutil.IsStructured(cmd) {
+				return cmdutil.PrintStructured(cmd, map[string]any{
+					"id":     user.ID,
+					"login":  user.Login,
+					"name":   name,
+					"email":  user.Email,
+					"domain": client.BaseURL,
+				})
+			}
+
+			fmt.Printf("login:  %s\n", user.Login)
+			fmt.Printf("name:   %s\n", name)
+			fmt.Printf("id:     %d\n", user.ID)
+			if user.Email != "" {
+				fmt.Printf("email:  %s\n", user.Email)
+			}
+			fmt.Printf("domain: %s\n", client.BaseURL)
+			return nil
+		},
+	}
+}
+
+// authError turns a rejected-credentials response into a friendly message
+// and passes other errors through.
+func authError(domain string, err error) error {
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden) {
+		return fmt.Errorf("not authenticated: %s rejected the credentials (HTTP %d); check the token with: tp config get token, or run: tp login", domain, apiErr.StatusCode)
+	}
+	return err
+}
@@ -0,0 +1,22 @@
+package whoami
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+func TestAuthError(t *testing.T) {
+	unauthorized := fmt.Errorf("fetching context: %w", &api.APIError{StatusCode: 401, Body: "Unauthorized"})
+	if err := authError("https://x.tpondemand.com", unauthorized); !strings.Contains(err.Error(), "not authenticated") {
+		t.Errorf("401 should give a not-authenticated message, got %q", err)
+	}
+
+	other := errors.New("dial tcp: connection refused")
+	if err := authError("https://x.tpondemand.com", other); !errors.Is(err, other) {
+		t.Errorf("non-auth errors should pass through, got %q", err)
+	}
+}
@@ -0,0 +1,38 @@
+package cmdutil
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+// AutoQuoteFlag returns the --auto-quote flag read by WhereFlag.
+func AutoQuoteFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "auto-quote",
+		Usage: "Quote bare words compared against in --where (name==login becomes name==\"login\")",
+	}
+}
+
+// WhereFlag returns the --where value, ANDed with the --where-file contents
+// when the command has that flag. With --auto-quote, comparisons against
+// bare words are quoted and each rewrite is reported on warn; without it they
+// are only warned about, since v2 reads a bare word as a field name.
+func WhereFlag(cmd *cli.Command, warn io.Writer) (string, error) {
+	where, err := whereWithFile(cmd)
+	if err != nil {
+		return "", err
+	}
+	if !cmd.Bool("auto-quote") {
+		fmt.Fprint(warn, api.WarnWhereBareLiterals(where))
+		return where, nil
+	}
+	quoted, values := api.QuoteBareLiterals(where)
+	for _, v := range values {
+		fmt.Fprintf(warn, "Warning: --auto-quote treated %s as a string: %q\n", v, v)
+	}
+	return quoted, nil
+}
@@ -0,0 +1,49 @@
+package cmdutil
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// v2DateLayout is the format DateTime.Parse expects in v2 where clauses.
+const v2DateLayout = "2006-01-02T15:04:05"
+
+// relativeDaysRe matches relative day offsets like "7d".
+var relativeDaysRe = regexp.MustCompile(`^(\d+)d$`)
+
+// localLayouts are accepted timestamps without a zone; they are taken to be
+// in the account's timezone already and passed through unchanged.
+var localLayouts = []string{v2DateLayout, "2006-01-02 15:04:05", "2006-01-02"}
+
+// ChangedSinceClause returns a v2 where clause matching entities modified at
+// or after value. value is either a relative offset in days ("7d", which
+// becomes Today.AddDays(-7)), a timestamp without a zone (taken as account
+// time), or an RFC 3339 timestamp with a zone, which is converted to the
+// account timezone loc.
+func ChangedSinceClause(value string, loc *time.Location) (string, error) {
+	value = strings.TrimSpace(value)
+	if m := relativeDaysRe.FindStringSubmatch(value); m != nil {
+		days, err := strconv.Atoi(m[1])
+		if err != nil {
+			return "", fmt.Errorf("invalid changed-since %q: %w", value, err)
+		}
+		return fmt.Sprintf("modifyDate>=Today.AddDays(-%d)", days), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return modifyDateClause(t.In(loc)), nil
+	}
+	for _, layout := range localLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return modifyDateClause(t), nil
+		}
+	}
+	return "", fmt.Errorf("invalid changed-since %q: use a relative form like 7d, or a timestamp like 2024-01-01T00:00:00 (optionally with a zone, e.g. 2024-01-01T00:00:00Z)", value)
+}
+
+func modifyDateClause(t time.Time) string {
+	return fmt.Sprintf(`modifyDate>=DateTime.Parse("%s")`, t.Format(v2DateLayout))
+}
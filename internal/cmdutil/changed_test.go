@@ -0,0 +1,38 @@
+package cmdutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChangedSinceClause(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "7d", want: "modifyDate>=Today.AddDays(-7)"},
+		{value: "2024-01-01T00:00:00", want: `modifyDate>=DateTime.Parse("2024-01-01T00:00:00")`},
+		{value: "2024-01-01", want: `modifyDate>=DateTime.Parse("2024-01-01T00:00:00")`},
+		{value: "2024-01-01T00:00:00Z", want: `modifyDate>=DateTime.Parse("2024-01-01T01:00:00")`},
+		{value: "2024-07-01T12:00:00+02:00", want: `modifyDate>=DateTime.Parse("2024-07-01T12:00:00")`},
+		{value: "yesterday", wantErr: true},
+		{value: "-7d", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ChangedSinceClause(tt.value, berlin)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ChangedSinceClause(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ChangedSinceClause(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
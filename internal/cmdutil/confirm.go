@@ -0,0 +1,87 @@
+package cmdutil
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+// ConfirmFlag returns the --confirm flag for commands that change or delete
+// entities.
+func ConfirmFlag() *cli.BoolFlag {
+	return &cli.BoolFlag{
+		Name:  "confirm",
+		Usage: "Show the current entity and ask before applying; fails without a terminal unless --yes (default from confirm_destructive)",
+	}
+}
+
+// ConfirmRequired reports whether a destructive command must confirm before
+// applying: --confirm was given or confirm_destructive is set.
+func (f *Factory) ConfirmRequired(cmd *cli.Command) bool {
+	if cmd.Bool("confirm") {
+		return true
+	}
+	cfg, err := f.Config()
+	return err == nil && cfg.ConfirmDestructive
+}
+
+// ConfirmDestructive asks on stderr before a destructive change. yes skips
+// the prompt. Without a terminal to ask on it fails rather than proceeding
+// or waiting on input that will never come.
+func ConfirmDestructive(yes bool, prompt string) error {
+	if yes {
+		return nil
+	}
+	if !IsInteractive() {
+		return errors.New("confirmation required but stdin is not a terminal; pass --yes to proceed")
+	}
+	if !Confirm(os.Stdin, os.Stderr, prompt) {
+		return errors.New("cancelled")
+	}
+	return nil
+}
+
+// EntitySummary describes an entity on one line for confirmation prompts:
+// type, id, name (or the start of a comment), state, and owner or assignee.
+func EntitySummary(entityType string, id int, e api.Entity) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s #%d", entityType, id)
+	if name, ok := e["Name"].(string); ok && name != "" {
+		fmt.Fprintf(&b, " %q", name)
+	} else if desc, ok := e["Description"].(string); ok && desc != "" {
+		fmt.Fprintf(&b, " %q", summaryText(desc))
+	}
+	if state, ok := e["EntityState"].(map[string]any); ok {
+		if name, ok := state["Name"].(string); ok {
+			fmt.Fprintf(&b, ", state: %s", name)
+		}
+	}
+	if owner, ok := e["Owner"].(map[string]any); ok {
+		if name := userName(owner); name != "" {
+			fmt.Fprintf(&b, ", by %s", name)
+		}
+	}
+	return b.String()
+}
+
+// summaryText strips the markdown marker and shortens text to one line.
+func summaryText(s string) string {
+	s = strings.TrimPrefix(s, "<!--markdown-->")
+	s = strings.Join(strings.Fields(s), " ")
+	const maxLen = 60
+	if r := []rune(s); len(r) > maxLen {
+		return string(r[:maxLen]) + "..."
+	}
+	return s
+}
+
+func userName(u map[string]any) string {
+	first, _ := u["FirstName"].(string)
+	last, _ := u["LastName"].(string)
+	return strings.TrimSpace(first + " " + last)
+}
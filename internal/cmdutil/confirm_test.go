@@ -0,0 +1,38 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+func TestEntitySummary(t *testing.T) {
+	tests := []struct {
+		typ    string
+		entity api.Entity
+		want   string
+	}{
+		{
+			"UserStory",
+			api.Entity{"Name": "Login page", "EntityState": map[string]any{"Name": "Open"}},
+			`UserStory #7 "Login page", state: Open`,
+		},
+		{
+			"Comment",
+			api.Entity{"Description": "<!--markdown-->Looks\n good", "Owner": map[string]any{"FirstName": "Ann", "LastName": "Lee"}},
+			`Comment #7 "Looks good", by Ann Lee`,
+		},
+		{"Bug", api.Entity{}, "Bug #7"},
+	}
+	for _, tt := range tests {
+		if got := EntitySummary(tt.typ, 7, tt.entity); got != tt.want {
+			t.Errorf("EntitySummary(%s) = %q, want %q", tt.typ, got, tt.want)
+		}
+	}
+}
+
+func TestConfirmDestructive_YesSkipsPrompt(t *testing.T) {
+	if err := ConfirmDestructive(true, "Delete?"); err != nil {
+		t.Errorf("ConfirmDestructive(yes) error = %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package cmdutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+// CustomFlag returns the repeatable --custom "Field Name=value" flag read by
+// CustomFields.
+func CustomFlag() cli.Flag {
+	return &RepeatableFlag{
+		Name:  "custom",
+		Usage: `Set a custom field as "Name=value" (repeatable); true/false and numbers are typed, "quoted" values stay text, an empty value clears`,
+	}
+}
+
+// CustomFields builds the CustomFields array from the --custom flags, or
+// returns nil when none were given.
+func CustomFields(cmd *cli.Command) ([]map[string]any, error) {
+	specs := cmd.StringSlice("custom")
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	values := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		name, value, ok := strings.Cut(spec, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --custom %q: expected \"Field Name=value\"", spec)
+		}
+		if _, dup := values[name]; dup {
+			return nil, fmt.Errorf("--custom sets %q twice", name)
+		}
+		values[name] = value
+	}
+	return api.BuildCustomFields(values), nil
+}
@@ -0,0 +1,49 @@
+package cmdutil
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+)
+
+func TestCustomFields(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    []map[string]any
+		wantErr bool
+	}{
+		{nil, nil, false},
+		{
+			[]string{"--custom", "Release Notes=Fixed a, b and c", "--custom", "Points = 3"},
+			[]map[string]any{{"Name": "Points", "Value": float64(3)}, {"Name": "Release Notes", "Value": "Fixed a, b and c"}},
+			false,
+		},
+		{[]string{"--custom", "Formula=a=b"}, []map[string]any{{"Name": "Formula", "Value": "a=b"}}, false},
+		{[]string{"--custom", "novalue"}, nil, true},
+		{[]string{"--custom", "=5"}, nil, true},
+		{[]string{"--custom", "Risk=High", "--custom", "Risk=Low"}, nil, true},
+	}
+	for _, tt := range tests {
+		var got []map[string]any
+		cmd := &cli.Command{
+			Flags: []cli.Flag{CustomFlag()},
+			Action: func(_ context.Context, cmd *cli.Command) error {
+				var err error
+				got, err = CustomFields(cmd)
+				return err
+			},
+		}
+		err := cmd.Run(context.Background(), append([]string{"tp"}, tt.args...))
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("CustomFields(%q) = %v, want error", tt.args, got)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("CustomFields(%q) = %v, %v; want %v", tt.args, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package cmdutil
+
+import (
+	"errors"
+
+	"github.com/urfave/cli/v3"
+)
+
+// NoDetectFlag returns the --no-detect flag for commands that look up an
+// entity's type when --type is omitted.
+func NoDetectFlag() *cli.BoolFlag {
+	return &cli.BoolFlag{
+		Name:  "no-detect",
+		Usage: "Require --type instead of looking up the entity's type (saves a request per ID in scripts)",
+	}
+}
+
+// CheckNoDetect returns an error if --no-detect is set and no entity type was
+// given, so scripts fail fast rather than paying for a lookup.
+func CheckNoDetect(cmd *cli.Command, entityType string) error {
+	if cmd.Bool("no-detect") && entityType == "" {
+		return errors.New("--no-detect requires --type")
+	}
+	return nil
+}
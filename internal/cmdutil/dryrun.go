@@ -0,0 +1,42 @@
+package cmdutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/output"
+)
+
+// PrintDryRun describes a write request that --dry-run skipped: the method
+// and path, then the JSON body if there is one. With -o json or -o yaml it
+// prints a single object with dryRun, method, path, and body.
+func PrintDryRun(w io.Writer, cmd *cli.Command, method, path string, body map[string]any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	// Descriptions carry a <!--markdown--> prefix; keep it readable.
+	enc.SetEscapeHTML(false)
+	if IsStructured(cmd) {
+		summary := map[string]any{
+			"dryRun": true,
+			"method": method,
+			"path":   path,
+			"body":   body,
+		}
+		if cmd.String("output") == "yaml" {
+			return output.PrintYAML(w, summary)
+		}
+		return enc.Encode(summary)
+	}
+
+	fmt.Fprintf(w, "%s %s\n", method, path)
+	if body == nil {
+		return nil
+	}
+	if err := enc.Encode(body); err != nil {
+		return fmt.Errorf("encoding request body: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package cmdutil
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+)
+
+func TestPrintDryRun(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &cli.Command{
+		Flags: []cli.Flag{OutputFlag()},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return PrintDryRun(&buf, cmd, "POST", "/api/v1/Bugs", map[string]any{"Description": "<!--markdown-->hi"})
+		},
+	}
+	if err := cmd.Run(context.Background(), []string{"tp"}); err != nil {
+		t.Fatal(err)
+	}
+	want := "POST /api/v1/Bugs\n{\n  \"Description\": \"<!--markdown-->hi\"\n}\n"
+	if buf.String() != want {
+		t.Errorf("PrintDryRun() = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,86 @@
+package cmdutil
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+// fieldPathRe matches a v2 field path such as "name" or "entityState.name".
+var fieldPathRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*(\.[A-Za-z][A-Za-z0-9]*)*$`)
+
+// EqClause turns a "field:value" (or "field=value") spec into field=="value"
+// with the value safely quoted. See FilterClause for the value rules.
+func EqClause(spec string) (string, error) {
+	return FilterClause("eq", spec)
+}
+
+// AppendEqClauses ANDs an EqClause for each spec onto where.
+func AppendEqClauses(where string, specs []string) (string, error) {
+	clauses := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		clause, err := EqClause(spec)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, clause)
+	}
+	return AndWhere(where, strings.Join(clauses, " and ")), nil
+}
+
+// splitFilterSpec splits "field:value" or "field=value" at the first
+// separator. Field paths contain neither, so values may contain both.
+func splitFilterSpec(flag, spec string) (field, value string, err error) {
+	i := strings.IndexAny(spec, ":=")
+	if i < 0 || strings.TrimSpace(spec[:i]) == "" {
+		return "", "", fmt.Errorf("invalid --%s %q: expected field:value", flag, spec)
+	}
+	field, value = strings.TrimSpace(spec[:i]), spec[i+1:]
+	if !fieldPathRe.MatchString(field) {
+		return "", "", fmt.Errorf("invalid --%s %q: %q is not a field path", flag, spec, field)
+	}
+	return field, value, nil
+}
+
+// isIDField reports whether field is an entity ID (id or *.id), which v2
+// compares as an integer.
+func isIDField(field string) bool {
+	return field == "id" || strings.HasSuffix(field, ".id")
+}
+
+// literal renders value as a v2 literal for field. null stays a bare null;
+// IDs must be integers; with numeric set, numbers are left unquoted (for
+// ordering comparisons); everything else is a quoted string.
+func literal(flag, spec, field, value string, numeric bool) (string, error) {
+	if value == "null" {
+		return "null", nil
+	}
+	if isIDField(field) {
+		if _, err := strconv.Atoi(strings.TrimSpace(value)); err != nil {
+			return "", fmt.Errorf("invalid --%s %q: %s must be an integer", flag, spec, field)
+		}
+		return strings.TrimSpace(value), nil
+	}
+	if numeric {
+		if _, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			return strings.TrimSpace(value), nil
+		}
+	}
+	return api.QuoteV2String(value), nil
+}
+
+// AndWhere combines two v2 where expressions with "and", parenthesizing both
+// sides: v2 binds "and" tighter than "or", so a bare join would turn
+// "a or b" into "a or (b and clause)". An empty side yields the other.
+func AndWhere(where, clause string) string {
+	switch {
+	case strings.TrimSpace(where) == "":
+		return clause
+	case strings.TrimSpace(clause) == "":
+		return where
+	}
+	return "(" + where + ") and (" + clause + ")"
+}
@@ -0,0 +1,59 @@
+package cmdutil
+
+import "testing"
+
+func TestEqClause(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr bool
+	}{
+		{"name:O'Brien's bug", `name=="O'Brien's bug"`, false},
+		{`name:say "hi"`, `name=="say \"hi\""`, false},
+		{`name:C:\temp`, `name=="C:\\temp"`, false},
+		{"entityState.name:In Progress", `entityState.name=="In Progress"`, false},
+		{"name:", `name==""`, false},
+		{"project.id:42", "project.id==42", false},
+		{"project.id:abc", "", true},
+		{"noseparator", "", true},
+		{":value", "", true},
+		{"bad field:x", "", true},
+	}
+	for _, tt := range tests {
+		got, err := EqClause(tt.spec)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("EqClause(%q) = %q, want error", tt.spec, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("EqClause(%q) = %q, %v; want %q", tt.spec, got, err, tt.want)
+		}
+	}
+}
+
+func TestAppendEqClauses(t *testing.T) {
+	got, err := AppendEqClauses("effort>0", []string{"name:a", "project.id:1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `(effort>0) and (name=="a" and project.id==1)`; got != want {
+		t.Errorf("AppendEqClauses() = %q, want %q", got, want)
+	}
+}
+
+func TestAndWhere(t *testing.T) {
+	tests := []struct {
+		where, clause, want string
+	}{
+		{"", "id>1", "id>1"},
+		{"id>1", "", "id>1"},
+		{"a==1 or b==2", "id>1", "(a==1 or b==2) and (id>1)"},
+	}
+	for _, tt := range tests {
+		if got := AndWhere(tt.where, tt.clause); got != tt.want {
+			t.Errorf("AndWhere(%q, %q) = %q, want %q", tt.where, tt.clause, got, tt.want)
+		}
+	}
+}
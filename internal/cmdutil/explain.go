@@ -0,0 +1,86 @@
+package cmdutil
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/output"
+)
+
+// QueryPlan describes a v2 request for --explain.
+type QueryPlan struct {
+	// TypeArg is the entity type as the user typed it; EntityType is what
+	// it resolved to.
+	TypeArg    string
+	EntityType string
+	// ID is set for a single-entity request, which sends only the select.
+	ID     int
+	Params api.V2Params
+	URL    string
+}
+
+// PrintQueryPlan writes an annotated breakdown of plan: the resolved type,
+// each select field and the key it comes back under (with the select
+// warnings inline), the filter, order, and paging, and the URL with
+// credentials redacted.
+func PrintQueryPlan(w io.Writer, plan QueryPlan) {
+	entityType := plan.EntityType
+	if plan.TypeArg != "" && plan.TypeArg != plan.EntityType {
+		entityType += fmt.Sprintf(" (from %q)", plan.TypeArg)
+	}
+	fmt.Fprintf(w, "Entity type:  %s\n", entityType)
+	if plan.ID > 0 {
+		fmt.Fprintf(w, "Entity ID:    %d\n", plan.ID)
+	}
+
+	fields := api.SelectFields(plan.Params.Select)
+	if len(fields) == 0 {
+		fmt.Fprintln(w, "Select:       (none: the API's default fields)")
+	} else {
+		fmt.Fprintln(w, "Select:")
+		tw := output.NewTabWriter(w)
+		for _, field := range fields {
+			key := "key " + field.Key
+			switch {
+			case field.Dropped:
+				key = "dropped (no alias)"
+			case field.Key == "":
+				key = "key chosen by the API (add 'as <name>' to fix it)"
+			}
+			fmt.Fprintf(tw, "  %s\t-> %s\n", field.Expr, key)
+		}
+		tw.Flush()
+		fmt.Fprint(w, indent(api.WarnSelectDotPaths(plan.Params.Select), "  "))
+		fmt.Fprint(w, indent(api.ValidateSelect(plan.Params.Select), "  "))
+	}
+	if plan.ID > 0 {
+		fmt.Fprintf(w, "URL:          %s\n", api.RedactURL(plan.URL))
+		return
+	}
+
+	fmt.Fprintf(w, "Where:        %s\n", orNone(plan.Params.Where, "(none: every entity)"))
+	fmt.Fprintf(w, "Order by:     %s\n", orNone(plan.Params.OrderBy, "(API default)"))
+	fmt.Fprintf(w, "Take:         %d\n", plan.Params.Take)
+	fmt.Fprintf(w, "Skip:         %d\n", plan.Params.Skip)
+	fmt.Fprintf(w, "URL:          %s\n", api.RedactURL(plan.URL))
+}
+
+func orNone(s, none string) string {
+	if strings.TrimSpace(s) == "" {
+		return none
+	}
+	return s
+}
+
+// indent prefixes every non-empty line of s.
+func indent(s, prefix string) string {
+	lines := strings.SplitAfter(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "")
+}
@@ -0,0 +1,43 @@
+package cmdutil
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+func TestPrintQueryPlan(t *testing.T) {
+	var buf bytes.Buffer
+	PrintQueryPlan(&buf, QueryPlan{
+		TypeArg:    "bugs",
+		EntityType: "Bug",
+		Params:     api.V2Params{Select: "id,project.name,entityState.name as state", Where: "effort>3", Take: 25},
+		URL:        "https://x.tpondemand.com/api/v2/Bug?access_token=secret&take=25",
+	})
+	out := buf.String()
+	for _, want := range []string{
+		`Entity type:  Bug (from "bugs")`,
+		"  id                         -> key id\n",
+		"  project.name               -> dropped (no alias)\n",
+		"  entityState.name as state  -> key state\n",
+		"    - project.name  (add: project.name as name)\n",
+		"Where:        effort>3\n",
+		"Order by:     (API default)\n",
+		"Take:         25\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("plan missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "secret") {
+		t.Errorf("plan leaks the token:\n%s", out)
+	}
+
+	buf.Reset()
+	PrintQueryPlan(&buf, QueryPlan{EntityType: "UserStory", ID: 12, URL: "https://x.tpondemand.com/api/v2/UserStory/12"})
+	if out := buf.String(); !strings.Contains(out, "Entity ID:    12\n") || strings.Contains(out, "Where:") {
+		t.Errorf("single-entity plan:\n%s", out)
+	}
+}
@@ -1,12 +1,19 @@
 package cmdutil
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/urfave/cli/v3"
 
 	"github.com/lifedraft/targetprocess-cli/internal/api"
 	"github.com/lifedraft/targetprocess-cli/internal/config"
+	"github.com/lifedraft/targetprocess-cli/internal/output"
 )
 
 // Factory provides shared dependencies to all commands.
@@ -14,6 +21,39 @@ type Factory struct {
 	ConfigPath string
 	Debug      bool
 
+	// VersionCheck warns once per client if the instance's product version
+	// is outside the range the CLI was tested against.
+	VersionCheck bool
+
+	// LogFile, if set, receives a JSON line per HTTP request (see api.LoggingTransport).
+	LogFile string
+
+	// HARFile, if set, receives a HAR trace of every HTTP request (see api.HARTransport).
+	HARFile string
+
+	// Width, if positive, overrides output_width and terminal detection
+	// (see OutputWidth).
+	Width int
+
+	// Timeout, if non-nil, overrides the timeout config key (see
+	// RequestTimeout). Zero disables the timeout.
+	Timeout *time.Duration
+
+	// Quiet suppresses warnings and hints (see Warnings).
+	Quiet bool
+
+	// Verbose logs each HTTP request and other detail short of Debug (see
+	// api.Client.Verbose). Quiet wins.
+	Verbose bool
+
+	// Offline marks a run that must not reach the network, such as query
+	// --explain or --dry-run; Client then skips the VersionCheck request.
+	Offline bool
+
+	// CacheTTL, if positive, serves repeated GET requests from an on-disk
+	// cache for that long (see api.CachingTransport).
+	CacheTTL time.Duration
+
 	cfgOnce    sync.Once
 	cfg        *config.Config
 	cfgErr     error
@@ -43,17 +83,98 @@ func (f *Factory) Client() (*api.Client, error) {
 			return
 		}
 		f.client = api.NewClient(cfg.Domain, cfg.Token, f.Debug)
+		f.client.Verbose = f.Verbose && !f.IsQuiet()
+		f.client.AuthMode = api.AuthMode(cfg.AuthMode)
+		f.client.Username = cfg.Username
+		f.client.Password = cfg.Password
+		if cfg.MaxRetryWait > 0 {
+			f.client.MaxRetryWait = time.Duration(cfg.MaxRetryWait) * time.Second
+		}
+		timeout, ok, err := f.RequestTimeout()
+		if err != nil {
+			f.clientErr = err
+			return
+		}
+		if ok {
+			f.client.SetTimeout(timeout)
+		}
+		if f.LogFile != "" {
+			file, err := os.OpenFile(f.LogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+			if err != nil {
+				f.clientErr = fmt.Errorf("opening log file: %w", err)
+				return
+			}
+			// Left open for the life of the process; the OS closes it on exit.
+			f.client.LogTo(file)
+		}
+		if f.HARFile != "" {
+			f.client.RecordHAR(f.HARFile)
+		}
+		if f.CacheTTL > 0 {
+			f.client.CacheTo(f.cacheDir(), f.CacheTTL)
+		}
+		if f.VersionCheck && !f.Offline {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			CheckAPIVersion(ctx, f.client, f.versionCachePath(), f.Warnings())
+		}
 	})
 	return f.client, f.clientErr
 }
 
+// RequestTimeout returns the timeout from --timeout, else from the timeout
+// config key. ok is false when neither is set, leaving the client default in
+// place; a zero duration means no timeout.
+func (f *Factory) RequestTimeout() (d time.Duration, ok bool, err error) {
+	if f.Timeout != nil {
+		return *f.Timeout, true, nil
+	}
+	cfg, err := f.Config()
+	if err != nil {
+		return 0, false, err
+	}
+	return cfg.RequestTimeout()
+}
+
+// versionCachePath keeps the version check cache next to the config file.
+func (f *Factory) versionCachePath() string {
+	configPath := f.ConfigPath
+	if configPath == "" {
+		configPath = config.DefaultPath()
+	}
+	return filepath.Join(filepath.Dir(configPath), "version-check.json")
+}
+
+// ReleaseCheckPath is where tp version --check caches the latest release,
+// next to the config file.
+func (f *Factory) ReleaseCheckPath() string {
+	return filepath.Join(filepath.Dir(f.versionCachePath()), "release-check.json")
+}
+
+// WatchStatePath is where watch-changes keeps its poll cursors by default,
+// next to the config file, so each config has its own.
+func (f *Factory) WatchStatePath() string {
+	return filepath.Join(filepath.Dir(f.versionCachePath()), "watch-state.json")
+}
+
+// cacheDir is where --cache keeps responses: the user cache directory, or
+// next to the config file if there is none.
+func (f *Factory) cacheDir() string {
+	if dir, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(dir, "tp", "http")
+	}
+	return filepath.Join(filepath.Dir(f.versionCachePath()), "http-cache")
+}
+
 // OutputFlag returns the standard --output flag for use in commands.
-func OutputFlag() *cli.StringFlag {
+// Commands that support formats beyond text and json list them in extra.
+func OutputFlag(extra ...string) *cli.StringFlag {
+	formats := append([]string{"text", "json", "yaml"}, extra...)
 	return &cli.StringFlag{
 		Name:    "output",
 		Aliases: []string{"o"},
 		Value:   "text",
-		Usage:   "Output format: text, json",
+		Usage:   "Output format: " + strings.Join(formats, ", "),
 	}
 }
 
@@ -61,3 +182,23 @@ func OutputFlag() *cli.StringFlag {
 func IsJSON(cmd *cli.Command) bool {
 	return cmd.String("output") == "json"
 }
+
+// IsStructured returns true if the output format is JSON or YAML: the
+// formats PrintStructured writes.
+func IsStructured(cmd *cli.Command) bool {
+	switch cmd.String("output") {
+	case "json", "yaml":
+		return true
+	}
+	return false
+}
+
+// PrintStructured writes v to stdout as JSON or YAML, per --output.
+func PrintStructured(cmd *cli.Command, v any) error {
+	return output.Print(os.Stdout, cmd.String("output"), v)
+}
+
+// IsJSONL returns true if the output format is JSON Lines (one object per line).
+func IsJSONL(cmd *cli.Command) bool {
+	return cmd.String("output") == "jsonl"
+}
@@ -0,0 +1,140 @@
+package cmdutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+// filterOp is one structured where flag: --eq, --in, --gt, and so on.
+type filterOp struct {
+	Flag  string
+	Usage string
+	// Op is the v2 comparison operator, for the simple field-op-value forms.
+	Op string
+	// Numeric leaves numeric values unquoted.
+	Numeric bool
+}
+
+// filterOps lists the structured where flags in the order their clauses are
+// ANDed.
+var filterOps = []filterOp{
+	{Flag: "eq", Op: "==", Usage: "Exact match as field:value, quoted and escaped for you; null matches unset (repeatable; ANDed with --where)"},
+	{Flag: "ne", Op: "!=", Usage: "Not equal, as field:value; null matches set fields (repeatable)"},
+	{Flag: "in", Usage: "One of several values, as field:a,b,c (repeatable)"},
+	{Flag: "not-in", Usage: "None of several values, as field:a,b,c (repeatable)"},
+	{Flag: "gt", Op: ">", Numeric: true, Usage: "Greater than, as field:value; numbers are compared as numbers (repeatable)"},
+	{Flag: "gte", Op: ">=", Numeric: true, Usage: "Greater than or equal, as field:value (repeatable)"},
+	{Flag: "lt", Op: "<", Numeric: true, Usage: "Less than, as field:value (repeatable)"},
+	{Flag: "lte", Op: "<=", Numeric: true, Usage: "Less than or equal, as field:value (repeatable)"},
+	{Flag: "between", Numeric: true, Usage: "Inclusive range, as field:low,high (repeatable)"},
+	{Flag: "contains", Usage: "Substring match, as field:text (repeatable)"},
+}
+
+// FilterFlags returns the structured where flags. Each takes field:value
+// (or field=value) and compiles to a properly quoted v2 clause; see
+// AppendFilterClauses.
+func FilterFlags() []cli.Flag {
+	flags := make([]cli.Flag, len(filterOps))
+	for i, op := range filterOps {
+		flags[i] = &RepeatableFlag{Name: op.Flag, Usage: op.Usage}
+	}
+	return flags
+}
+
+// AppendFilterClauses ANDs a clause for every structured where flag given on
+// cmd onto where.
+func AppendFilterClauses(cmd *cli.Command, where string) (string, error) {
+	var clauses []string
+	for _, op := range filterOps {
+		for _, spec := range cmd.StringSlice(op.Flag) {
+			clause, err := FilterClause(op.Flag, spec)
+			if err != nil {
+				return "", err
+			}
+			clauses = append(clauses, clause)
+		}
+	}
+	return AndWhere(where, strings.Join(clauses, " and ")), nil
+}
+
+// FilterClause compiles one structured where flag value into a v2 clause.
+// The value null is a bare null, id fields must be integers, and the
+// ordering flags (gt, gte, lt, lte, between) leave numbers unquoted; other
+// values are quoted strings.
+func FilterClause(flag, spec string) (string, error) {
+	var op filterOp
+	for _, o := range filterOps {
+		if o.Flag == flag {
+			op = o
+		}
+	}
+	if op.Flag == "" {
+		return "", fmt.Errorf("unknown filter flag --%s", flag)
+	}
+
+	field, value, err := splitFilterSpec(flag, spec)
+	if err != nil {
+		return "", err
+	}
+
+	switch flag {
+	case "in", "not-in":
+		list, err := literalList(flag, spec, field, value)
+		if err != nil {
+			return "", err
+		}
+		if flag == "not-in" {
+			return field + " not in " + list, nil
+		}
+		return field + " in " + list, nil
+	case "between":
+		low, high, ok := strings.Cut(value, ",")
+		if !ok || strings.Contains(high, ",") || strings.TrimSpace(low) == "" || strings.TrimSpace(high) == "" {
+			return "", fmt.Errorf("invalid --between %q: expected field:low,high", spec)
+		}
+		lo, err := literal(flag, spec, field, strings.TrimSpace(low), true)
+		if err != nil {
+			return "", err
+		}
+		hi, err := literal(flag, spec, field, strings.TrimSpace(high), true)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("(%s>=%s and %s<=%s)", field, lo, field, hi), nil
+	case "contains":
+		return field + ".contains(" + api.QuoteV2String(value) + ")", nil
+	}
+
+	lit, err := literal(flag, spec, field, value, op.Numeric)
+	if err != nil {
+		return "", err
+	}
+	if lit == "null" && op.Numeric {
+		return "", fmt.Errorf("invalid --%s %q: null cannot be ordered; use --eq or --ne", flag, spec)
+	}
+	return field + op.Op + lit, nil
+}
+
+// literalList renders a comma-separated value as a v2 list literal.
+func literalList(flag, spec, field, value string) (string, error) {
+	var items []string
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		lit, err := literal(flag, spec, field, v, false)
+		if err != nil {
+			return "", err
+		}
+		items = append(items, lit)
+	}
+	if len(items) == 0 {
+		return "", fmt.Errorf("invalid --%s %q: expected field:a,b,c", flag, spec)
+	}
+	return "[" + strings.Join(items, ",") + "]", nil
+}
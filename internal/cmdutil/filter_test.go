@@ -0,0 +1,68 @@
+package cmdutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+)
+
+func TestFilterClause(t *testing.T) {
+	tests := []struct {
+		flag    string
+		spec    string
+		want    string
+		wantErr bool
+	}{
+		{"eq", "assignedUser.id:null", "assignedUser.id==null", false},
+		{"eq", "name=Login page", `name=="Login page"`, false},
+		{"ne", "entityState.name:Done", `entityState.name!="Done"`, false},
+		{"in", "priority.name:High, Urgent", `priority.name in ["High","Urgent"]`, false},
+		{"in", "id:1,2,3", "id in [1,2,3]", false},
+		{"in", "id:1,x", "", true},
+		{"in", "name:", "", true},
+		{"not-in", "entityState.name:Done,Closed", `entityState.name not in ["Done","Closed"]`, false},
+		{"gt", "effort:5", "effort>5", false},
+		{"gte", "effort:2.5", "effort>=2.5", false},
+		{"lt", "createDate:2024-01-01", `createDate<"2024-01-01"`, false},
+		{"lte", "effort:null", "", true},
+		{"between", "effort:1,5", "(effort>=1 and effort<=5)", false},
+		{"between", "effort:1", "", true},
+		{"between", "effort:1,2,3", "", true},
+		{"contains", `name:"login"`, `name.contains("\"login\"")`, false},
+		{"contains", "noseparator", "", true},
+		{"like", "name:x", "", true},
+	}
+	for _, tt := range tests {
+		got, err := FilterClause(tt.flag, tt.spec)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("FilterClause(%q, %q) = %q, want error", tt.flag, tt.spec, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("FilterClause(%q, %q) = %q, %v; want %q", tt.flag, tt.spec, got, err, tt.want)
+		}
+	}
+}
+
+func TestAppendFilterClauses_KeepsCommas(t *testing.T) {
+	var got string
+	cmd := &cli.Command{
+		Flags: FilterFlags(),
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			var err error
+			got, err = AppendFilterClauses(cmd, "")
+			return err
+		},
+	}
+	args := []string{"tp", "--in", "priority.name:High,Urgent", "--between", "effort:1,5", "--eq", "name:a, b", "--eq", "id:7"}
+	if err := cmd.Run(context.Background(), args); err != nil {
+		t.Fatal(err)
+	}
+	want := `name=="a, b" and id==7 and priority.name in ["High","Urgent"] and (effort>=1 and effort<=5)`
+	if got != want {
+		t.Errorf("AppendFilterClauses() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,32 @@
+package cmdutil
+
+import (
+	"context"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/output"
+)
+
+// Progress returns a status line on stderr for long operations. It is silent
+// when stderr is not a terminal or warnings are suppressed with --quiet.
+func (f *Factory) Progress() *output.Progress {
+	return output.NewProgress(f.Warnings())
+}
+
+// FetchAll is client.QueryV2All with a running count of fetched items on the
+// progress line.
+func (f *Factory) FetchAll(ctx context.Context, client *api.Client, entityType string, params api.V2Params) ([]api.Entity, error) {
+	progress := f.Progress()
+	defer progress.Done()
+
+	var all []api.Entity
+	err := client.QueryV2Pages(ctx, entityType, params, func(items []api.Entity) error {
+		all = append(all, items...)
+		progress.Update("fetched %d items…", len(all))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
@@ -0,0 +1,30 @@
+package cmdutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// IsInteractive reports whether stdin is a terminal, i.e. a person can answer
+// a prompt. Piped or redirected input counts as scripted use, including
+// /dev/null, which is a character device but not a terminal.
+func IsInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// Confirm writes prompt to w and reads a yes/no answer from r. Only "y" or
+// "yes" (any case) confirm; anything else, including EOF, declines.
+func Confirm(r io.Reader, w io.Writer, prompt string) bool {
+	fmt.Fprintf(w, "%s [y/N]: ", prompt)
+	answer, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
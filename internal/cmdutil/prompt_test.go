@@ -0,0 +1,16 @@
+package cmdutil
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := map[string]bool{"y\n": true, "YES\n": true, "n\n": false, "\n": false, "": false, "yes": true}
+	for input, want := range tests {
+		if got := Confirm(strings.NewReader(input), io.Discard, "Proceed?"); got != want {
+			t.Errorf("Confirm(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
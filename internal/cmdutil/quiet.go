@@ -0,0 +1,39 @@
+package cmdutil
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// IsQuiet reports whether warnings and hints should be suppressed: --quiet
+// was given or the quiet config key (TP_QUIET) is set.
+func (f *Factory) IsQuiet() bool {
+	if f.Quiet {
+		return true
+	}
+	cfg, err := f.Config()
+	return err == nil && cfg.Quiet
+}
+
+// Warnings returns where advisory output (warnings, hints, progress notes)
+// goes: stderr, or io.Discard when quiet. Errors, prompts, and the diff a
+// prompt asks about are written to stderr directly.
+func (f *Factory) Warnings() io.Writer {
+	if f.IsQuiet() {
+		return io.Discard
+	}
+	return os.Stderr
+}
+
+// Hints returns where tips about the CLI itself go, such as the alias hint:
+// stderr when it is a terminal or with --verbose, otherwise io.Discard, so
+// scripts that treat any stderr output as failure are not tripped by them.
+// Quiet always wins.
+func (f *Factory) Hints() io.Writer {
+	if f.IsQuiet() || (!f.Verbose && !term.IsTerminal(int(os.Stderr.Fd()))) {
+		return io.Discard
+	}
+	return os.Stderr
+}
@@ -0,0 +1,43 @@
+package cmdutil
+
+import (
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// RepeatableFlag is a string flag that may be given several times. Unlike
+// cli.StringSliceFlag it never splits a value on commas, so values such as
+// --in 'field:a,b' and --custom 'Notes=x, y' arrive whole. Read it with
+// cmd.StringSlice.
+type RepeatableFlag = cli.FlagBase[[]string, cli.NoConfig, repeatableValue]
+
+// repeatableValue collects each Set call as one element.
+type repeatableValue struct {
+	values *[]string
+}
+
+func (repeatableValue) Create(val []string, p *[]string, _ cli.NoConfig) cli.Value {
+	*p = append([]string(nil), val...)
+	return &repeatableValue{values: p}
+}
+
+func (repeatableValue) ToString(val []string) string {
+	return strings.Join(val, ", ")
+}
+
+func (v *repeatableValue) Set(s string) error {
+	*v.values = append(*v.values, s)
+	return nil
+}
+
+func (v *repeatableValue) Get() any {
+	return *v.values
+}
+
+func (v *repeatableValue) String() string {
+	if v.values == nil {
+		return ""
+	}
+	return strings.Join(*v.values, ", ")
+}
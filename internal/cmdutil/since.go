@@ -0,0 +1,63 @@
+package cmdutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ApplySinceID narrows a v2 query to entities with an ID greater than sinceID
+// and orders the results by ID ascending, so repeated runs can pick up where
+// the previous one stopped. It also makes sure a non-empty select returns id.
+func ApplySinceID(sinceID int, where, selectExpr, orderBy string) (newWhere, newSelect, newOrderBy string, err error) {
+	if sinceID < 0 {
+		return "", "", "", fmt.Errorf("since-id must be non-negative, got %d", sinceID)
+	}
+	if orderBy != "" {
+		return "", "", "", errors.New("--since-id orders results by id and cannot be combined with an explicit order")
+	}
+
+	newWhere = AndWhere(where, fmt.Sprintf("id>%d", sinceID))
+
+	newSelect = selectExpr
+	if selectExpr != "" && !selectsID(selectExpr) {
+		newSelect = "id," + selectExpr
+	}
+
+	return newWhere, newSelect, "id asc", nil
+}
+
+// selectsID reports whether a select expression includes the plain id field.
+func selectsID(selectExpr string) bool {
+	for _, field := range strings.Split(selectExpr, ",") {
+		if strings.EqualFold(strings.TrimSpace(field), "id") {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxID returns the largest "id" (or "Id") value among items, or 0 if none.
+func MaxID(items []map[string]any) int {
+	maxID := 0
+	for _, item := range items {
+		v, ok := item["id"]
+		if !ok {
+			v = item["Id"]
+		}
+		if f, ok := v.(float64); ok && int(f) > maxID {
+			maxID = int(f)
+		}
+	}
+	return maxID
+}
+
+// SinceIDHint returns the stderr note telling the user which --since-id to
+// pass on the next incremental run.
+func SinceIDHint(sinceID int, items []map[string]any) string {
+	maxID := MaxID(items)
+	if maxID == 0 {
+		return fmt.Sprintf("No entities newer than id %d\n", sinceID)
+	}
+	return fmt.Sprintf("Max id seen: %d (next run: --since-id %d)\n", maxID, maxID)
+}
@@ -0,0 +1,35 @@
+package cmdutil
+
+import "testing"
+
+func TestApplySinceID(t *testing.T) {
+	where, sel, order, err := ApplySinceID(100, "entityState.isFinal!=true", "name", "")
+	if err != nil {
+		t.Fatalf("ApplySinceID() error = %v", err)
+	}
+	if where != "(entityState.isFinal!=true) and (id>100)" {
+		t.Errorf("where = %q", where)
+	}
+	if sel != "id,name" {
+		t.Errorf("select = %q", sel)
+	}
+	if order != "id asc" {
+		t.Errorf("orderBy = %q", order)
+	}
+
+	// An "or" filter must not escape the id bound.
+	if where, _, _, _ := ApplySinceID(7, "a==1 or b==2", "", ""); where != "(a==1 or b==2) and (id>7)" {
+		t.Errorf("where with or = %q", where)
+	}
+
+	if _, _, _, err := ApplySinceID(100, "", "", "createDate desc"); err == nil {
+		t.Error("expected error when combined with an explicit order")
+	}
+}
+
+func TestMaxID(t *testing.T) {
+	items := []map[string]any{{"id": float64(5)}, {"Id": float64(9)}, {"name": "x"}}
+	if got := MaxID(items); got != 9 {
+		t.Errorf("MaxID() = %d, want 9", got)
+	}
+}
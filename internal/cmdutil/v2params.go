@@ -0,0 +1,40 @@
+package cmdutil
+
+import (
+	"fmt"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+// maxTake is the largest page size the v2 API accepts.
+const maxTake = 1000
+
+// NewV2Params builds the v2 collection parameters shared by query and search,
+// validating take and skip so both commands reject the same inputs.
+func NewV2Params(where, selectExpr, orderBy string, take, skip int) (api.V2Params, error) {
+	if take < 0 || take > maxTake {
+		return api.V2Params{}, fmt.Errorf("take must be between 0 and %d, got %d", maxTake, take)
+	}
+	if skip < 0 {
+		return api.V2Params{}, fmt.Errorf("skip must be non-negative, got %d", skip)
+	}
+	return api.V2Params{
+		Where:   where,
+		Select:  selectExpr,
+		OrderBy: orderBy,
+		Take:    take,
+		Skip:    skip,
+	}, nil
+}
+
+// PageWindow converts a 1-based page number and page size into take and
+// skip, so page 3 of 25 is take 25, skip 50.
+func PageWindow(page, pageSize int) (take, skip int, err error) {
+	if page < 1 {
+		return 0, 0, fmt.Errorf("page must be at least 1, got %d", page)
+	}
+	if pageSize < 1 || pageSize > maxTake {
+		return 0, 0, fmt.Errorf("page size must be between 1 and %d, got %d", maxTake, pageSize)
+	}
+	return pageSize, (page - 1) * pageSize, nil
+}
@@ -0,0 +1,35 @@
+package cmdutil
+
+import "testing"
+
+func TestNewV2Params(t *testing.T) {
+	p, err := NewV2Params("id>1", "id,name", "id asc", 25, 50)
+	if err != nil {
+		t.Fatalf("NewV2Params() error = %v", err)
+	}
+	if p.Take != 25 || p.Skip != 50 || p.Where != "id>1" {
+		t.Errorf("unexpected params %+v", p)
+	}
+	if _, err := NewV2Params("", "", "", 1001, 0); err == nil {
+		t.Error("expected error for take over 1000")
+	}
+	if _, err := NewV2Params("", "", "", 25, -1); err == nil {
+		t.Error("expected error for negative skip")
+	}
+}
+
+func TestPageWindow(t *testing.T) {
+	take, skip, err := PageWindow(3, 25)
+	if err != nil || take != 25 || skip != 50 {
+		t.Errorf("PageWindow(3, 25) = %d, %d, %v; want 25, 50, nil", take, skip, err)
+	}
+	take, skip, err = PageWindow(1, 10)
+	if err != nil || take != 10 || skip != 0 {
+		t.Errorf("PageWindow(1, 10) = %d, %d, %v; want 10, 0, nil", take, skip, err)
+	}
+	for _, bad := range [][2]int{{0, 25}, {-1, 25}, {1, 0}, {1, 1001}} {
+		if _, _, err := PageWindow(bad[0], bad[1]); err == nil {
+			t.Errorf("PageWindow(%d, %d) should fail", bad[0], bad[1])
+		}
+	}
+}
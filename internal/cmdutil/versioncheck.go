@@ -0,0 +1,128 @@
+package cmdutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+// The CLI's v2 assumptions (singular entity names, select brace wrapping)
+// have been verified against Targetprocess product versions in this range,
+// compared on major.minor.
+const (
+	minTestedVersion = "3.10"
+	maxTestedVersion = "3.13"
+)
+
+// versionCheckTTL is how long a cached product version is trusted.
+const versionCheckTTL = 24 * time.Hour
+
+type versionCache struct {
+	Domain    string    `json:"domain"`
+	Version   string    `json:"version"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// CheckAPIVersion warns on w if the instance's product version is outside the
+// tested range. The version is cached in cachePath per domain for a day so
+// the endpoint isn't hit on every command. Lookup failures are silent: the
+// check is advisory and must never block a command.
+func CheckAPIVersion(ctx context.Context, client *api.Client, cachePath string, w io.Writer) {
+	version, ok := cachedVersion(cachePath, client.BaseURL)
+	if !ok {
+		v, err := client.ProductVersion(ctx)
+		if err != nil {
+			if client.Debug {
+				fmt.Fprintf(os.Stderr, "DEBUG: version check skipped: %v\n", err)
+			}
+			return
+		}
+		version = v
+		saveVersionCache(cachePath, versionCache{Domain: client.BaseURL, Version: version, CheckedAt: time.Now()})
+	}
+
+	if warn := VersionWarning(version); warn != "" {
+		fmt.Fprint(w, warn)
+	}
+}
+
+// VersionWarning returns a warning if version is outside the tested range,
+// or "" if it is inside it or cannot be parsed.
+func VersionWarning(version string) string {
+	v, ok := parseMajorMinor(version)
+	if !ok {
+		return ""
+	}
+	lo, _ := parseMajorMinor(minTestedVersion)
+	hi, _ := parseMajorMinor(maxTestedVersion)
+	if compareVersion(v, lo) >= 0 && compareVersion(v, hi) <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("Warning: Targetprocess version %s is outside the range this CLI was tested against (%s-%s); some queries may behave unexpectedly.\n",
+		version, minTestedVersion, maxTestedVersion)
+}
+
+func parseMajorMinor(version string) ([2]int, bool) {
+	parts := strings.SplitN(strings.TrimSpace(version), ".", 3)
+	if len(parts) < 2 {
+		return [2]int{}, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return [2]int{}, false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return [2]int{}, false
+	}
+	return [2]int{major, minor}, true
+}
+
+func compareVersion(a, b [2]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// cachedVersion returns the cached version for domain if it is still fresh.
+func cachedVersion(path, domain string) (string, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	var c versionCache
+	if err := json.Unmarshal(data, &c); err != nil {
+		return "", false
+	}
+	if c.Domain != domain || time.Since(c.CheckedAt) > versionCheckTTL {
+		return "", false
+	}
+	return c.Version, true
+}
+
+// saveVersionCache writes the cache best-effort; a failure only means the
+// next command checks again.
+func saveVersionCache(path string, c versionCache) {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
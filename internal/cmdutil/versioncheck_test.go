@@ -0,0 +1,56 @@
+package cmdutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+)
+
+func TestVersionWarning(t *testing.T) {
+	tests := []struct {
+		version string
+		warn    bool
+	}{
+		{"3.10.0", false},
+		{"3.13.7.12345", false},
+		{"3.9", true},
+		{"4.0.1", true},
+		{"", false},
+		{"unknown", false},
+	}
+	for _, tt := range tests {
+		if got := VersionWarning(tt.version) != ""; got != tt.warn {
+			t.Errorf("VersionWarning(%q) warned = %v, want %v", tt.version, got, tt.warn)
+		}
+	}
+}
+
+func TestCheckAPIVersion_Caches(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"Version":"4.1.0"}`)
+	}))
+	defer srv.Close()
+
+	client := api.NewClient(srv.URL, "tok", false)
+	cache := filepath.Join(t.TempDir(), "version-check.json")
+
+	for i := 0; i < 2; i++ {
+		var warn bytes.Buffer
+		CheckAPIVersion(context.Background(), client, cache, &warn)
+		if !strings.Contains(warn.String(), "version 4.1.0 is outside") {
+			t.Errorf("run %d: expected warning, got %q", i, warn.String())
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 request thanks to the cache, got %d", calls)
+	}
+}
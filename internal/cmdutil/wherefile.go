@@ -0,0 +1,55 @@
+package cmdutil
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// WhereFileFlag returns the --where-file flag read by WhereFlag.
+func WhereFileFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "where-file",
+		Usage: "Read the where expression from a file; lines are joined, blank and # lines skipped. ANDed with --where",
+	}
+}
+
+// ReadWhereFile reads a where expression kept in a file. Each line is
+// trimmed and the lines are joined with spaces, so a long filter can be
+// spread over several lines; blank lines and lines starting with # are
+// skipped.
+func ReadWhereFile(path string) (string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path comes from the user's own flag
+	if err != nil {
+		return "", fmt.Errorf("reading --where-file: %w", err)
+	}
+	var parts []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts = append(parts, line)
+	}
+	if len(parts) == 0 {
+		return "", fmt.Errorf("--where-file %s has no where expression", path)
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// whereWithFile combines --where-file and --where. Each side is
+// parenthesized when both are given, so an "or" in either keeps its meaning.
+func whereWithFile(cmd *cli.Command) (string, error) {
+	where := cmd.String("where")
+	path := cmd.String("where-file")
+	if path == "" {
+		return where, nil
+	}
+	fromFile, err := ReadWhereFile(path)
+	if err != nil {
+		return "", err
+	}
+	return AndWhere(fromFile, where), nil
+}
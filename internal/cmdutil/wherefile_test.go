@@ -0,0 +1,58 @@
+package cmdutil
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+)
+
+func TestWhereFlag_WhereFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "triage.v2")
+	content := "# open, urgent bugs\nentityState.isFinal!=true\n\n  and (priority.name==\"Urgent\"\n   or severity.name==\"Critical\")\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	empty := filepath.Join(dir, "empty.v2")
+	if err := os.WriteFile(empty, []byte("# nothing yet\n\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	fromFile := `entityState.isFinal!=true and (priority.name=="Urgent" or severity.name=="Critical")`
+
+	tests := []struct {
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{[]string{"--where-file", path}, fromFile, false},
+		{[]string{"--where-file", path, "--where", "effort>3"}, "(" + fromFile + ") and (effort>3)", false},
+		{[]string{"--where", "effort>3"}, "effort>3", false},
+		{[]string{"--where-file", empty}, "", true},
+		{[]string{"--where-file", filepath.Join(dir, "missing.v2")}, "", true},
+	}
+	for _, tt := range tests {
+		var got string
+		cmd := &cli.Command{
+			Flags: []cli.Flag{&cli.StringFlag{Name: "where"}, WhereFileFlag(), AutoQuoteFlag()},
+			Action: func(_ context.Context, cmd *cli.Command) error {
+				var err error
+				got, err = WhereFlag(cmd, io.Discard)
+				return err
+			},
+		}
+		err := cmd.Run(context.Background(), append([]string{"tp"}, tt.args...))
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("WhereFlag(%q) = %q, want error", tt.args, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("WhereFlag(%q) = %q, %v; want %q", tt.args, got, err, tt.want)
+		}
+	}
+}
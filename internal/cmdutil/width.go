@@ -0,0 +1,25 @@
+package cmdutil
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+// OutputWidth returns the column budget for width-dependent table output:
+// the --width flag, then the output_width config key, then the width of the
+// terminal on stdout. It returns 0 when none is known, e.g. when output is
+// piped and nothing is configured.
+func (f *Factory) OutputWidth() int {
+	if f.Width > 0 {
+		return f.Width
+	}
+	if cfg, err := f.Config(); err == nil && cfg.OutputWidth > 0 {
+		return cfg.OutputWidth
+	}
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 {
+		return 0
+	}
+	return width
+}
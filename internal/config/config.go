@@ -1,10 +1,13 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/env"
@@ -13,7 +16,8 @@ import (
 	goyaml "gopkg.in/yaml.v3"
 )
 
-// TokenSource describes where the token was resolved from.
+// TokenSource describes where a secret, the token or the basic-auth password,
+// was resolved from.
 type TokenSource string
 
 const (
@@ -24,16 +28,88 @@ const (
 )
 
 const (
-	keyDomain = "domain"
-	keyToken  = "token"
+	keyDomain             = "domain"
+	keyToken              = "token"
+	keyDefaultProjectID   = "default_project_id"
+	keyMaxRetryWait       = "max_retry_wait"
+	keyTimezone           = "timezone"
+	keyAuthMode           = "auth_mode"
+	keyUsername           = "username"
+	keyPassword           = "password"
+	keyOutputWidth        = "output_width"
+	keyConfirmDestructive = "confirm_destructive"
+	keyTimeout            = "timeout"
+	keyQuiet              = "quiet"
 )
 
+// Auth modes accepted for auth_mode. They match api.AuthMode values.
+const (
+	AuthModeTokenQuery = "token_query"
+	AuthModeBasic      = "basic"
+	AuthModeBearer     = "bearer"
+)
+
+// ValidKeys lists the config keys accepted by Get and Set, for error messages.
+const ValidKeys = "domain, token, default_project_id, max_retry_wait, timezone, auth_mode, username, password, output_width, confirm_destructive, timeout, quiet"
+
 type Config struct {
 	Domain string `koanf:"domain" yaml:"domain"`
 	Token  string `koanf:"token" yaml:"token"`
 
+	// DefaultProjectID is used by create when no project is given explicitly.
+	DefaultProjectID int `koanf:"default_project_id" yaml:"default_project_id,omitempty"`
+
+	// MaxRetryWait caps, in seconds, how long a rate-limit Retry-After may
+	// delay a request. Zero means the client default.
+	MaxRetryWait int `koanf:"max_retry_wait" yaml:"max_retry_wait,omitempty"`
+
+	// Timezone is the Targetprocess account's IANA timezone (e.g. "Europe/Berlin"),
+	// used to convert absolute timestamps into the account's local time.
+	// Empty means the machine's local timezone.
+	Timezone string `koanf:"timezone" yaml:"timezone,omitempty"`
+
+	// AuthMode is how requests authenticate: token_query (default, the token
+	// as an access_token query parameter), bearer (the token in an
+	// Authorization header), or basic (Username and Password).
+	AuthMode string `koanf:"auth_mode" yaml:"auth_mode,omitempty"`
+	Username string `koanf:"username" yaml:"username,omitempty"`
+	Password string `koanf:"password" yaml:"password,omitempty"`
+
+	// OutputWidth fixes the column budget for width-dependent table output
+	// instead of detecting the terminal. Zero means auto-detect.
+	OutputWidth int `koanf:"output_width" yaml:"output_width,omitempty"`
+
+	// ConfirmDestructive makes update and delete always ask before applying,
+	// as if --confirm were given.
+	ConfirmDestructive bool `koanf:"confirm_destructive" yaml:"confirm_destructive,omitempty"`
+
+	// Timeout bounds each HTTP request and the command as a whole, as a
+	// duration ("90s", "5m") or whole seconds. "0" means no timeout; empty
+	// means the client default. See RequestTimeout.
+	Timeout string `koanf:"timeout" yaml:"timeout,omitempty"`
+
+	// Quiet suppresses warnings and hints on stderr, as if --quiet were
+	// given. Errors still print.
+	Quiet bool `koanf:"quiet" yaml:"quiet,omitempty"`
+
+	// Presets are team-defined search presets, by name, used alongside the
+	// built-in ones. They are edited in the file, not with Set.
+	Presets map[string]Preset `koanf:"presets" yaml:"presets,omitempty"`
+
 	// TokenSource indicates where the token was loaded from (not persisted).
 	TokenSource TokenSource `koanf:"-" yaml:"-"`
+
+	// PasswordSource indicates where the password was loaded from (not persisted).
+	PasswordSource TokenSource `koanf:"-" yaml:"-"`
+}
+
+// Preset is a user-defined search preset from the presets section of the
+// config file. Where is required; Select and OrderBy are optional.
+type Preset struct {
+	Description string `koanf:"description" yaml:"description,omitempty"`
+	Where       string `koanf:"where" yaml:"where"`
+	Select      string `koanf:"select" yaml:"select,omitempty"`
+	OrderBy     string `koanf:"orderBy" yaml:"orderBy,omitempty"`
 }
 
 func DefaultPath() string {
@@ -51,10 +127,8 @@ func Load(path string) (*Config, error) {
 		path = DefaultPath()
 	}
 
-	if _, err := os.Stat(path); err == nil {
-		if err := k.Load(file.Provider(path), yaml.Parser()); err != nil {
-			return nil, fmt.Errorf("loading config file: %w", err)
-		}
+	if err := loadFileInto(k, path); err != nil {
+		return nil, err
 	}
 
 	// Environment variables override file config (TP_DOMAIN, TP_TOKEN).
@@ -73,34 +147,68 @@ func Load(path string) (*Config, error) {
 		return nil, fmt.Errorf("parsing config: %w", err)
 	}
 
-	// Determine token source with priority: env > keyring > file
+	// Determine secret sources with priority: env > keyring > file
 	cfg.TokenSource = resolveTokenSource(&cfg)
+	cfg.PasswordSource = resolvePasswordSource(&cfg)
 
 	return &cfg, nil
 }
 
+// loadFile reads the config file alone, without environment variables or
+// the keyring, so that writing it back persists only what was in the file.
+func loadFile(path string) (*Config, error) {
+	k := koanf.New(".")
+	if err := loadFileInto(k, path); err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := k.Unmarshal("", &cfg); err != nil {
+		return nil, fmt.Errorf("parsing config: %w", err)
+	}
+	return &cfg, nil
+}
+
+func loadFileInto(k *koanf.Koanf, path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return nil //nolint:nilerr // no file means defaults
+	}
+	if err := k.Load(file.Provider(path), yaml.Parser()); err != nil {
+		return fmt.Errorf("loading config file: %w", err)
+	}
+	return nil
+}
+
 // resolveTokenSource determines where the token came from and fills it from
 // the keyring if no higher-priority source provided one.
 func resolveTokenSource(cfg *Config) TokenSource {
-	// Check if TP_TOKEN env var is set (highest priority).
-	if os.Getenv("TP_TOKEN") != "" {
+	return resolveSecretSource(&cfg.Token, "TP_TOKEN", keyringTokenUser)
+}
+
+// resolvePasswordSource is resolveTokenSource for the basic-auth password.
+func resolvePasswordSource(cfg *Config) TokenSource {
+	return resolveSecretSource(&cfg.Password, "TP_PASSWORD", keyringPasswordUser)
+}
+
+func resolveSecretSource(value *string, envVar, account string) TokenSource {
+	// Check if the env var is set (highest priority).
+	if os.Getenv(envVar) != "" {
 		return TokenSourceEnv
 	}
 
 	// Try the OS keyring.
-	if token, err := keyringGet(); err == nil && token != "" {
-		if cfg.Token == "" {
-			cfg.Token = token
+	if secret, err := keyringGet(account); err == nil && secret != "" {
+		if *value == "" {
+			*value = secret
 		}
-		// If the file also had a token, keyring still wins (we already have it).
-		// But if user explicitly set TP_TOKEN env, that already returned above.
-		if cfg.Token == token {
+		// If the file also had the secret, keyring still wins (we already have it).
+		// But if the user explicitly set the env var, that already returned above.
+		if *value == secret {
 			return TokenSourceKeyring
 		}
 	}
 
-	// Token came from the config file.
-	if cfg.Token != "" {
+	// Secret came from the config file.
+	if *value != "" {
 		return TokenSourceFile
 	}
 
@@ -111,12 +219,67 @@ func (c *Config) Validate() error {
 	if c.Domain == "" {
 		return fmt.Errorf("domain is required (set TP_DOMAIN env var or domain in %s)", DefaultPath())
 	}
-	if c.Token == "" {
-		return fmt.Errorf("token is required (set TP_TOKEN env var or token in %s)", DefaultPath())
+	switch c.AuthMode {
+	case "", AuthModeTokenQuery, AuthModeBearer:
+		if c.Token == "" {
+			return fmt.Errorf("token is required (set TP_TOKEN env var or token in %s)", DefaultPath())
+		}
+	case AuthModeBasic:
+		if c.Username == "" || c.Password == "" {
+			return fmt.Errorf("auth_mode basic requires username and password (set TP_USERNAME/TP_PASSWORD env vars or username/password in %s)", DefaultPath())
+		}
+	default:
+		return fmt.Errorf("invalid auth_mode %q: must be %s, %s, or %s", c.AuthMode, AuthModeTokenQuery, AuthModeBasic, AuthModeBearer)
 	}
 	return nil
 }
 
+// Location returns the account timezone, falling back to the machine's local
+// timezone when none is configured.
+func (c *Config) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q in config: %w", c.Timezone, err)
+	}
+	return loc, nil
+}
+
+// RequestTimeout returns the configured timeout. ok is false when none is
+// set, in which case callers keep their default; a zero duration means no
+// timeout.
+func (c *Config) RequestTimeout() (d time.Duration, ok bool, err error) {
+	if c.Timeout == "" {
+		return 0, false, nil
+	}
+	d, err = ParseTimeout(c.Timeout)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid timeout %q in config: %w", c.Timeout, err)
+	}
+	return d, true, nil
+}
+
+// ParseTimeout parses a timeout given as a Go duration ("90s", "5m") or as
+// whole seconds ("90"). Zero is allowed and means no timeout.
+func ParseTimeout(value string) (time.Duration, error) {
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, errors.New("must not be negative")
+		}
+		return time.Duration(secs) * time.Second, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.New("must be a duration like 90s or 5m, or a number of seconds")
+	}
+	if d < 0 {
+		return 0, errors.New("must not be negative")
+	}
+	return d, nil
+}
+
 func Get(path, key string) (string, error) {
 	cfg, err := Load(path)
 	if err != nil {
@@ -127,8 +290,43 @@ func Get(path, key string) (string, error) {
 		return cfg.Domain, nil
 	case keyToken:
 		return cfg.Token, nil
+	case keyDefaultProjectID:
+		if cfg.DefaultProjectID == 0 {
+			return "", nil
+		}
+		return strconv.Itoa(cfg.DefaultProjectID), nil
+	case keyMaxRetryWait:
+		if cfg.MaxRetryWait == 0 {
+			return "", nil
+		}
+		return strconv.Itoa(cfg.MaxRetryWait), nil
+	case keyTimezone:
+		return cfg.Timezone, nil
+	case keyAuthMode:
+		return cfg.AuthMode, nil
+	case keyUsername:
+		return cfg.Username, nil
+	case keyPassword:
+		return cfg.Password, nil
+	case keyOutputWidth:
+		if cfg.OutputWidth == 0 {
+			return "", nil
+		}
+		return strconv.Itoa(cfg.OutputWidth), nil
+	case keyConfirmDestructive:
+		if !cfg.ConfirmDestructive {
+			return "", nil
+		}
+		return "true", nil
+	case keyTimeout:
+		return cfg.Timeout, nil
+	case keyQuiet:
+		if !cfg.Quiet {
+			return "", nil
+		}
+		return "true", nil
 	default:
-		return "", fmt.Errorf("unknown config key: %s (valid keys: domain, token)", key)
+		return "", fmt.Errorf("unknown config key: %s (valid keys: %s)", key, ValidKeys)
 	}
 }
 
@@ -136,22 +334,36 @@ func Get(path, key string) (string, error) {
 // It tries the OS keyring first; if unavailable, falls back to the config file.
 // Returns the storage location used and any error.
 func SetToken(path, token string) (TokenSource, error) {
-	if err := keyringSet(token); err == nil {
-		// Stored in keyring — remove token from the config file if present.
-		if err := clearFileToken(path); err != nil {
-			return TokenSourceKeyring, fmt.Errorf("stored in keyring but failed to clear file token: %w", err)
+	return setSecret(path, keyToken, keyringTokenUser, token)
+}
+
+// SetPassword stores the basic-auth password like SetToken: in the OS
+// keyring if available, otherwise in the config file.
+func SetPassword(path, password string) (TokenSource, error) {
+	return setSecret(path, keyPassword, keyringPasswordUser, password)
+}
+
+func setSecret(path, key, account, value string) (TokenSource, error) {
+	if err := keyringSet(account, value); err == nil {
+		// Stored in keyring — remove the secret from the config file if present.
+		if err := clearFileValue(path, key); err != nil {
+			return TokenSourceKeyring, fmt.Errorf("stored in keyring but failed to clear file %s: %w", key, err)
 		}
 		return TokenSourceKeyring, nil
 	}
 
 	// Keyring unavailable — fall back to config file.
-	return TokenSourceFile, setFileValue(path, keyToken, token)
+	return TokenSourceFile, setFileValue(path, key, value)
 }
 
 func Set(path, key, value string) error {
-	if key == keyToken {
+	switch key {
+	case keyToken:
 		_, err := SetToken(path, value)
 		return err
+	case keyPassword:
+		_, err := SetPassword(path, value)
+		return err
 	}
 	return setFileValue(path, key, value)
 }
@@ -160,7 +372,7 @@ func setFileValue(path, key, value string) error {
 	if path == "" {
 		path = DefaultPath()
 	}
-	cfg, err := Load(path)
+	cfg, err := loadFile(path)
 	if err != nil {
 		cfg = &Config{}
 	}
@@ -169,26 +381,84 @@ func setFileValue(path, key, value string) error {
 		cfg.Domain = value
 	case keyToken:
 		cfg.Token = value
+	case keyDefaultProjectID:
+		id, err := strconv.Atoi(value)
+		if err != nil || id < 0 {
+			return fmt.Errorf("invalid %s %q: must be a non-negative integer", key, value)
+		}
+		cfg.DefaultProjectID = id
+	case keyMaxRetryWait:
+		secs, err := strconv.Atoi(value)
+		if err != nil || secs < 0 {
+			return fmt.Errorf("invalid %s %q: must be a non-negative number of seconds", key, value)
+		}
+		cfg.MaxRetryWait = secs
+	case keyTimezone:
+		if _, err := time.LoadLocation(value); err != nil {
+			return fmt.Errorf("invalid %s %q: must be an IANA timezone name like Europe/Berlin", key, value)
+		}
+		cfg.Timezone = value
+	case keyAuthMode:
+		switch value {
+		case "", AuthModeTokenQuery, AuthModeBasic, AuthModeBearer:
+		default:
+			return fmt.Errorf("invalid %s %q: must be %s, %s, or %s", key, value, AuthModeTokenQuery, AuthModeBasic, AuthModeBearer)
+		}
+		cfg.AuthMode = value
+	case keyUsername:
+		cfg.Username = value
+	case keyPassword:
+		cfg.Password = value
+	case keyOutputWidth:
+		width, err := strconv.Atoi(value)
+		if err != nil || width < 0 {
+			return fmt.Errorf("invalid %s %q: must be a non-negative number of columns (0 to auto-detect)", key, value)
+		}
+		cfg.OutputWidth = width
+	case keyConfirmDestructive:
+		confirm, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: must be true or false", key, value)
+		}
+		cfg.ConfirmDestructive = confirm
+	case keyTimeout:
+		if value != "" {
+			if _, err := ParseTimeout(value); err != nil {
+				return fmt.Errorf("invalid %s %q: %w", key, value, err)
+			}
+		}
+		cfg.Timeout = value
+	case keyQuiet:
+		quiet, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: must be true or false", key, value)
+		}
+		cfg.Quiet = quiet
 	default:
-		return fmt.Errorf("unknown config key: %s (valid keys: domain, token)", key)
+		return fmt.Errorf("unknown config key: %s (valid keys: %s)", key, ValidKeys)
 	}
 	return Save(path, cfg)
 }
 
-// clearFileToken removes the token field from the config file,
-// keeping other settings (like domain) intact.
-func clearFileToken(path string) error {
+// clearFileValue removes a secret, the token or the password, from the
+// config file, keeping other settings (like domain) intact.
+func clearFileValue(path, key string) error {
 	if path == "" {
 		path = DefaultPath()
 	}
 	if _, err := os.Stat(path); err != nil {
 		return nil //nolint:nilerr // no file means nothing to clean
 	}
-	cfg, err := Load(path)
+	cfg, err := loadFile(path)
 	if err != nil {
 		return err
 	}
-	cfg.Token = ""
+	switch key {
+	case keyToken:
+		cfg.Token = ""
+	case keyPassword:
+		cfg.Password = ""
+	}
 	return Save(path, cfg)
 }
 
@@ -197,13 +467,35 @@ func Save(path string, cfg *Config) error {
 		path = DefaultPath()
 	}
 
-	// Only persist domain and token to file (strip transient fields).
+	// Only persist user-settable fields to file (strip transient fields).
 	fileCfg := struct {
-		Domain string `yaml:"domain"`
-		Token  string `yaml:"token,omitempty"`
+		Domain             string            `yaml:"domain"`
+		Token              string            `yaml:"token,omitempty"`
+		DefaultProjectID   int               `yaml:"default_project_id,omitempty"`
+		MaxRetryWait       int               `yaml:"max_retry_wait,omitempty"`
+		Timezone           string            `yaml:"timezone,omitempty"`
+		AuthMode           string            `yaml:"auth_mode,omitempty"`
+		Username           string            `yaml:"username,omitempty"`
+		Password           string            `yaml:"password,omitempty"`
+		OutputWidth        int               `yaml:"output_width,omitempty"`
+		ConfirmDestructive bool              `yaml:"confirm_destructive,omitempty"`
+		Timeout            string            `yaml:"timeout,omitempty"`
+		Quiet              bool              `yaml:"quiet,omitempty"`
+		Presets            map[string]Preset `yaml:"presets,omitempty"`
 	}{
-		Domain: cfg.Domain,
-		Token:  cfg.Token,
+		Domain:             cfg.Domain,
+		Token:              cfg.Token,
+		DefaultProjectID:   cfg.DefaultProjectID,
+		MaxRetryWait:       cfg.MaxRetryWait,
+		Timezone:           cfg.Timezone,
+		AuthMode:           cfg.AuthMode,
+		Username:           cfg.Username,
+		Password:           cfg.Password,
+		OutputWidth:        cfg.OutputWidth,
+		ConfirmDestructive: cfg.ConfirmDestructive,
+		Timeout:            cfg.Timeout,
+		Quiet:              cfg.Quiet,
+		Presets:            cfg.Presets,
 	}
 
 	dir := filepath.Dir(path)
@@ -5,12 +5,15 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func cleanKeyring(t *testing.T) {
 	t.Helper()
-	if err := keyringDelete(); err != nil {
-		t.Logf("keyring cleanup skipped: %v", err)
+	for _, account := range []string{keyringTokenUser, keyringPasswordUser} {
+		if err := keyringDelete(account); err != nil {
+			t.Logf("keyring cleanup skipped: %v", err)
+		}
 	}
 }
 
@@ -78,6 +81,101 @@ func TestSetToken_FallsBackToFile(t *testing.T) {
 	}
 }
 
+func TestResolvePasswordSource(t *testing.T) {
+	t.Setenv("TP_PASSWORD", "env-password")
+	if src := resolvePasswordSource(&Config{Password: "file-password"}); src != TokenSourceEnv {
+		t.Errorf("with TP_PASSWORD: expected TokenSourceEnv, got %s", src)
+	}
+
+	t.Setenv("TP_PASSWORD", "")
+	cleanKeyring(t)
+	if src := resolvePasswordSource(&Config{Password: "file-password"}); src != TokenSourceFile {
+		t.Errorf("from the file: expected TokenSourceFile, got %s", src)
+	}
+	if src := resolvePasswordSource(&Config{}); src != TokenSourceNone {
+		t.Errorf("unset: expected TokenSourceNone, got %s", src)
+	}
+}
+
+func TestSetPassword_KeepsPlainTextOnlyAsFallback(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	if err := os.WriteFile(path, []byte("domain: test.tpondemand.com\nauth_mode: basic\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("TP_PASSWORD", "")
+	source, err := SetPassword(path, "my-secret-password")
+	if err != nil {
+		t.Fatalf("SetPassword failed: %v", err)
+	}
+
+	t.Cleanup(func() { cleanKeyring(t) })
+
+	// Setting another key must not copy a keyring password into the file.
+	if err := Set(path, "username", "jdoe"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inFile := strings.Contains(string(data), "my-secret-password")
+	switch source {
+	case TokenSourceKeyring:
+		if inFile {
+			t.Errorf("password stored in the keyring was also written to the file:\n%s", data)
+		}
+	case TokenSourceFile:
+		if !inFile {
+			t.Errorf("keyring unavailable but the password is not in the file:\n%s", data)
+		}
+	default:
+		t.Fatalf("unexpected source: %s", source)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Password != "my-secret-password" || cfg.PasswordSource != source {
+		t.Errorf("password = %q (source %s), want it back from %s", cfg.Password, cfg.PasswordSource, source)
+	}
+}
+
+func TestSet_DoesNotPersistEnvValues(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("domain: old.tpondemand.com\nusername: jdoe\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("TP_PASSWORD", "env-password")
+	t.Setenv("TP_TOKEN", "env-token")
+	t.Setenv("TP_AUTH_MODE", "basic")
+	t.Setenv("TP_QUIET", "true")
+	t.Setenv("TP_TIMEOUT", "5m")
+
+	if err := Set(path, "domain", "new.tpondemand.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, leaked := range []string{"env-password", "env-token", "auth_mode", "quiet", "timeout"} {
+		if strings.Contains(string(data), leaked) {
+			t.Errorf("config file picked up %q from the environment:\n%s", leaked, data)
+		}
+	}
+	if !strings.Contains(string(data), "new.tpondemand.com") || !strings.Contains(string(data), "username: jdoe") {
+		t.Errorf("config file lost its own values:\n%s", data)
+	}
+}
+
 func TestSave_OmitsEmptyToken(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "config.yaml")
@@ -96,3 +194,235 @@ func TestSave_OmitsEmptyToken(t *testing.T) {
 		t.Errorf("expected no token field in config file, got:\n%s", data)
 	}
 }
+
+func TestSet_DefaultProjectID(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	t.Setenv("TP_DEFAULT_PROJECT_ID", "")
+
+	if err := Set(path, "default_project_id", "42"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.DefaultProjectID != 42 {
+		t.Errorf("expected DefaultProjectID 42, got %d", cfg.DefaultProjectID)
+	}
+
+	got, err := Get(path, "default_project_id")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got != "42" {
+		t.Errorf("expected Get to return %q, got %q", "42", got)
+	}
+
+	if err := Set(path, "default_project_id", "abc"); err == nil {
+		t.Error("expected error for non-integer project ID")
+	}
+}
+
+func TestSet_MaxRetryWait(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	t.Setenv("TP_MAX_RETRY_WAIT", "")
+
+	if err := Set(path, "max_retry_wait", "30"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.MaxRetryWait != 30 {
+		t.Errorf("expected MaxRetryWait 30, got %d", cfg.MaxRetryWait)
+	}
+
+	if err := Set(path, "max_retry_wait", "-1"); err == nil {
+		t.Error("expected error for negative wait")
+	}
+}
+
+func TestSet_Timezone(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	t.Setenv("TP_TIMEZONE", "")
+
+	if err := Set(path, "timezone", "Not/AZone"); err == nil {
+		t.Error("expected error for unknown timezone")
+	}
+	if err := Set(path, "timezone", "UTC"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	loc, err := cfg.Location()
+	if err != nil || loc.String() != "UTC" {
+		t.Errorf("Location() = %v, %v; want UTC", loc, err)
+	}
+}
+
+func TestSet_AuthMode(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	t.Setenv("TP_AUTH_MODE", "")
+
+	if err := Set(path, "auth_mode", "oauth"); err == nil {
+		t.Error("expected error for unknown auth mode")
+	}
+	if err := Set(path, "auth_mode", "basic"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if got, _ := Get(path, "auth_mode"); got != "basic" {
+		t.Errorf("auth_mode = %q, want basic", got)
+	}
+}
+
+func TestSet_OutputWidth(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	t.Setenv("TP_OUTPUT_WIDTH", "")
+
+	if err := Set(path, "output_width", "wide"); err == nil {
+		t.Error("expected error for non-numeric width")
+	}
+	if err := Set(path, "output_width", "120"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if got, _ := Get(path, "output_width"); got != "120" {
+		t.Errorf("output_width = %q, want 120", got)
+	}
+}
+
+func TestSet_Timeout(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	t.Setenv("TP_TIMEOUT", "")
+
+	for _, bad := range []string{"soon", "-5s", "-1"} {
+		if err := Set(path, "timeout", bad); err == nil {
+			t.Errorf("expected error for timeout %q", bad)
+		}
+	}
+	if err := Set(path, "timeout", "0"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if d, ok, err := cfg.RequestTimeout(); err != nil || !ok || d != 0 {
+		t.Errorf("RequestTimeout() = %v, %v, %v; want 0, true, nil", d, ok, err)
+	}
+}
+
+func TestQuiet_FromEnvAndSet(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	t.Setenv("TP_QUIET", "true")
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !cfg.Quiet {
+		t.Error("TP_QUIET=true should set Quiet")
+	}
+
+	t.Setenv("TP_QUIET", "")
+	if err := Set(path, "quiet", "maybe"); err == nil {
+		t.Error("expected error for non-boolean quiet")
+	}
+	if err := Set(path, "quiet", "true"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if got, _ := Get(path, "quiet"); got != "true" {
+		t.Errorf("quiet = %q, want true", got)
+	}
+}
+
+func TestParseTimeout(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"90", 90 * time.Second},
+		{"90s", 90 * time.Second},
+		{"5m", 5 * time.Minute},
+		{"0", 0},
+		{"0s", 0},
+	}
+	for _, tt := range tests {
+		got, err := ParseTimeout(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseTimeout(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestValidate_AuthModes(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"token default", Config{Domain: "d", Token: "t"}, false},
+		{"token missing", Config{Domain: "d"}, true},
+		{"bearer", Config{Domain: "d", Token: "t", AuthMode: "bearer"}, false},
+		{"basic", Config{Domain: "d", AuthMode: "basic", Username: "u", Password: "p"}, false},
+		{"basic missing password", Config{Domain: "d", AuthMode: "basic", Username: "u"}, true},
+		{"unknown mode", Config{Domain: "d", Token: "t", AuthMode: "oauth"}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestPresets_LoadAndSurviveSet(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := `domain: example.tpondemand.com
+presets:
+  myTriage:
+    description: Untriaged bugs for the core team
+    where: team.name=="Core" and severity==null
+    select: id,name,createDate
+    orderBy: createDate desc
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	want := Preset{
+		Description: "Untriaged bugs for the core team",
+		Where:       `team.name=="Core" and severity==null`,
+		Select:      "id,name,createDate",
+		OrderBy:     "createDate desc",
+	}
+	if got := cfg.Presets["myTriage"]; got != want {
+		t.Errorf("Presets[myTriage] = %+v, want %+v", got, want)
+	}
+
+	if err := Set(path, "timezone", "Europe/Berlin"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	cfg, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if got := cfg.Presets["myTriage"]; got != want {
+		t.Errorf("after Set, Presets[myTriage] = %+v, want %+v", got, want)
+	}
+}
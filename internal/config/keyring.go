@@ -6,42 +6,54 @@ import (
 	"github.com/zalando/go-keyring"
 )
 
+// keyringService is the keyring service name; each secret is stored under
+// its own account.
+const keyringService = "targetprocess-cli"
+
+// Keyring accounts for the secrets tp stores.
 const (
-	keyringService = "targetprocess-cli"
-	keyringUser    = "token"
+	keyringTokenUser    = "token"
+	keyringPasswordUser = "password"
 )
 
 // ErrKeyringUnavailable indicates the OS keyring is not accessible.
 var ErrKeyringUnavailable = errors.New("keyring unavailable")
 
-// keyringGet retrieves the token from the OS keyring.
+// keyringGet retrieves the secret stored under account from the OS keyring.
 // Returns ErrKeyringUnavailable if the keyring cannot be accessed.
-func keyringGet() (string, error) {
-	token, err := keyring.Get(keyringService, keyringUser)
+func keyringGet(account string) (string, error) {
+	secret, err := keyring.Get(keyringService, account)
 	if err != nil {
 		if errors.Is(err, keyring.ErrNotFound) {
 			return "", nil
 		}
 		return "", ErrKeyringUnavailable
 	}
-	return token, nil
+	return secret, nil
 }
 
-// keyringSet stores the token in the OS keyring.
+// keyringSet stores the secret under account in the OS keyring.
 // Returns ErrKeyringUnavailable if the keyring cannot be accessed.
-func keyringSet(token string) error {
-	err := keyring.Set(keyringService, keyringUser, token)
+func keyringSet(account, secret string) error {
+	err := keyring.Set(keyringService, account, secret)
 	if err != nil {
 		return ErrKeyringUnavailable
 	}
 	return nil
 }
 
-// keyringDelete removes the token from the OS keyring.
-func keyringDelete() error {
-	err := keyring.Delete(keyringService, keyringUser)
+// keyringDelete removes the secret stored under account from the OS keyring.
+func keyringDelete(account string) error {
+	err := keyring.Delete(keyringService, account)
 	if err != nil && !errors.Is(err, keyring.ErrNotFound) {
 		return ErrKeyringUnavailable
 	}
 	return nil
 }
+
+// KeyringAvailable reports whether the OS keyring can be accessed, so
+// diagnostics can tell a missing keyring from a missing token.
+func KeyringAvailable() bool {
+	_, err := keyringGet(keyringTokenUser)
+	return err == nil
+}
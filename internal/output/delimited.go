@@ -0,0 +1,38 @@
+package output
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// delimitedCleaner replaces characters that would break a delimited record:
+// field separators and line breaks become single spaces.
+var delimitedCleaner = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// WriteTSV writes a header row and one row per record, fields separated by
+// literal tabs with no padding, so the output splits cleanly with cut or
+// awk -F'\t'. Tabs and newlines inside values are replaced with spaces to
+// keep each record on one line.
+func WriteTSV(w io.Writer, header []string, rows [][]string) error {
+	return writeDelimited(w, "\t", header, rows)
+}
+
+// writeDelimited writes header and rows joined by sep, one record per line.
+func writeDelimited(w io.Writer, sep string, header []string, rows [][]string) error {
+	bw := bufio.NewWriter(w)
+	writeRecord := func(fields []string) {
+		for i, f := range fields {
+			if i > 0 {
+				bw.WriteString(sep)
+			}
+			bw.WriteString(delimitedCleaner.Replace(f))
+		}
+		bw.WriteString("\n")
+	}
+	writeRecord(header)
+	for _, row := range rows {
+		writeRecord(row)
+	}
+	return bw.Flush()
+}
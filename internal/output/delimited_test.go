@@ -0,0 +1,22 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteTSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteTSV(&buf, []string{"id", "name"}, [][]string{
+		{"1", "plain"},
+		{"2", "tab\there\nand newline"},
+		{"3", ""},
+	})
+	if err != nil {
+		t.Fatalf("WriteTSV() error = %v", err)
+	}
+	want := "id\tname\n1\tplain\n2\ttab here and newline\n3\t\n"
+	if buf.String() != want {
+		t.Errorf("WriteTSV() = %q, want %q", buf.String(), want)
+	}
+}
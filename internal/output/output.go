@@ -15,11 +15,28 @@ func PrintJSON(w io.Writer, v any) error {
 	return enc.Encode(v)
 }
 
-// PrintEntity prints a single entity as key-value pairs.
+// PrintJSONLines writes each item as compact JSON on its own line (JSON
+// Lines / ndjson), with no surrounding array.
+func PrintJSONLines(w io.Writer, items []map[string]any) error {
+	enc := json.NewEncoder(w)
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PrintEntity prints a single entity as key-value pairs. CustomFields is
+// listed as one indented "name: value" line per field.
 func PrintEntity(w io.Writer, entity map[string]any) {
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
 	for _, key := range sortedKeys(entity) {
 		val := entity[key]
+		if list, ok := val.([]any); ok && key == "CustomFields" {
+			printCustomFields(tw, list)
+			continue
+		}
 		switch v := val.(type) {
 		case map[string]any:
 			if name, ok := v["Name"]; ok {
@@ -36,6 +53,35 @@ func PrintEntity(w io.Writer, entity map[string]any) {
 	tw.Flush()
 }
 
+// printCustomFields writes the CustomFields array under a CustomFields:
+// heading. Reference values show their Name; unset values are blank.
+func printCustomFields(w io.Writer, list []any) {
+	if len(list) == 0 {
+		fmt.Fprintf(w, "CustomFields:\t(none)\n")
+		return
+	}
+	fmt.Fprintf(w, "CustomFields:\t\n")
+	for _, item := range list {
+		cf, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		var value any
+		switch v := cf["Value"].(type) {
+		case nil:
+			value = ""
+		case map[string]any:
+			value = v["Name"]
+			if value == nil {
+				value = v["Id"]
+			}
+		default:
+			value = v
+		}
+		fmt.Fprintf(w, "  %v:\t%v\n", cf["Name"], value)
+	}
+}
+
 // PrintEntityTable prints a list of entities as a table.
 func PrintEntityTable(w io.Writer, entities []map[string]any) {
 	if len(entities) == 0 {
@@ -0,0 +1,42 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrintJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	items := []map[string]any{{"id": 1, "name": "a"}, {"id": 2, "name": "b"}}
+	if err := PrintJSONLines(&buf, items); err != nil {
+		t.Fatalf("PrintJSONLines() error = %v", err)
+	}
+	want := "{\"id\":1,\"name\":\"a\"}\n{\"id\":2,\"name\":\"b\"}\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintEntity_CustomFields(t *testing.T) {
+	var buf bytes.Buffer
+	PrintEntity(&buf, map[string]any{
+		"Id": 7,
+		"CustomFields": []any{
+			map[string]any{"Name": "Risk", "Type": "DropDown", "Value": "High"},
+			map[string]any{"Name": "Story Points", "Type": "Number", "Value": float64(5)},
+			map[string]any{"Name": "Release", "Type": "Entity", "Value": map[string]any{"Id": float64(9), "Name": "R1"}},
+			map[string]any{"Name": "Notes", "Type": "Text", "Value": nil},
+		},
+		"Name": "Login",
+	})
+	want := "CustomFields:    \n" +
+		"  Risk:          High\n" +
+		"  Story Points:  5\n" +
+		"  Release:       R1\n" +
+		"  Notes:         \n" +
+		"Id:              7\n" +
+		"Name:            Login\n"
+	if buf.String() != want {
+		t.Errorf("PrintEntity() =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
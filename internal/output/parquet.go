@@ -0,0 +1,171 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// parquetKind is the column type inferred for Parquet output.
+type parquetKind int
+
+const (
+	kindUnknown parquetKind = iota // only nulls seen so far
+	kindBool
+	kindInt
+	kindFloat
+	kindString
+)
+
+// WriteParquet writes flat items as a Parquet file with one optional column
+// per key. Column types are inferred from the values: all-boolean columns
+// become BOOLEAN, integral numbers INT64, other numbers DOUBLE, and anything
+// else (including mixed columns) STRING. Nested objects or arrays are
+// rejected; flatten the items first.
+func WriteParquet(w io.Writer, items []map[string]any) error {
+	kinds := make(map[string]parquetKind)
+	for _, item := range items {
+		for key, v := range item {
+			k, err := inferKind(v)
+			if err != nil {
+				return fmt.Errorf("column %q: %w", key, err)
+			}
+			kinds[key] = mergeKinds(kinds[key], k)
+		}
+	}
+
+	cols := make([]string, 0, len(kinds))
+	group := parquet.Group{}
+	for key, k := range kinds {
+		cols = append(cols, key)
+		group[key] = parquet.Optional(parquetNode(k))
+	}
+	// parquet.Group orders its fields by name, so column indexes follow sorted keys.
+	sort.Strings(cols)
+
+	pw := parquet.NewWriter(w, parquet.NewSchema("tp", group))
+	rows := make([]parquet.Row, 0, len(items))
+	for _, item := range items {
+		row := make(parquet.Row, len(cols))
+		for i, col := range cols {
+			v, ok := item[col]
+			if !ok || v == nil {
+				row[i] = parquet.NullValue().Level(0, 0, i)
+				continue
+			}
+			row[i] = parquetValue(kinds[col], v).Level(0, 1, i)
+		}
+		rows = append(rows, row)
+	}
+	if _, err := pw.WriteRows(rows); err != nil {
+		return fmt.Errorf("writing parquet rows: %w", err)
+	}
+	if err := pw.Close(); err != nil {
+		return fmt.Errorf("finishing parquet file: %w", err)
+	}
+	return nil
+}
+
+func inferKind(v any) (parquetKind, error) {
+	switch val := v.(type) {
+	case nil:
+		return kindUnknown, nil
+	case bool:
+		return kindBool, nil
+	case float64:
+		if val == math.Trunc(val) && math.Abs(val) < 1<<53 {
+			return kindInt, nil
+		}
+		return kindFloat, nil
+	case string:
+		return kindString, nil
+	case map[string]any, []any:
+		return kindUnknown, fmt.Errorf("nested value not supported in parquet output (use --flatten)")
+	default:
+		return kindString, nil
+	}
+}
+
+// mergeKinds widens the column type to fit both kinds.
+func mergeKinds(a, b parquetKind) parquetKind {
+	switch {
+	case a == b || b == kindUnknown:
+		return a
+	case a == kindUnknown:
+		return b
+	case (a == kindInt && b == kindFloat) || (a == kindFloat && b == kindInt):
+		return kindFloat
+	default:
+		return kindString
+	}
+}
+
+func parquetNode(k parquetKind) parquet.Node {
+	switch k {
+	case kindBool:
+		return parquet.Leaf(parquet.BooleanType)
+	case kindInt:
+		return parquet.Int(64)
+	case kindFloat:
+		return parquet.Leaf(parquet.DoubleType)
+	default:
+		return parquet.String()
+	}
+}
+
+func parquetValue(k parquetKind, v any) parquet.Value {
+	switch k {
+	case kindBool:
+		b, _ := v.(bool)
+		return parquet.BooleanValue(b)
+	case kindInt:
+		f, _ := v.(float64)
+		return parquet.Int64Value(int64(f))
+	case kindFloat:
+		f, _ := v.(float64)
+		return parquet.DoubleValue(f)
+	default:
+		if s, ok := v.(string); ok {
+			return parquet.ByteArrayValue([]byte(s))
+		}
+		if f, ok := v.(float64); ok {
+			b, _ := json.Marshal(f)
+			return parquet.ByteArrayValue(b)
+		}
+		return parquet.ByteArrayValue([]byte(fmt.Sprintf("%v", v)))
+	}
+}
+
+// Flatten returns a copy of item with nested objects expanded into
+// dot-separated keys (e.g. {"project":{"name":"X"}} becomes "project.name").
+// Arrays are kept as their JSON encoding.
+func Flatten(item map[string]any) map[string]any {
+	flat := make(map[string]any, len(item))
+	flattenInto(flat, "", item)
+	return flat
+}
+
+func flattenInto(dst map[string]any, prefix string, m map[string]any) {
+	for key, v := range m {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch val := v.(type) {
+		case map[string]any:
+			flattenInto(dst, key, val)
+		case []any:
+			b, err := json.Marshal(val)
+			if err != nil {
+				dst[key] = fmt.Sprintf("%v", val)
+				continue
+			}
+			dst[key] = string(b)
+		default:
+			dst[key] = v
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package output
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestWriteParquet(t *testing.T) {
+	items := []map[string]any{
+		{"id": float64(1), "name": "Login", "effort": float64(2.5), "done": true},
+		{"id": float64(2), "name": "Logout", "effort": float64(3), "done": nil},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteParquet(&buf, items); err != nil {
+		t.Fatalf("WriteParquet() error = %v", err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("OpenFile() error = %v", err)
+	}
+	if f.NumRows() != 2 {
+		t.Fatalf("NumRows() = %d, want 2", f.NumRows())
+	}
+
+	wantTypes := map[string]string{"done": "BOOLEAN", "effort": "DOUBLE", "id": "INT64", "name": "BYTE_ARRAY"}
+	for _, field := range f.Schema().Fields() {
+		want, ok := wantTypes[field.Name()]
+		if !ok {
+			t.Errorf("unexpected column %q", field.Name())
+			continue
+		}
+		if got := field.Type().Kind().String(); got != want {
+			t.Errorf("column %q type = %s, want %s", field.Name(), got, want)
+		}
+	}
+
+	rows := make([]parquet.Row, 2)
+	r := f.RowGroups()[0].Rows()
+	defer r.Close()
+	n, err := r.ReadRows(rows)
+	if err != nil && err != io.EOF {
+		t.Fatalf("ReadRows() error = %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("read %d rows, want 2", n)
+	}
+	// Columns are ordered by name: done, effort, id, name.
+	if got := rows[0][2].Int64(); got != 1 {
+		t.Errorf("row 0 id = %d, want 1", got)
+	}
+	if got := string(rows[1][3].ByteArray()); got != "Logout" {
+		t.Errorf("row 1 name = %q, want Logout", got)
+	}
+	if !rows[1][0].IsNull() {
+		t.Errorf("row 1 done should be null, got %v", rows[1][0])
+	}
+}
+
+func TestWriteParquetRejectsNested(t *testing.T) {
+	items := []map[string]any{{"project": map[string]any{"name": "X"}}}
+	if err := WriteParquet(io.Discard, items); err == nil {
+		t.Error("expected error for nested value")
+	}
+	if err := WriteParquet(io.Discard, []map[string]any{Flatten(items[0])}); err != nil {
+		t.Errorf("flattened items should be accepted, got %v", err)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	got := Flatten(map[string]any{
+		"id":      float64(1),
+		"project": map[string]any{"name": "X", "owner": map[string]any{"id": float64(7)}},
+		"tags":    []any{"a", "b"},
+	})
+	want := map[string]any{
+		"id":               float64(1),
+		"project.name":     "X",
+		"project.owner.id": float64(7),
+		"tags":             `["a","b"]`,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Flatten() = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("Flatten()[%q] = %v, want %v", k, got[k], v)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// spinnerFrames cycle once per progress update.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Progress shows a one-line status such as "fetched 150 items…" that is
+// rewritten in place. It only draws when w is a terminal; piped, redirected,
+// or discarded output gets nothing, so scripts never see it. It is safe for
+// concurrent use.
+type Progress struct {
+	mu      sync.Mutex
+	w       io.Writer
+	enabled bool
+	frame   int
+	drawn   bool
+}
+
+// NewProgress returns a Progress drawing on w, or a silent one when w is not
+// a terminal.
+func NewProgress(w io.Writer) *Progress {
+	file, ok := w.(*os.File)
+	return &Progress{w: w, enabled: ok && term.IsTerminal(int(file.Fd()))}
+}
+
+// Update replaces the status line with the formatted message.
+func (p *Progress) Update(format string, args ...any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.enabled {
+		return
+	}
+	frame := spinnerFrames[p.frame%len(spinnerFrames)]
+	p.frame++
+	fmt.Fprintf(p.w, "\r\033[K%s %s", frame, fmt.Sprintf(format, args...))
+	p.drawn = true
+}
+
+// Done clears the status line so later output starts on a clean line.
+func (p *Progress) Done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.drawn {
+		fmt.Fprint(p.w, "\r\033[K")
+		p.drawn = false
+	}
+}
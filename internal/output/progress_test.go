@@ -0,0 +1,42 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProgressSilentWithoutTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewProgress(&buf)
+	p.Update("fetched %d items…", 150)
+	p.Done()
+	if buf.Len() != 0 {
+		t.Errorf("buffer got %q, want nothing", buf.String())
+	}
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "stderr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	p = NewProgress(file)
+	p.Update("fetched %d items…", 150)
+	p.Done()
+	if info, _ := file.Stat(); info.Size() != 0 {
+		t.Errorf("regular file got %d bytes, want nothing", info.Size())
+	}
+}
+
+func TestProgressRewritesLine(t *testing.T) {
+	var buf bytes.Buffer
+	p := &Progress{w: &buf, enabled: true}
+	p.Update("fetched %d items…", 100)
+	p.Update("fetched %d items…", 150)
+	p.Done()
+	want := "\r\033[K⠋ fetched 100 items…\r\033[K⠙ fetched 150 items…\r\033[K"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
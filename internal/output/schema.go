@@ -0,0 +1,94 @@
+package output
+
+import (
+	"math"
+	"regexp"
+	"time"
+)
+
+// Field types reported by InferSchema.
+const (
+	TypeNull     = "null" // only nulls seen
+	TypeBoolean  = "boolean"
+	TypeInteger  = "integer"
+	TypeNumber   = "number"
+	TypeString   = "string"
+	TypeDateTime = "datetime"
+	TypeObject   = "object"
+	TypeArray    = "array"
+	TypeMixed    = "mixed"
+)
+
+// SchemaSampleSize is how many items InferSchema looks at by default.
+const SchemaSampleSize = 100
+
+// msDateRe matches the Microsoft JSON date format the Targetprocess API uses,
+// e.g. "/Date(1700000000000+0100)/".
+var msDateRe = regexp.MustCompile(`^/Date\(-?\d+([+-]\d{4})?\)/$`)
+
+// InferSchema returns the type of each field across the first sample items
+// (all items if sample <= 0). Integers widen to number when mixed with
+// fractions, nulls never narrow a type, and any other conflict is "mixed".
+func InferSchema(items []map[string]any, sample int) map[string]string {
+	if sample > 0 && len(items) > sample {
+		items = items[:sample]
+	}
+	schema := make(map[string]string)
+	for _, item := range items {
+		for key, v := range item {
+			if key == "resourceType" {
+				continue
+			}
+			t := valueType(v)
+			prev, ok := schema[key]
+			if !ok {
+				schema[key] = t
+				continue
+			}
+			schema[key] = mergeTypes(prev, t)
+		}
+	}
+	return schema
+}
+
+func valueType(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return TypeNull
+	case bool:
+		return TypeBoolean
+	case float64:
+		if val == math.Trunc(val) && math.Abs(val) < 1<<53 {
+			return TypeInteger
+		}
+		return TypeNumber
+	case string:
+		if msDateRe.MatchString(val) {
+			return TypeDateTime
+		}
+		if _, err := time.Parse(time.RFC3339, val); err == nil {
+			return TypeDateTime
+		}
+		return TypeString
+	case map[string]any:
+		return TypeObject
+	case []any:
+		return TypeArray
+	default:
+		return TypeString
+	}
+}
+
+// mergeTypes widens a field type to fit both observations.
+func mergeTypes(a, b string) string {
+	switch {
+	case a == b || b == TypeNull:
+		return a
+	case a == TypeNull:
+		return b
+	case (a == TypeInteger && b == TypeNumber) || (a == TypeNumber && b == TypeInteger):
+		return TypeNumber
+	default:
+		return TypeMixed
+	}
+}
@@ -0,0 +1,34 @@
+package output
+
+import "testing"
+
+func TestInferSchema(t *testing.T) {
+	items := []map[string]any{
+		{"id": float64(1), "name": "a", "effort": float64(2), "isFinal": true, "owner": nil, "createDate": "/Date(1700000000000+0100)/", "resourceType": "Bug"},
+		{"id": float64(2), "name": "b", "effort": 1.5, "isFinal": false, "owner": map[string]any{"id": float64(3)}, "createDate": "2024-01-02T03:04:05Z", "tags": []any{"x"}},
+		{"id": "3", "name": nil},
+	}
+
+	got := InferSchema(items, 0)
+	want := map[string]string{
+		"id":         TypeMixed,
+		"name":       TypeString,
+		"effort":     TypeNumber,
+		"isFinal":    TypeBoolean,
+		"owner":      TypeObject,
+		"createDate": TypeDateTime,
+		"tags":       TypeArray,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("InferSchema() = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s: got %q, want %q", k, got[k], v)
+		}
+	}
+
+	if got := InferSchema(items, 1); got["effort"] != TypeInteger || got["id"] != TypeInteger {
+		t.Errorf("sampled schema should only see the first item, got %v", got)
+	}
+}
@@ -0,0 +1,50 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Print writes v to w in format: "yaml" for YAML, anything else as JSON.
+// Commands call it for every structured output format so a new format only
+// needs a case here.
+func Print(w io.Writer, format string, v any) error {
+	if format == "yaml" {
+		return PrintYAML(w, v)
+	}
+	return PrintJSON(w, v)
+}
+
+// PrintYAML writes v as YAML to w. v goes through JSON first, so keys,
+// their order, and omitted fields follow the same json tags as PrintJSON.
+func PrintYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	// JSON is valid YAML, so this keeps key order and number types.
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("encoding YAML: %w", err)
+	}
+	blockStyle(&doc)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("encoding YAML: %w", err)
+	}
+	return enc.Close()
+}
+
+// blockStyle clears the flow and quoting styles the JSON input gave n and
+// its children, so the encoder writes block YAML and quotes only where needed.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
@@ -0,0 +1,54 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrintYAML(t *testing.T) {
+	var buf bytes.Buffer
+	entity := map[string]any{
+		"Id":      float64(123),
+		"Name":    "Login: fix it",
+		"Effort":  2.5,
+		"Project": map[string]any{"Id": float64(42), "Name": "Mobile"},
+		"Tags":    []any{"a", "b"},
+		"Owner":   nil,
+		"Code":    "007",
+		"Flag":    "true",
+	}
+	if err := PrintYAML(&buf, entity); err != nil {
+		t.Fatalf("PrintYAML() error = %v", err)
+	}
+	want := `Code: "007"
+Effort: 2.5
+Flag: "true"
+Id: 123
+Name: 'Login: fix it'
+Owner: null
+Project:
+  Id: 42
+  Name: Mobile
+Tags:
+  - a
+  - b
+`
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintUsesJSONTags(t *testing.T) {
+	type row struct {
+		Value string `json:"value"`
+		Count int    `json:"count"`
+		Note  string `json:"note,omitempty"`
+	}
+	var buf bytes.Buffer
+	if err := Print(&buf, "yaml", []row{{Value: "Open", Count: 2}}); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+	if want := "- value: Open\n  count: 2\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
@@ -10,6 +10,7 @@ var knownTypes = map[string]string{
 	"feature":        "Feature",
 	"epic":           "Epic",
 	"request":        "Request",
+	"requester":      "Requester",
 	"assignable":     "Assignable",
 	"project":        "Project",
 	"team":           "Team",
@@ -45,6 +46,7 @@ var plurals = map[string]string{
 	"features":        "feature",
 	"epics":           "epic",
 	"requests":        "request",
+	"requesters":      "requester",
 	"projects":        "project",
 	"teams":           "team",
 	"assignments":     "assignment",
@@ -0,0 +1,74 @@
+package resolve
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SuggestEntityType returns the known entity type closest to input when
+// input is not one itself but is within a typo or two of one, or "". The
+// allowed distance grows with the input, one edit per four characters, so
+// short real types such as Tag are never taken for Task.
+func SuggestEntityType(input string) string {
+	lower := strings.ToLower(input)
+	if lower == "" || EntityType(input) != input {
+		return ""
+	}
+	if _, ok := knownTypes[lower]; ok {
+		return ""
+	}
+
+	limit := len(lower) / 4
+	best, bestDist := "", limit+1
+	names := make([]string, 0, len(knownTypes)+len(plurals))
+	for name := range knownTypes {
+		names = append(names, name)
+	}
+	for name := range plurals {
+		names = append(names, name)
+	}
+	sort.Strings(names) // ties go to the same name every run
+	for _, name := range names {
+		if d := EditDistance(lower, name); d < bestDist {
+			best, bestDist = name, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return EntityType(best)
+}
+
+// CheckEntityType returns an error with a suggestion when input looks like a
+// misspelled entity type, so the mistake is caught before the API answers
+// with a bare 404. Unknown types that are not close to a known one pass, as
+// the API may still know them.
+func CheckEntityType(input string) error {
+	if s := SuggestEntityType(input); s != "" {
+		return fmt.Errorf("unknown type %q; did you mean %q?", input, s)
+	}
+	return nil
+}
+
+// EditDistance returns the Levenshtein distance between a and b, counting
+// bytes.
+func EditDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,61 @@
+package resolve
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSuggestEntityType(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"UserStorie", "UserStory"},
+		{"userstoris", "UserStory"},
+		{"Featur", "Feature"},
+		{"Bugg", "Bug"},
+		{"Iteraton", "Iteration"},
+		// Known types, plurals, and aliases resolve, so nothing to suggest.
+		{"UserStory", ""},
+		{"bugs", ""},
+		{"story", ""},
+		{"Requester", ""},
+		// Too far from anything known, or too short to guess: the API decides.
+		{"Tag", ""},
+		{"Process", ""},
+		{"CustomRule", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := SuggestEntityType(tt.input); got != tt.want {
+			t.Errorf("SuggestEntityType(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestCheckEntityType(t *testing.T) {
+	err := CheckEntityType("UserStorie")
+	if err == nil || !strings.Contains(err.Error(), `unknown type "UserStorie"; did you mean "UserStory"?`) {
+		t.Errorf("CheckEntityType(UserStorie) = %v, want a did-you-mean error", err)
+	}
+	if err := CheckEntityType("Bug"); err != nil {
+		t.Errorf("CheckEntityType(Bug) = %v, want nil", err)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"owner", "owner", 0},
+		{"projetc", "project", 2},
+	}
+	for _, tt := range tests {
+		if got := EditDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("EditDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// PagedSimulation serves items from GET path as v2 pages of pageSize, linked
+// the way Targetprocess links them: every page but the last has an absolute
+// "next" URL on the simulation server with take and skip set. The first
+// page answers requests without skip; later pages match on their skip.
+func PagedSimulation(path string, pageSize int, items []map[string]any) (*Simulation, error) {
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("page size must be positive, got %d", pageSize)
+	}
+	sim := &Simulation{}
+	for skip := 0; skip == 0 || skip < len(items); skip += pageSize {
+		end := min(skip+pageSize, len(items))
+		page := map[string]any{"items": items[skip:end]}
+		if end < len(items) {
+			page["next"] = fmt.Sprintf("%s%s?take=%d&skip=%d", BaseURLPlaceholder, path, pageSize, end)
+		}
+		body, err := json.Marshal(page)
+		if err != nil {
+			return nil, fmt.Errorf("encoding page at skip %d: %w", skip, err)
+		}
+
+		req := Request{Method: "GET", Path: path}
+		if skip == 0 {
+			req.AbsentQuery = []string{"skip"}
+		} else {
+			req.Query = map[string]string{"skip": strconv.Itoa(skip)}
+		}
+		sim.Pairs = append(sim.Pairs, Pair{
+			Description: fmt.Sprintf("page %d", skip/pageSize+1),
+			Request:     req,
+			Response:    Response{Status: 200, Body: body},
+		})
+	}
+	return sim, nil
+}
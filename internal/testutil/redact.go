@@ -95,6 +95,7 @@ var sensitiveFieldPatterns = map[string]string{
 	"Icon":         "url",
 	"AvatarUri":    "url",
 	"Company":      "text",
+	"Abbreviation": "text",
 	"Phone":        "text",
 	"Tags":         "text",
 	"CustomField1": "text",
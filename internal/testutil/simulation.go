@@ -1,6 +1,7 @@
 package testutil
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -22,6 +23,24 @@ type Pair struct {
 	Description string   `json:"description,omitempty"`
 	Request     Request  `json:"request"`
 	Response    Response `json:"response"`
+
+	// Times limits how many requests this pair answers; after that, matching
+	// falls through to later pairs. Zero means unlimited. Use it to script
+	// sequences such as a 429 followed by a 200.
+	Times int `json:"times,omitempty"`
+
+	// Sequence, if set, replaces Response with responses returned in order
+	// on successive matches; once exhausted, the last one repeats. Use it
+	// when identical requests must see evolving data, as when polling.
+	Sequence []Response `json:"sequence,omitempty"`
+}
+
+// responseFor returns the response for the pair's hit-th match (from 0).
+func (p Pair) responseFor(hit int) Response {
+	if len(p.Sequence) == 0 {
+		return p.Response
+	}
+	return p.Sequence[min(hit, len(p.Sequence)-1)]
 }
 
 // Request describes the expected HTTP request to match.
@@ -29,8 +48,24 @@ type Request struct {
 	Method string            `json:"method"`
 	Path   string            `json:"path"`
 	Query  map[string]string `json:"query,omitempty"`
+
+	// StrictQuery requires the request's query to be exactly Query, apart
+	// from access_token and format; by default extra params are ignored.
+	StrictQuery bool `json:"strictQuery,omitempty"`
+
+	// AbsentQuery lists params the request must not carry, so a first page
+	// can be told apart from later pages that add a cursor such as skip.
+	AbsentQuery []string `json:"absentQuery,omitempty"`
 }
 
+// BaseURLPlaceholder in a response body is replaced with the simulation
+// server's URL, so links such as a v2 "next" point back at the server.
+const BaseURLPlaceholder = "{{baseURL}}"
+
+// ignoredStrictParams are query params StrictQuery does not compare: the
+// credential and the response format the client adds to every request.
+var ignoredStrictParams = map[string]bool{"access_token": true, "format": true}
+
 // Response describes the canned HTTP response to return.
 type Response struct {
 	Status  int               `json:"status"`
@@ -90,11 +125,24 @@ func matches(r *http.Request, sim Request) bool {
 	if r.URL.Path != sim.Path {
 		return false
 	}
+	actual := r.URL.Query()
 	// If the simulation specifies query params, they must all be present.
-	if len(sim.Query) > 0 {
-		actual := r.URL.Query()
-		for key, expected := range sim.Query {
-			if got := actual.Get(key); got != expected {
+	for key, expected := range sim.Query {
+		if got := actual.Get(key); got != expected {
+			return false
+		}
+	}
+	for _, key := range sim.AbsentQuery {
+		if actual.Has(key) {
+			return false
+		}
+	}
+	if sim.StrictQuery {
+		for key, vals := range actual {
+			if ignoredStrictParams[key] {
+				continue
+			}
+			if _, ok := sim.Query[key]; !ok || len(vals) != 1 {
 				return false
 			}
 		}
@@ -109,6 +157,7 @@ type SimulationServer struct {
 	mu       sync.Mutex
 	sim      *Simulation
 	requests []recordedRequest
+	served   map[int]int // pair index -> responses served
 }
 
 type recordedRequest struct {
@@ -133,22 +182,28 @@ func (ss *SimulationServer) handler(w http.ResponseWriter, r *http.Request) {
 	})
 	ss.mu.Unlock()
 
-	for _, pair := range ss.sim.Pairs {
+	for i, pair := range ss.sim.Pairs {
 		if !matches(r, pair.Request) {
 			continue
 		}
-		for k, v := range pair.Response.Headers {
+		hit, ok := ss.take(i, pair.Times)
+		if !ok {
+			continue
+		}
+		resp := pair.responseFor(hit)
+		for k, v := range resp.Headers {
 			w.Header().Set(k, v)
 		}
 		if w.Header().Get("Content-Type") == "" {
 			w.Header().Set("Content-Type", "application/json")
 		}
-		status := pair.Response.Status
+		status := resp.Status
 		if status == 0 {
 			status = http.StatusOK
 		}
 		w.WriteHeader(status)
-		if _, wErr := w.Write(pair.Response.BodyBytes()); wErr != nil {
+		body := bytes.ReplaceAll(resp.BodyBytes(), []byte(BaseURLPlaceholder), []byte(ss.URL()))
+		if _, wErr := w.Write(body); wErr != nil {
 			return // client disconnected
 		}
 		return
@@ -160,6 +215,22 @@ func (ss *SimulationServer) handler(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, "no matching simulation for %s %s", r.Method, r.URL.String()) //nolint:gosec // test-only simulation server
 }
 
+// take reports whether pair i may answer another request and, if so, counts
+// it and returns how many requests the pair had already answered.
+func (ss *SimulationServer) take(i, times int) (int, bool) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.served == nil {
+		ss.served = make(map[int]int)
+	}
+	hit := ss.served[i]
+	if times > 0 && hit >= times {
+		return 0, false
+	}
+	ss.served[i]++
+	return hit, true
+}
+
 // URL returns the test server's URL.
 func (ss *SimulationServer) URL() string {
 	return ss.Server.URL
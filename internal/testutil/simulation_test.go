@@ -0,0 +1,60 @@
+package testutil
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMatches_StrictQuery(t *testing.T) {
+	lenient := Request{Method: "GET", Path: "/api/v2/Bug", Query: map[string]string{"take": "1"}}
+	strict := lenient
+	strict.StrictQuery = true
+
+	tests := []struct {
+		url         string
+		wantLenient bool
+		wantStrict  bool
+	}{
+		{"/api/v2/Bug?take=1", true, true},
+		{"/api/v2/Bug?take=1&access_token=tok&format=json", true, true},
+		{"/api/v2/Bug?take=1&skip=5", true, false},
+		{"/api/v2/Bug?take=1&take=2", true, false},
+		{"/api/v2/Bug?skip=5", false, false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", tt.url, nil)
+		if got := matches(r, lenient); got != tt.wantLenient {
+			t.Errorf("lenient matches(%s) = %v, want %v", tt.url, got, tt.wantLenient)
+		}
+		if got := matches(r, strict); got != tt.wantStrict {
+			t.Errorf("strict matches(%s) = %v, want %v", tt.url, got, tt.wantStrict)
+		}
+	}
+}
+
+func TestSimulationServer_Sequence(t *testing.T) {
+	ss := NewSimulationServer(&Simulation{Pairs: []Pair{{
+		Request: Request{Method: "GET", Path: "/api/v2/Bug"},
+		Sequence: []Response{
+			{Status: 200, Body: json.RawMessage(`{"items":[]}`)},
+			{Status: 200, Body: json.RawMessage(`{"items":[{"id":1}]}`)},
+		},
+	}}})
+	defer ss.Close()
+
+	want := []string{`{"items":[]}`, `{"items":[{"id":1}]}`, `{"items":[{"id":1}]}`}
+	for i, w := range want {
+		resp, err := http.Get(ss.URL() + "/api/v2/Bug")
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != w {
+			t.Errorf("call %d body = %s, want %s", i+1, body, w)
+		}
+	}
+}
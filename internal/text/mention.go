@@ -3,8 +3,12 @@ package text
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/lifedraft/targetprocess-cli/internal/api"
@@ -14,23 +18,62 @@ import (
 // It matches @timo, @timo.litzius, (@name), but not user@email.com or @user:login[Name].
 var mentionRe = regexp.MustCompile(`(?:^|[\s(])@([a-zA-Z][a-zA-Z0-9]*(?:\.[a-zA-Z][a-zA-Z0-9]*)*)`)
 
+// resolvedMentionRe matches the stored @user:login[Full Name] mention syntax.
+var resolvedMentionRe = regexp.MustCompile(`@user:[^\s\[\]]+\[([^\]]+)\]`)
+
+// maxMentionCandidates is how many users a lookup fetches, enough to notice
+// that a mention is ambiguous and list the candidates.
+const maxMentionCandidates = 10
+
 // UserResolver resolves @mentions in text to TargetProcess user references.
 type UserResolver struct {
 	Client *api.Client
+
+	// Warn receives ambiguity warnings from ResolveMentions. Defaults to os.Stderr.
+	Warn io.Writer
+}
+
+type mentionUser struct {
+	ID        int    `json:"id"`
+	Login     string `json:"login"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+func (u mentionUser) fullName() string {
+	return strings.TrimSpace(u.FirstName + " " + u.LastName)
 }
 
 type v2Response struct {
-	Items []struct {
-		ID        int    `json:"id"`
-		Login     string `json:"login"`
-		FirstName string `json:"firstName"`
-		LastName  string `json:"lastName"`
-	} `json:"items"`
+	Items []mentionUser `json:"items"`
+}
+
+// AmbiguousMentionError reports a mention that matched several users with no
+// clear winner.
+type AmbiguousMentionError struct {
+	Name       string
+	Candidates []string
+}
+
+func (e *AmbiguousMentionError) Error() string {
+	return fmt.Sprintf("@%s is ambiguous, matches: %s", e.Name, strings.Join(e.Candidates, ", "))
 }
 
 // ResolveMentions replaces @mentions in text with @user:login[Full Name] format.
-// Unresolvable mentions are left unchanged.
+// Unresolvable mentions are left unchanged. Ambiguous mentions are also left
+// unchanged, with a warning listing the candidates.
 func (r *UserResolver) ResolveMentions(ctx context.Context, text string) (string, error) {
+	return r.resolveMentions(ctx, text, false)
+}
+
+// ResolveMentionsStrict is like ResolveMentions but returns an
+// *AmbiguousMentionError instead of guessing when a mention matches several
+// users. Intended for scripted use where a wrong mention is worse than none.
+func (r *UserResolver) ResolveMentionsStrict(ctx context.Context, text string) (string, error) {
+	return r.resolveMentions(ctx, text, true)
+}
+
+func (r *UserResolver) resolveMentions(ctx context.Context, text string, strict bool) (string, error) {
 	matches := mentionRe.FindAllStringSubmatchIndex(text, -1)
 	if len(matches) == 0 {
 		return text, nil
@@ -56,6 +99,11 @@ func (r *UserResolver) ResolveMentions(ctx context.Context, text string) (string
 	// Resolve each unique mention.
 	for _, mi := range unique {
 		resolved, err := r.lookupUser(ctx, mi.name)
+		var ambErr *AmbiguousMentionError
+		if !strict && errors.As(err, &ambErr) {
+			fmt.Fprintf(r.warnWriter(), "Warning: %v; left unresolved\n", ambErr)
+			continue
+		}
 		if err != nil {
 			return "", err
 		}
@@ -80,36 +128,134 @@ func (r *UserResolver) ResolveMentions(ctx context.Context, text string) (string
 	return result, nil
 }
 
-// lookupUser tries to find a TP user matching the given mention name.
-// Strategy: exact login, then login contains, then first name match.
+// DisplayMentions converts stored @user:login[Full Name] mentions into a
+// readable @Full Name for terminal display. It is the inverse of
+// ResolveMentions; plain @name mentions are left unchanged.
+func DisplayMentions(s string) string {
+	return resolvedMentionRe.ReplaceAllString(s, "@$1")
+}
+
+func (r *UserResolver) warnWriter() io.Writer {
+	if r.Warn != nil {
+		return r.Warn
+	}
+	return os.Stderr
+}
+
+// LookupUserID resolves a user to an ID using the same strategies and
+// disambiguation as mention resolution. Accepted forms are a numeric ID, a
+// login (with or without a leading @, exact or a unique part of it), a first
+// name, or a full name such as "Anna Schmidt".
+func (r *UserResolver) LookupUserID(ctx context.Context, name string) (int, error) {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "@")
+	if id, err := strconv.Atoi(name); err == nil && id > 0 {
+		return id, nil
+	}
+
+	u, ok, err := r.findUser(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("no user matches %q", name)
+	}
+	return u.ID, nil
+}
+
+// lookupUser returns the @user:login[Full Name] reference for a mention
+// name, or "" if no user matches.
 func (r *UserResolver) lookupUser(ctx context.Context, name string) (string, error) {
+	u, ok, err := r.findUser(ctx, name)
+	if err != nil || !ok {
+		return "", err
+	}
+	return fmt.Sprintf("@user:%s[%s]", u.Login, u.fullName()), nil
+}
+
+// findUser tries to find a TP user matching the given name.
+// Strategy: exact login, then login contains, then first name match, or
+// first and last name for a name with a space. The first strategy with
+// results decides; see pickUser for how several candidates are narrowed
+// down. The name is quoted, so it may contain any characters.
+func (r *UserResolver) findUser(ctx context.Context, name string) (mentionUser, bool, error) {
 	strategies := []string{
-		fmt.Sprintf("login=='%s'", name),
-		fmt.Sprintf("login.contains('%s')", name),
-		fmt.Sprintf("firstName.toLower()=='%s'", strings.ToLower(name)),
+		"login==" + api.QuoteV2String(name),
+		"login.contains(" + api.QuoteV2String(name) + ")",
+	}
+	if first, last, ok := strings.Cut(strings.ToLower(name), " "); ok {
+		strategies = append(strategies, fmt.Sprintf("firstName.toLower()==%s and lastName.toLower()==%s",
+			api.QuoteV2String(first), api.QuoteV2String(strings.TrimSpace(last))))
+	} else {
+		strategies = append(strategies, "firstName.toLower()=="+api.QuoteV2String(strings.ToLower(name)))
 	}
 
 	for _, where := range strategies {
 		data, err := r.Client.QueryV2(ctx, "GeneralUser", api.V2Params{
 			Where:  where,
 			Select: "id,login,firstName,lastName",
-			Take:   1,
+			Take:   maxMentionCandidates,
 		})
 		if err != nil {
-			return "", fmt.Errorf("looking up user %q: %w", name, err)
+			return mentionUser{}, false, fmt.Errorf("looking up user %q: %w", name, err)
 		}
 
 		var resp v2Response
 		if err := json.Unmarshal(data, &resp); err != nil {
-			return "", fmt.Errorf("parsing user response for %q: %w", name, err)
+			return mentionUser{}, false, fmt.Errorf("parsing user response for %q: %w", name, err)
 		}
 
 		if len(resp.Items) > 0 {
-			u := resp.Items[0]
-			fullName := strings.TrimSpace(u.FirstName + " " + u.LastName)
-			return fmt.Sprintf("@user:%s[%s]", u.Login, fullName), nil
+			u, err := pickUser(name, resp.Items)
+			if err != nil {
+				return mentionUser{}, false, err
+			}
+			return u, true, nil
 		}
 	}
 
-	return "", nil
+	return mentionUser{}, false, nil
+}
+
+// pickUser chooses among the users matched for a mention. A single candidate
+// wins outright; otherwise an exact login match is preferred, then an exact
+// first+last name match (@tom.smith or @tomsmith for Tom Smith). Anything
+// still ambiguous yields an *AmbiguousMentionError.
+func pickUser(name string, users []mentionUser) (mentionUser, error) {
+	if len(users) == 1 {
+		return users[0], nil
+	}
+
+	if u, ok := uniqueMatch(users, func(u mentionUser) bool {
+		return strings.EqualFold(u.Login, name)
+	}); ok {
+		return u, nil
+	}
+
+	if u, ok := uniqueMatch(users, func(u mentionUser) bool {
+		return strings.EqualFold(u.FirstName+"."+u.LastName, name) ||
+			strings.EqualFold(u.FirstName+u.LastName, name) ||
+			strings.EqualFold(u.FirstName+" "+u.LastName, name)
+	}); ok {
+		return u, nil
+	}
+
+	candidates := make([]string, len(users))
+	for i, u := range users {
+		candidates[i] = fmt.Sprintf("%s (%s)", u.Login, u.fullName())
+	}
+	return mentionUser{}, &AmbiguousMentionError{Name: name, Candidates: candidates}
+}
+
+// uniqueMatch returns the only user satisfying match, if exactly one does.
+func uniqueMatch(users []mentionUser, match func(mentionUser) bool) (mentionUser, bool) {
+	var found []mentionUser
+	for _, u := range users {
+		if match(u) {
+			found = append(found, u)
+		}
+	}
+	if len(found) != 1 {
+		return mentionUser{}, false
+	}
+	return found[0], true
 }
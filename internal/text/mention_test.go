@@ -1,8 +1,11 @@
 package text
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/lifedraft/targetprocess-cli/internal/api"
@@ -104,9 +107,9 @@ func TestResolveMentions(t *testing.T) {
 					Method: "GET",
 					Path:   "/api/v2/GeneralUser",
 					Query: map[string]string{
-						"where":  "login=='timo.litzius'",
+						"where":  `login=="timo.litzius"`,
 						"select": "{id,login,firstName,lastName}",
-						"take":   "1",
+						"take":   "10",
 					},
 				},
 				Response: testutil.Response{
@@ -120,9 +123,9 @@ func TestResolveMentions(t *testing.T) {
 					Method: "GET",
 					Path:   "/api/v2/GeneralUser",
 					Query: map[string]string{
-						"where":  "login=='timo'",
+						"where":  `login=="timo"`,
 						"select": "{id,login,firstName,lastName}",
-						"take":   "1",
+						"take":   "10",
 					},
 				},
 				Response: testutil.Response{
@@ -136,9 +139,9 @@ func TestResolveMentions(t *testing.T) {
 					Method: "GET",
 					Path:   "/api/v2/GeneralUser",
 					Query: map[string]string{
-						"where":  "login.contains('timo')",
+						"where":  `login.contains("timo")`,
 						"select": "{id,login,firstName,lastName}",
-						"take":   "1",
+						"take":   "10",
 					},
 				},
 				Response: testutil.Response{
@@ -152,9 +155,9 @@ func TestResolveMentions(t *testing.T) {
 					Method: "GET",
 					Path:   "/api/v2/GeneralUser",
 					Query: map[string]string{
-						"where":  "login=='unknown'",
+						"where":  `login=="unknown"`,
 						"select": "{id,login,firstName,lastName}",
-						"take":   "1",
+						"take":   "10",
 					},
 				},
 				Response: testutil.Response{
@@ -168,9 +171,9 @@ func TestResolveMentions(t *testing.T) {
 					Method: "GET",
 					Path:   "/api/v2/GeneralUser",
 					Query: map[string]string{
-						"where":  "login.contains('unknown')",
+						"where":  `login.contains("unknown")`,
 						"select": "{id,login,firstName,lastName}",
-						"take":   "1",
+						"take":   "10",
 					},
 				},
 				Response: testutil.Response{
@@ -184,9 +187,9 @@ func TestResolveMentions(t *testing.T) {
 					Method: "GET",
 					Path:   "/api/v2/GeneralUser",
 					Query: map[string]string{
-						"where":  "firstName.toLower()=='unknown'",
+						"where":  `firstName.toLower()=="unknown"`,
 						"select": "{id,login,firstName,lastName}",
-						"take":   "1",
+						"take":   "10",
 					},
 				},
 				Response: testutil.Response{
@@ -258,3 +261,125 @@ func TestResolveMentions(t *testing.T) {
 		}
 	})
 }
+
+func TestResolveMentions_Ambiguous(t *testing.T) {
+	users := func(us ...map[string]any) []byte {
+		data, err := json.Marshal(map[string]any{"items": us})
+		if err != nil {
+			t.Fatalf("failed to marshal users: %v", err)
+		}
+		return data
+	}
+	empty := users()
+	pair := func(where string, body []byte) testutil.Pair {
+		return testutil.Pair{
+			Description: where,
+			Request: testutil.Request{
+				Method: "GET",
+				Path:   "/api/v2/GeneralUser",
+				Query:  map[string]string{"where": where, "take": "10"},
+			},
+			Response: testutil.Response{Status: 200, Body: body},
+		}
+	}
+
+	sim := &testutil.Simulation{
+		Pairs: []testutil.Pair{
+			pair(`login=="tom"`, empty),
+			pair(`login.contains("tom")`, users(
+				map[string]any{"id": 1, "login": "tom.a", "firstName": "Tom", "lastName": "Adams"},
+				map[string]any{"id": 2, "login": "tom.b", "firstName": "Tom", "lastName": "Baker"},
+			)),
+			pair(`login=="tom.smith"`, empty),
+			pair(`login.contains("tom.smith")`, users(
+				map[string]any{"id": 3, "login": "tom.smithers", "firstName": "Tom", "lastName": "Smithers"},
+				map[string]any{"id": 4, "login": "tom.smith2", "firstName": "Tom", "lastName": "Smith"},
+			)),
+		},
+	}
+
+	ss := testutil.NewSimulationServer(sim)
+	defer ss.Close()
+
+	var warn bytes.Buffer
+	resolver := &UserResolver{Client: api.NewClient(ss.URL(), "test-token", false), Warn: &warn}
+	ctx := context.Background()
+
+	t.Run("full name breaks the tie", func(t *testing.T) {
+		got, err := resolver.ResolveMentions(ctx, "cc @tom.smith")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "cc @user:tom.smith2[Tom Smith]"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("ambiguous left unresolved with warning", func(t *testing.T) {
+		warn.Reset()
+		got, err := resolver.ResolveMentions(ctx, "cc @tom")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "cc @tom" {
+			t.Errorf("got %q, want mention unchanged", got)
+		}
+		if !strings.Contains(warn.String(), "tom.a (Tom Adams), tom.b (Tom Baker)") {
+			t.Errorf("warning should list candidates, got %q", warn.String())
+		}
+	})
+
+	t.Run("strict errors on ambiguity", func(t *testing.T) {
+		_, err := resolver.ResolveMentionsStrict(ctx, "cc @tom")
+		var ambErr *AmbiguousMentionError
+		if !errors.As(err, &ambErr) {
+			t.Fatalf("expected *AmbiguousMentionError, got %v", err)
+		}
+		if ambErr.Name != "tom" || len(ambErr.Candidates) != 2 {
+			t.Errorf("unexpected error details: %+v", ambErr)
+		}
+	})
+}
+
+func TestDisplayMentions(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "single resolved mention",
+			input: "Hey @user:timo.litzius[Timo Litzius]",
+			want:  "Hey @Timo Litzius",
+		},
+		{
+			name:  "multiple resolved mentions",
+			input: "@user:alice[Alice Smith] and @user:bob.jones[Bob Jones], please review",
+			want:  "@Alice Smith and @Bob Jones, please review",
+		},
+		{
+			name:  "plain mention untouched",
+			input: "ping @timo about it",
+			want:  "ping @timo about it",
+		},
+		{
+			name:  "mixed resolved and plain",
+			input: "@user:alice[Alice Smith] cc @bob",
+			want:  "@Alice Smith cc @bob",
+		},
+		{
+			name:  "email untouched",
+			input: "mail user@example.com",
+			want:  "mail user@example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DisplayMentions(tt.input)
+			if got != tt.want {
+				t.Errorf("DisplayMentions(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
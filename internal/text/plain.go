@@ -0,0 +1,45 @@
+package text
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	imageRe     = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	linkRe      = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	boldRe      = regexp.MustCompile(`(\*\*|__)(\S(?:.*?\S)?)(\*\*|__)`)
+	italicRe    = regexp.MustCompile(`(^|[^\w*])\*(\S(?:[^*]*\S)?)\*`)
+	codeRe      = regexp.MustCompile("`([^`]+)`")
+	headingRe   = regexp.MustCompile(`(?m)^#{1,6}\s+`)
+	quoteRe     = regexp.MustCompile(`(?m)^>\s?`)
+	htmlBreakRe = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</div>|</li>`)
+	htmlTagRe   = regexp.MustCompile(`<[^>]+>`)
+)
+
+// MarkdownToPlain converts a stored TargetProcess description into readable
+// plain text for terminal display. It drops the <!--markdown--> prefix, strips
+// basic markdown and HTML markup, and turns resolved @user:login[Full Name]
+// mentions back into @Full Name.
+func MarkdownToPlain(s string) string {
+	s = strings.TrimPrefix(s, markdownPrefix)
+	s = DisplayMentions(s)
+	s = imageRe.ReplaceAllString(s, "$1")
+	s = linkRe.ReplaceAllStringFunc(s, func(m string) string {
+		parts := linkRe.FindStringSubmatch(m)
+		if parts[1] == parts[2] {
+			return parts[1]
+		}
+		return parts[1] + " (" + parts[2] + ")"
+	})
+	s = codeRe.ReplaceAllString(s, "$1")
+	s = boldRe.ReplaceAllString(s, "$2")
+	s = italicRe.ReplaceAllString(s, "$1$2")
+	s = headingRe.ReplaceAllString(s, "")
+	s = quoteRe.ReplaceAllString(s, "")
+	s = htmlBreakRe.ReplaceAllString(s, "\n")
+	s = htmlTagRe.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,71 @@
+package text
+
+import "testing"
+
+func TestMarkdownToPlain(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "markdown prefix",
+			input: "<!--markdown-->hello world",
+			want:  "hello world",
+		},
+		{
+			name:  "bold",
+			input: "this is **important** and __also this__",
+			want:  "this is important and also this",
+		},
+		{
+			name:  "italic",
+			input: "an *emphasized* word",
+			want:  "an emphasized word",
+		},
+		{
+			name:  "link",
+			input: "see [the docs](https://example.com/docs)",
+			want:  "see the docs (https://example.com/docs)",
+		},
+		{
+			name:  "bare link text equals url",
+			input: "[https://example.com](https://example.com)",
+			want:  "https://example.com",
+		},
+		{
+			name:  "resolved mention",
+			input: "<!--markdown-->Hey @user:timo.litzius[Timo Litzius], thanks",
+			want:  "Hey @Timo Litzius, thanks",
+		},
+		{
+			name:  "heading and code",
+			input: "## Summary\nrun `tp query`",
+			want:  "Summary\nrun tp query",
+		},
+		{
+			name:  "html",
+			input: "<div>Fixed in <b>v2</b> &amp; released</div>",
+			want:  "Fixed in v2 & released",
+		},
+		{
+			name:  "plain mention untouched",
+			input: "ping @timo",
+			want:  "ping @timo",
+		},
+		{
+			name:  "snake case untouched",
+			input: "set max_retry_count",
+			want:  "set max_retry_count",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MarkdownToPlain(tt.input)
+			if got != tt.want {
+				t.Errorf("MarkdownToPlain(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
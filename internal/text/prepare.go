@@ -2,13 +2,25 @@ package text
 
 import (
 	"context"
+	"io"
 
 	"github.com/lifedraft/targetprocess-cli/internal/api"
 )
 
+// PrepareOptions controls how PrepareFields processes text fields.
+type PrepareOptions struct {
+	// SkipMentions passes @mentions through unchanged instead of looking
+	// each one up via the API.
+	SkipMentions bool
+
+	// Warn receives mention ambiguity warnings. Defaults to os.Stderr.
+	Warn io.Writer
+}
+
 // PrepareFields processes text fields in a TP entity field map before submission.
-// For Description fields, it resolves @mentions and prepends the markdown prefix.
-func PrepareFields(ctx context.Context, client *api.Client, fields map[string]any) error {
+// For Description fields, it resolves @mentions (unless opts.SkipMentions is set)
+// and prepends the markdown prefix.
+func PrepareFields(ctx context.Context, client *api.Client, fields map[string]any, opts PrepareOptions) error {
 	v, ok := fields["Description"]
 	if !ok {
 		return nil
@@ -18,7 +30,12 @@ func PrepareFields(ctx context.Context, client *api.Client, fields map[string]an
 		return nil
 	}
 
-	resolver := &UserResolver{Client: client}
+	if opts.SkipMentions {
+		fields["Description"] = EnsureMarkdown(desc)
+		return nil
+	}
+
+	resolver := &UserResolver{Client: client, Warn: opts.Warn}
 	resolved, err := resolver.ResolveMentions(ctx, desc)
 	if err != nil {
 		return err
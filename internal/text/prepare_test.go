@@ -0,0 +1,68 @@
+package text
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/lifedraft/targetprocess-cli/internal/api"
+	"github.com/lifedraft/targetprocess-cli/internal/testutil"
+)
+
+func TestPrepareFields(t *testing.T) {
+	userResponse, err := json.Marshal(map[string]any{
+		"items": []map[string]any{
+			{"id": 1, "login": "timo", "firstName": "Timo", "lastName": "Litzius"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal user response: %v", err)
+	}
+
+	ss := testutil.NewSimulationServer(&testutil.Simulation{
+		Pairs: []testutil.Pair{
+			{
+				Description: "exact login match for timo",
+				Request: testutil.Request{
+					Method: "GET",
+					Path:   "/api/v2/GeneralUser",
+					Query:  map[string]string{"where": `login=="timo"`},
+				},
+				Response: testutil.Response{Status: 200, Body: userResponse},
+			},
+		},
+	})
+	defer ss.Close()
+
+	client := api.NewClient(ss.URL(), "test-token", false)
+	ctx := context.Background()
+
+	t.Run("resolves mentions by default", func(t *testing.T) {
+		fields := map[string]any{"Description": "hi @timo"}
+		if err := PrepareFields(ctx, client, fields, PrepareOptions{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := "<!--markdown-->hi @user:timo[Timo Litzius]"
+		if fields["Description"] != want {
+			t.Errorf("got %q, want %q", fields["Description"], want)
+		}
+		if n := len(ss.Requests()); n != 1 {
+			t.Errorf("expected 1 user lookup, got %d", n)
+		}
+	})
+
+	t.Run("skips mention resolution", func(t *testing.T) {
+		before := len(ss.Requests())
+		fields := map[string]any{"Description": "hi @timo"}
+		if err := PrepareFields(ctx, client, fields, PrepareOptions{SkipMentions: true}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := "<!--markdown-->hi @timo"
+		if fields["Description"] != want {
+			t.Errorf("got %q, want %q", fields["Description"], want)
+		}
+		if n := len(ss.Requests()) - before; n != 0 {
+			t.Errorf("expected no API calls, got %d", n)
+		}
+	})
+}
@@ -11,6 +11,11 @@
 //	c, err := targetprocess.NewClient("yourcompany.tpondemand.com", "your-token")
 //	story, err := targetprocess.Get[targetprocess.UserStory](ctx, c, 12345)
 //	fmt.Println(story.Name, story.EntityState.Name)
+//
+// This package is the supported public API and follows semantic versioning:
+// exported identifiers are not removed or changed incompatibly within a major
+// version. Packages under internal/ back the tp CLI and may change at any
+// time.
 package targetprocess
 
 // Identifiable is implemented by any entity that has a numeric ID.
@@ -170,6 +175,7 @@ type Comment struct {
 	CreateDate   string   `json:"CreateDate,omitempty"`
 	Owner        *UserRef `json:"Owner,omitempty"`
 	General      *Ref     `json:"General,omitempty"`
+	ParentID     *int     `json:"ParentId,omitempty"`
 	ResourceType string   `json:"ResourceType,omitempty"`
 }
 
@@ -4,12 +4,18 @@ package integration_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bradleyjkemp/cupaloy/v2"
 
@@ -131,6 +137,25 @@ func TestQueryCollectionJSON(t *testing.T) {
 	cupaloy.SnapshotT(t, out)
 }
 
+func TestQuerySingleEntityJSONL(t *testing.T) {
+	ss := startServer(t, "query_single.json")
+	out := runTP(t, ss.URL(),
+		"query", "UserStory/342348",
+		"-s", "id,name,entityState.name as state",
+		"-o", "jsonl",
+	)
+	if strings.Count(out, "\n") != 1 || strings.Contains(out, `"items"`) {
+		t.Fatalf("want one line without an items envelope, got %q", out)
+	}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("line does not parse as JSON: %v", err)
+	}
+	if got["id"] != float64(342348) {
+		t.Errorf("id = %v, want 342348", got["id"])
+	}
+}
+
 func TestQuerySingleEntity(t *testing.T) {
 	ss := startServer(t, "query_single.json")
 	out := runTP(t, ss.URL(),
@@ -174,6 +199,354 @@ func TestShowJSON(t *testing.T) {
 	cupaloy.SnapshotT(t, out)
 }
 
+func TestShowYAML(t *testing.T) {
+	ss := startServer(t, "entity_get.json")
+	out := runTP(t, ss.URL(),
+		"show", "342348",
+		"--type", "UserStory",
+		"--output", "yaml",
+	)
+	for _, want := range []string{"Id: 342348\n", "ResourceType: UserStory\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.HasPrefix(out, "{") {
+		t.Errorf("output is JSON, want YAML:\n%s", out)
+	}
+}
+
+func TestQuietSuppressesHints(t *testing.T) {
+	ss := startServer(t, "entity_get.json")
+	stderrOf := func(env []string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command(testBinary, args...)
+		cmd.Env = append(os.Environ(), append([]string{"TP_DOMAIN=" + ss.URL(), "TP_TOKEN=test-token"}, env...)...)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			t.Fatalf("tp %s failed: %v\nstderr: %s", strings.Join(args, " "), err, stderr.String())
+		}
+		return stderr.String()
+	}
+
+	// stderr is not a terminal here, so the alias hint needs --verbose.
+	if out := stderrOf(nil, "get", "342348", "--type", "UserStory"); out != "" {
+		t.Errorf("without a terminal: stderr = %q, want nothing", out)
+	}
+	if out := stderrOf(nil, "--verbose", "get", "342348", "--type", "UserStory"); !strings.Contains(out, "alias") {
+		t.Errorf("--verbose: expected an alias hint, got stderr %q", out)
+	}
+	if out := stderrOf(nil, "--quiet", "get", "342348", "--type", "UserStory"); out != "" {
+		t.Errorf("--quiet: stderr = %q, want nothing", out)
+	}
+	if out := stderrOf([]string{"TP_QUIET=true"}, "get", "342348", "--type", "UserStory"); out != "" {
+		t.Errorf("TP_QUIET: stderr = %q, want nothing", out)
+	}
+}
+
+func TestVerboseLogsRequests(t *testing.T) {
+	ss := startServer(t, "entity_get.json")
+	cmd := exec.Command(testBinary, "--verbose", "show", "342348", "--type", "UserStory")
+	cmd.Env = append(os.Environ(), "TP_DOMAIN="+ss.URL(), "TP_TOKEN=test-token")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("tp --verbose show failed: %v\nstderr: %s", err, stderr.String())
+	}
+	out := stderr.String()
+	if !strings.Contains(out, "GET ") || !strings.Contains(out, ": HTTP 200, ") {
+		t.Errorf("expected a request line, got stderr %q", out)
+	}
+	if strings.Contains(out, "test-token") || strings.Contains(out, "DEBUG") {
+		t.Errorf("verbose output leaks the token or debug detail: %q", out)
+	}
+
+	if out := runTPExpectError(t, ss.URL(), "--quiet", "--verbose", "show", "342348"); !strings.Contains(out, "cannot be combined") {
+		t.Errorf("--quiet --verbose: stderr = %q", out)
+	}
+}
+
+func TestQueryWhereFileDryRun(t *testing.T) {
+	ss := startServer(t)
+	path := filepath.Join(t.TempDir(), "open.v2")
+	if err := os.WriteFile(path, []byte("# open bugs\nentityState.isFinal!=true\n  or effort>8\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out := runTP(t, ss.URL(), "query", "Bug", "--where-file", path, "-w", "effort>3", "--dry-run")
+	u, err := url.Parse(strings.TrimSpace(out))
+	if err != nil {
+		t.Fatalf("parsing dry-run URL %q: %v", out, err)
+	}
+	if got, want := u.Query().Get("where"), "(entityState.isFinal!=true or effort>8) and (effort>3)"; got != want {
+		t.Errorf("where = %q, want %q", got, want)
+	}
+}
+
+func TestQueryChangedSinceKeepsOrFilter(t *testing.T) {
+	ss := startServer(t)
+	out := runTP(t, ss.URL(), "query", "Bug", "-w", "effort>8 or priority.name==\"Urgent\"", "--changed-since", "7d", "--dry-run")
+	u, err := url.Parse(strings.TrimSpace(out))
+	if err != nil {
+		t.Fatalf("parsing dry-run URL %q: %v", out, err)
+	}
+	if got, want := u.Query().Get("where"), `(effort>8 or priority.name=="Urgent") and (modifyDate>=Today.AddDays(-7))`; got != want {
+		t.Errorf("where = %q, want %q", got, want)
+	}
+}
+
+// twoPageBugServer serves four bugs in two v2 pages, each carrying the
+// aliases the report modes select under.
+func twoPageBugServer(t *testing.T) *testutil.SimulationServer {
+	t.Helper()
+	ss := testutil.NewSimulationServer(&testutil.Simulation{Pairs: []testutil.Pair{
+		{
+			Request: testutil.Request{Method: "GET", Path: "/api/v2/Bug", Query: map[string]string{"skip": "2"}},
+			Response: testutil.Response{Status: 200, Body: []byte(`{"items":[
+				{"id":3,"groupKey":"Open","distinctValue":{"id":1,"name":"Open"}},
+				{"id":4,"groupKey":"Done","distinctValue":{"id":2,"name":"Done"}}]}`)},
+		},
+		{
+			Request: testutil.Request{Method: "GET", Path: "/api/v2/Bug", AbsentQuery: []string{"skip"}},
+			Response: testutil.Response{Status: 200, Body: []byte(`{"items":[
+				{"id":1,"groupKey":"Open","distinctValue":{"id":1,"name":"Open"}},
+				{"id":2,"groupKey":"Open","distinctValue":{"id":1,"name":"Open"}}],
+				"next":"` + testutil.BaseURLPlaceholder + `/api/v2/Bug?take=2&skip=2"}`)},
+		},
+	}})
+	t.Cleanup(ss.Close)
+	return ss
+}
+
+func TestQueryGroupByReadsEveryPage(t *testing.T) {
+	ss := twoPageBugServer(t)
+	out := runTP(t, ss.URL(), "query", "Bug", "--group-by", "entityState.name", "-o", "json")
+
+	var got struct {
+		Groups []struct {
+			Group string `json:"group"`
+			Count int    `json:"count"`
+		} `json:"groups"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("parsing %q: %v", out, err)
+	}
+	if len(got.Groups) != 2 || got.Groups[0].Group != "Open" || got.Groups[0].Count != 3 || got.Groups[1].Count != 1 {
+		t.Errorf("groups = %+v, want Open 3 and Done 1 across both pages", got.Groups)
+	}
+	if reqs := ss.Requests(); len(reqs) != 2 || reqs[0].Query.Get("take") != "1000" {
+		t.Errorf("requests = %+v, want two pages starting at take=1000", reqs)
+	}
+}
+
+func TestQueryDistinctReadsEveryPage(t *testing.T) {
+	ss := twoPageBugServer(t)
+	out := runTP(t, ss.URL(), "query", "Bug", "--distinct", "entityState.name", "-o", "json")
+
+	var got []struct {
+		Value string `json:"value"`
+		Count int    `json:"count"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("parsing %q: %v", out, err)
+	}
+	if len(got) != 2 || got[0].Value != "Done" || got[0].Count != 1 || got[1].Value != "Open" || got[1].Count != 3 {
+		t.Errorf("distinct = %+v, want Done 1 and Open 3 across both pages", got)
+	}
+}
+
+func TestWherePresetKeepsOrFilter(t *testing.T) {
+	ss := startServer(t)
+	want := `(entityState.isInitial==true) and (effort>8 or priority.name=="Urgent")`
+	for _, command := range []string{"query", "search"} {
+		out := runTP(t, ss.URL(), command, "Bug", "--where-preset", "open", "-w", `effort>8 or priority.name=="Urgent"`, "--dry-run")
+		u, err := url.Parse(strings.TrimSpace(out))
+		if err != nil {
+			t.Fatalf("%s: parsing dry-run URL %q: %v", command, out, err)
+		}
+		if got := u.Query().Get("where"); got != want {
+			t.Errorf("%s: where = %q, want %q", command, got, want)
+		}
+	}
+}
+
+func TestUserPresetsFromConfig(t *testing.T) {
+	ss := startServer(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "presets:\n  coreTriage:\n    description: Core team triage\n    where: team.name==\"Core\" and severity==null\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out := runTP(t, ss.URL(), "--config", path, "presets")
+	if !regexp.MustCompile(`coreTriage\s+config\s+Core team triage`).MatchString(out) {
+		t.Errorf("tp presets does not list the config preset:\n%s", out)
+	}
+
+	out = runTP(t, ss.URL(), "--config", path, "query", "Bug", "--where-preset", "coreTriage,open", "--dry-run")
+	u, err := url.Parse(strings.TrimSpace(out))
+	if err != nil {
+		t.Fatalf("parsing dry-run URL %q: %v", out, err)
+	}
+	if got, want := u.Query().Get("where"), `(team.name=="Core" and severity==null) and (entityState.isInitial==true)`; got != want {
+		t.Errorf("where = %q, want %q", got, want)
+	}
+}
+
+func TestBrokenUserPresetOnlyFailsPresetCommands(t *testing.T) {
+	ss := startServer(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("presets:\n  core triage:\n    where: team.name==\"Core\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	runTP(t, ss.URL(), "--config", path, "config", "set", "timezone", "Europe/Berlin")
+
+	stderr := runTPExpectError(t, ss.URL(), "--config", path, "presets")
+	if !strings.Contains(stderr, `invalid preset name "core triage"`) {
+		t.Errorf("tp presets stderr = %q, want the invalid preset name", stderr)
+	}
+	stderr = runTPExpectError(t, ss.URL(), "--config", path, "search", "Bug", "--preset", "open", "--dry-run")
+	if !strings.Contains(stderr, `invalid preset name "core triage"`) {
+		t.Errorf("tp search --preset stderr = %q, want the invalid preset name", stderr)
+	}
+
+	cmd := exec.Command(testBinary, "--config", path, "config", "doctor", "-o", "json")
+	cmd.Env = append(os.Environ(), "TP_DOMAIN="+ss.URL(), "TP_TOKEN=test-token")
+	out, _ := cmd.Output()
+	if !strings.Contains(string(out), `"name": "presets"`) || !strings.Contains(string(out), `invalid preset name`) {
+		t.Errorf("doctor output does not report the preset:\n%s", out)
+	}
+}
+
+func TestConfigSetKeepsEnvPasswordOutOfFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	cmd := exec.Command(testBinary, "--config", path, "config", "set", "domain", "https://example.tpondemand.com")
+	cmd.Env = append(os.Environ(), "TP_PASSWORD=env-password", "TP_TOKEN=env-token")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("tp config set domain: %v\n%s", err, out)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "env-password") || strings.Contains(string(data), "env-token") {
+		t.Errorf("config file contains secrets from the environment:\n%s", data)
+	}
+}
+
+func TestWatchStateFileFollowsConfig(t *testing.T) {
+	ss := startServer(t)
+	dir := t.TempDir()
+	runTP(t, ss.URL(), "--config", filepath.Join(dir, "work.yaml"), "watch-changes", "--type", "Bug", "--once")
+
+	if _, err := os.Stat(filepath.Join(dir, "watch-state.json")); err != nil {
+		t.Errorf("watch state not kept next to the --config file: %v", err)
+	}
+}
+
+func TestUpdateSendsWhenDiffIsEmpty(t *testing.T) {
+	ss := testutil.NewSimulationServer(&testutil.Simulation{Pairs: []testutil.Pair{
+		{
+			Request:  testutil.Request{Method: "GET", Path: "/api/v1/Bugs/123"},
+			Response: testutil.Response{Status: 200, Body: []byte(`{"Id":123,"Name":"Same name"}`)},
+		},
+		{
+			Request:  testutil.Request{Method: "POST", Path: "/api/v1/Bugs/123"},
+			Response: testutil.Response{Status: 200, Body: []byte(`{"Id":123,"Name":"Same name"}`)},
+		},
+	}})
+	t.Cleanup(ss.Close)
+
+	runTP(t, ss.URL(), "update", "123", "--type", "Bug", "--name", "Same name")
+
+	posted := false
+	for _, r := range ss.Requests() {
+		posted = posted || r.Method == "POST"
+	}
+	if !posted {
+		t.Errorf("update with no visible differences was not sent; requests: %+v", ss.Requests())
+	}
+}
+
+func TestQueryExplain(t *testing.T) {
+	ss := startServer(t)
+	// Even with --version-check, which otherwise asks the server for its version.
+	out := runTP(t, ss.URL(), "--version-check", "query", "bugs", "-s", "id,project.name", "-w", "effort>3", "--order", "id desc", "--include", "project", "--explain")
+	for _, want := range []string{`Entity type:  Bug (from "bugs")`, "-> dropped (no alias)", "project.name as project  -> key project", "Where:        effort>3", "Order by:     id desc", "URL:          " + ss.URL() + "/api/v2/Bug?"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "test-token") {
+		t.Errorf("explain output leaks the token:\n%s", out)
+	}
+	if n := len(ss.Requests()); n != 0 {
+		t.Errorf("--explain sent %d requests, want none", n)
+	}
+}
+
+func TestSearchExplainResolvesNoUsers(t *testing.T) {
+	ss := startServer(t)
+	out := runTP(t, ss.URL(), "--version-check", "search", "Bug", "--created-by", "timo", "--modified-by", "42", "--explain")
+	if want := `(owner.id==<id of timo>) and (lastEditor.id==42)`; !strings.Contains(out, want) {
+		t.Errorf("output missing %q:\n%s", want, out)
+	}
+	if n := len(ss.Requests()); n != 0 {
+		t.Errorf("--explain sent %d requests, want none", n)
+	}
+}
+
+func TestQueryExplainErrors(t *testing.T) {
+	ss := startServer(t)
+	stderr := runTPExpectError(t, ss.URL(), "query", "Bug", "-w", "description is null", "--explain-errors")
+	if !strings.Contains(stderr, "--- explain: matching error patterns ---") {
+		t.Errorf("stderr missing the pattern listing:\n%s", stderr)
+	}
+
+	stderr = runTPExpectError(t, ss.URL(), "query", "Bug", "-w", "description is null")
+	if strings.Contains(stderr, "--- explain:") {
+		t.Errorf("pattern listing shown without --explain-errors:\n%s", stderr)
+	}
+}
+
+func TestQueryAutoQuoteDryRun(t *testing.T) {
+	ss := startServer(t)
+	out := runTP(t, ss.URL(),
+		"query", "Bug", "-w", "entityState.name==In Progress and effort>3", "--auto-quote", "--dry-run",
+	)
+	u, err := url.Parse(strings.TrimSpace(out))
+	if err != nil {
+		t.Fatalf("parsing dry-run URL %q: %v", out, err)
+	}
+	if got, want := u.Query().Get("where"), `entityState.name=="In Progress" and effort>3`; got != want {
+		t.Errorf("where = %q, want %q", got, want)
+	}
+}
+
+func TestQueryMisspelledTypeSuggests(t *testing.T) {
+	ss := startServer(t)
+	out := runTPExpectError(t, ss.URL(), "query", "UserStorie")
+	if !strings.Contains(out, `did you mean "UserStory"?`) {
+		t.Errorf("stderr = %q, want a did-you-mean suggestion", out)
+	}
+	if n := len(ss.Requests()); n != 0 {
+		t.Errorf("made %d requests, want none", n)
+	}
+}
+
+func TestShowNoDetectRequiresType(t *testing.T) {
+	ss := startServer(t, "entity_get.json")
+	out := runTPExpectError(t, ss.URL(), "show", "342348", "--no-detect")
+	if !strings.Contains(out, "--no-detect requires --type") {
+		t.Errorf("stderr = %q, want a --no-detect error", out)
+	}
+	if n := len(ss.Requests()); n != 0 {
+		t.Errorf("sent %d requests, want none", n)
+	}
+}
+
 func TestSearch(t *testing.T) {
 	ss := startServer(t, "entity_search.json")
 	out := runTP(t, ss.URL(),
@@ -225,6 +598,92 @@ func TestInspectProperties(t *testing.T) {
 	cupaloy.SnapshotT(t, out)
 }
 
+func TestInspectPropertiesXMLIsVerbatim(t *testing.T) {
+	ss := startServer(t, "inspect_properties.json")
+	out := runTP(t, ss.URL(),
+		"inspect", "properties",
+		"--type", "UserStory",
+		"--output", "xml",
+	)
+	sim, err := testutil.LoadSimulation(filepath.Join(projectRoot(), "testdata", "simulations", "inspect_properties.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := string(sim.Pairs[0].Response.BodyBytes())
+	if strings.TrimRight(out, "\n") != strings.TrimRight(want, "\n") {
+		t.Errorf("-o xml output differs from the metadata the server sent:\n%s", out)
+	}
+}
+
+func TestInspectPropertiesJSONIsFiltered(t *testing.T) {
+	ss := startServer(t, "inspect_properties.json")
+	out := runTP(t, ss.URL(),
+		"inspect", "properties",
+		"--type", "UserStory",
+		"--filter", "DATE",
+		"--settable",
+		"--output", "json",
+	)
+	var resp struct {
+		Properties []struct {
+			Name   string `json:"name"`
+			CanSet string `json:"canSet"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	var names []string
+	for _, p := range resp.Properties {
+		if p.CanSet != "true" {
+			t.Errorf("%s is not settable but was listed", p.Name)
+		}
+		names = append(names, p.Name)
+	}
+	want := "StartDate,EndDate,ModifyDate,LastCommentDate,PlannedStartDate,PlannedEndDate"
+	if got := strings.Join(names, ","); got != want {
+		t.Errorf("properties = %s, want %s", got, want)
+	}
+}
+
+func TestInspectPrioritiesByType(t *testing.T) {
+	ss := startServer(t, "inspect_priorities.json")
+	out := runTP(t, ss.URL(), "inspect", "priorities", "--type", "bug")
+	for _, want := range []string{"IMPORTANCE", "Fix ASAP", "Fix If Time", "Bug"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestCreateFromCSVDryRun(t *testing.T) {
+	ss := startServer(t)
+	csvPath := filepath.Join(t.TempDir(), "stories.csv")
+	if err := os.WriteFile(csvPath, []byte("Name,Effort\nLogin page,3\nSignup page,5\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out := runTP(t, ss.URL(), "create", "--from-csv", csvPath, "--type", "UserStory", "--project-id", "42", "--dry-run")
+	if n := strings.Count(out, "POST /api/v1/UserStorys"); n != 2 {
+		t.Errorf("expected 2 requests, got %d:\n%s", n, out)
+	}
+	for _, want := range []string{`"Name": "Signup page"`, `"Effort": 5`, `"Id": 42`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s:\n%s", want, out)
+		}
+	}
+}
+
+func TestCreateCustomFieldsDryRun(t *testing.T) {
+	ss := startServer(t)
+	out := runTP(t, ss.URL(), "create", "Bug", "Crash", "--project-id", "42", "--dry-run",
+		"--custom", "Severity Score=8", "--custom", "Notes=Seen on a, b", "--custom", `Ticket="00123"`)
+	for _, want := range []string{`"Name": "Severity Score"`, `"Value": 8`, `"Value": "Seen on a, b"`, `"Value": "00123"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s:\n%s", want, out)
+		}
+	}
+}
+
 // --- Comment command tests ---
 
 func TestCommentList(t *testing.T) {
@@ -233,6 +692,12 @@ func TestCommentList(t *testing.T) {
 	cupaloy.SnapshotT(t, out)
 }
 
+func TestCommentListAuthor(t *testing.T) {
+	ss := startServer(t, "comment_list.json")
+	out := runTP(t, ss.URL(), "comment", "list", "342236", "--author", "mueller")
+	cupaloy.SnapshotT(t, out)
+}
+
 func TestCommentAdd(t *testing.T) {
 	ss := startServer(t, "comment_add.json")
 	out := runTP(t, ss.URL(), "comment", "add", "342236", "New comment added")
@@ -245,6 +710,169 @@ func TestCommentDelete(t *testing.T) {
 	cupaloy.SnapshotT(t, out)
 }
 
+func TestQueryAllFollowsNextPages(t *testing.T) {
+	items := []map[string]any{
+		{"id": 1, "name": "one"}, {"id": 2, "name": "two"}, {"id": 3, "name": "three"},
+	}
+	sim, err := testutil.PagedSimulation("/api/v2/UserStory", 2, items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss := testutil.NewSimulationServer(sim)
+	t.Cleanup(ss.Close)
+
+	out := runTP(t, ss.URL(), "query", "UserStory", "-s", "id,name", "--take", "2", "--all", "-o", "jsonl")
+	if lines := strings.Count(out, "\n"); lines != len(items) {
+		t.Errorf("got %d lines, want %d:\n%s", lines, len(items), out)
+	}
+}
+
+func TestExportWritesEveryPage(t *testing.T) {
+	items := []map[string]any{
+		{"id": 1, "name": "one", "project": map[string]any{"name": "Web"}},
+		{"id": 2, "name": "two, too", "project": map[string]any{"name": "Web"}},
+		{"id": 3, "name": "three", "project": map[string]any{"name": "App"}},
+	}
+	sim, err := testutil.PagedSimulation("/api/v2/UserStory", 2, items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss := testutil.NewSimulationServer(sim)
+	t.Cleanup(ss.Close)
+
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "stories.csv")
+	runTP(t, ss.URL(), "export", "UserStory", "-s", "id,name,project", "--take", "2", "--file", csvPath)
+	data, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "id,name,project.name\n1,one,Web\n2,\"two, too\",Web\n3,three,App\n"
+	if string(data) != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", data, want)
+	}
+
+	jsonPath := filepath.Join(dir, "stories.json")
+	runTP(t, ss.URL(), "export", "UserStory", "-s", "id,name,project", "--take", "2", "--file", jsonPath)
+	data, err = os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("export JSON does not parse: %v\n%s", err, data)
+	}
+	if len(got) != len(items) {
+		t.Errorf("got %d items, want %d", len(got), len(items))
+	}
+}
+
+func TestCommentDeleteConfirmRefusesWithoutTTY(t *testing.T) {
+	ss := testutil.NewSimulationServer(&testutil.Simulation{Pairs: []testutil.Pair{{
+		Request:  testutil.Request{Method: "GET", Path: "/api/v1/Comments/1001"},
+		Response: testutil.Response{Status: 200, Body: []byte(`{"Id":1001,"Description":"Old note","ResourceType":"Comment"}`)},
+	}}})
+	t.Cleanup(ss.Close)
+
+	out := runTPExpectError(t, ss.URL(), "comment", "delete", "1001", "--confirm")
+	if !strings.Contains(out, `Comment #1001 "Old note"`) || !strings.Contains(out, "pass --yes") {
+		t.Errorf("stderr = %q, want the comment summary and a --yes hint", out)
+	}
+	for _, r := range ss.Requests() {
+		if r.Method == "DELETE" {
+			t.Error("comment was deleted without confirmation")
+		}
+	}
+}
+
+func TestTimeoutAbortsSlowRequest(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	t.Cleanup(slow.Close)
+
+	start := time.Now()
+	out := runTPExpectError(t, slow.URL, "--timeout", "200ms", "whoami")
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("tp --timeout 200ms took %s", elapsed)
+	}
+	if !strings.Contains(out, "deadline exceeded") || !strings.Contains(out, "--timeout") {
+		t.Errorf("stderr = %q, want a deadline error mentioning --timeout", out)
+	}
+}
+
+func TestConfigDoctor(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/Context" {
+			return
+		}
+		if r.URL.Query().Get("access_token") != "test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"LoggedUser":{"Id":7,"Login":"jdoe"}}`)) //nolint:errcheck,gosec // test server
+	}))
+	t.Cleanup(srv.Close)
+
+	doctor := func(token string) (string, error) {
+		t.Helper()
+		cmd := exec.Command(testBinary, "config", "doctor")
+		cmd.Env = append(os.Environ(), "HOME="+t.TempDir(), "TP_DOMAIN="+srv.URL, "TP_TOKEN="+token)
+		var stdout bytes.Buffer
+		cmd.Stdout = &stdout
+		err := cmd.Run()
+		return stdout.String(), err
+	}
+
+	out, err := doctor("test-token")
+	if err != nil {
+		t.Fatalf("tp config doctor failed: %v\nstdout: %s", err, out)
+	}
+	for _, want := range []string{"PASS  token        set (source: env)", "PASS  connection", "PASS  auth         authenticated as jdoe"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	out, err = doctor("stale-token")
+	if err == nil {
+		t.Fatal("expected tp config doctor to fail with rejected credentials")
+	}
+	if !strings.Contains(out, "FAIL  auth         the credentials were rejected (HTTP 401)") || !strings.Contains(out, "hint: create a new access token") {
+		t.Errorf("expected a failed auth check with a hint, got:\n%s", out)
+	}
+}
+
+// --- Dry-run tests ---
+
+func TestDryRunSendsNothing(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"create", "Bug", "Fix typo", "--project-id", "42", "--dry-run"}, "POST /api/v1/Bugs\n"},
+		{[]string{"update", "123", "--type", "Bug", "--name", "Renamed", "--dry-run"}, "POST /api/v1/Bugs/123\n"},
+		{[]string{"comment", "delete", "1001", "--dry-run"}, "DELETE /api/v1/Comments/1001\nWould delete comment 1001\n"},
+	}
+	for _, tt := range tests {
+		ss := startServer(t)
+		out := runTP(t, ss.URL(), tt.args...)
+		if !strings.HasPrefix(out, tt.want) {
+			t.Errorf("tp %s output = %q, want prefix %q", strings.Join(tt.args, " "), out, tt.want)
+		}
+		// Lookups such as type metadata may still be read; nothing is written.
+		for _, r := range ss.Requests() {
+			if r.Method != "GET" {
+				t.Errorf("tp %s sent %s %s, want no writes", strings.Join(tt.args, " "), r.Method, r.Path)
+			}
+		}
+	}
+}
+
 // --- Error scenario tests ---
 
 func TestQueryMissingEntityType(t *testing.T) {